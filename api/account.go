@@ -7,6 +7,7 @@ import (
 
 	db "github.com/Cell6969/go_bank/db/sqlc"
 	"github.com/Cell6969/go_bank/token"
+	"github.com/Cell6969/go_bank/util"
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
 )
@@ -27,6 +28,18 @@ func (server *Server) createAccount(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !user.IsEmailVerified {
+		err := errors.New("email must be verified before creating an account")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
 	arg := db.CreateAccountParams{
 		Owner:    authPayload.Username,
 		Balance:  0,
@@ -73,7 +86,7 @@ func (server *Server) getAccount(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	if account.Owner != authPayload.Username {
+	if account.Owner != authPayload.Username && !authPayload.HasRole(util.StaffRoles...) {
 		err := errors.New("Request forbidden")
 		ctx.JSON(http.StatusForbidden, errorResponse(err))
 		return
@@ -84,8 +97,13 @@ func (server *Server) getAccount(ctx *gin.Context) {
 
 // For List Account
 type listAccountRequest struct {
-	Page     int32 `form:"page" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=100"`
+	PageToken string `form:"page_token"`
+}
+
+type listAccountResponse struct {
+	Accounts      []db.Account `json:"accounts"`
+	NextPageToken string       `json:"next_page_token"`
 }
 
 func (server *Server) listAccount(ctx *gin.Context) {
@@ -95,12 +113,20 @@ func (server *Server) listAccount(ctx *gin.Context) {
 		return
 	}
 
+	cursorCreatedAt, cursorID, err := util.DecodePageToken(req.PageToken)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
+	// fetch one extra row to find out whether there is a next page
 	arg := db.ListAccountParams{
-		Owner:  authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: (req.Page - 1) * req.PageSize,
+		Owner:           authPayload.Username,
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		PageSize:        req.PageSize + 1,
 	}
 
 	accounts, err := server.store.ListAccount(ctx, arg)
@@ -109,5 +135,32 @@ func (server *Server) listAccount(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, accounts)
+	response := listAccountResponse{
+		Accounts: accounts,
+	}
+	if len(accounts) > int(req.PageSize) {
+		response.Accounts = accounts[:req.PageSize]
+		last := response.Accounts[req.PageSize-1]
+		response.NextPageToken = util.EncodePageToken(last.CreatedAt, last.ID)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// authorizedAccount loads the account and makes sure it belongs to the authenticated user,
+// bank staff may look at any account
+func (server *Server) authorizedAccount(ctx *gin.Context, accountID int64) (db.Account, bool) {
+	account, ok := server.findAccount(ctx, accountID)
+	if !ok {
+		return account, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if account.Owner != authPayload.Username && !authPayload.HasRole(util.StaffRoles...) {
+		err := errors.New("Request forbidden")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return account, false
+	}
+
+	return account, true
 }
@@ -35,7 +35,40 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "OK",
 			accountID: account.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccount(t, recorder.Body, account)
+			},
+		},
+		{
+			name:      "Unauthorized User",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "Support Staff",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "support_user", util.SupportRole, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -52,7 +85,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "Not Found",
 			accountID: account.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -68,7 +101,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "Internal Error",
 			accountID: account.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -84,7 +117,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "Invalid ID",
 			accountID: 0,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -125,6 +158,7 @@ func TestGetAccountAPI(t *testing.T) {
 
 func TestCreateAccount(t *testing.T) {
 	user, _ := randomUser(t)
+	user.IsEmailVerified = true
 	account := randomAccount(user.Username)
 
 	testCases := []struct {
@@ -141,7 +175,7 @@ func TestCreateAccount(t *testing.T) {
 				"currency": account.Currency,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.CreateAccountParams{
@@ -150,6 +184,10 @@ func TestCreateAccount(t *testing.T) {
 					Balance:  0,
 				}
 
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					CreateAccount(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
@@ -160,6 +198,31 @@ func TestCreateAccount(t *testing.T) {
 				requireBodyMatchAccount(t, recorder.Body, account)
 			},
 		},
+		{
+			name: "Email Not Verified",
+			body: gin.H{
+				"owner":    account.Owner,
+				"currency": account.Currency,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				unverifiedUser := user
+				unverifiedUser.IsEmailVerified = false
+
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(unverifiedUser, nil)
+				store.EXPECT().
+					CreateAccount(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -195,14 +258,17 @@ func TestListAccount(t *testing.T) {
 	n := 5
 
 	user, _ := randomUser(t)
-	accounts := make([]db.Account, n)
-	for i := 0; i < 5; i++ {
+	accounts := make([]db.Account, n+1)
+	for i := range accounts {
 		accounts[i] = randomAccount(user.Username)
+		accounts[i].CreatedAt = time.Now().UTC().Truncate(time.Microsecond).Add(time.Duration(i) * time.Second)
 	}
 
+	nextPageToken := util.EncodePageToken(accounts[n-1].CreatedAt, accounts[n-1].ID)
+
 	type Query struct {
-		page     int
-		pageSize int
+		pageSize  int
+		pageToken string
 	}
 
 	testCases := []struct {
@@ -215,27 +281,89 @@ func TestListAccount(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				page:     1,
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListAccountParams{
-					Owner:  user.Username,
-					Limit:  int32(n),
-					Offset: 0,
+					Owner:    user.Username,
+					PageSize: int32(n + 1),
 				}
 
 				store.EXPECT().
 					ListAccount(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
+					Return(accounts[:n], nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccounts(t, recorder.Body, accounts[:n], "")
+			},
+		},
+		{
+			name: "Has Next Page",
+			query: Query{
+				pageSize: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAccount(gomock.Any(), gomock.Any()).
+					Times(1).
 					Return(accounts, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchAccounts(t, recorder.Body, accounts)
+				requireBodyMatchAccounts(t, recorder.Body, accounts[:n], nextPageToken)
+			},
+		},
+		{
+			name: "With Page Token",
+			query: Query{
+				pageSize:  n,
+				pageToken: nextPageToken,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountParams{
+					Owner:           user.Username,
+					CursorCreatedAt: accounts[n-1].CreatedAt,
+					CursorID:        accounts[n-1].ID,
+					PageSize:        int32(n + 1),
+				}
+
+				store.EXPECT().
+					ListAccount(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(accounts[n:], nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccounts(t, recorder.Body, accounts[n:], "")
+			},
+		},
+		{
+			name: "Invalid Page Token",
+			query: Query{
+				pageSize:  n,
+				pageToken: "invalid",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAccount(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 	}
@@ -258,8 +386,10 @@ func TestListAccount(t *testing.T) {
 			require.NoError(t, err)
 
 			q := request.URL.Query()
-			q.Add("page", fmt.Sprintf("%d", tc.query.page))
 			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.pageToken != "" {
+				q.Add("page_token", tc.query.pageToken)
+			}
 			request.URL.RawQuery = q.Encode()
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -275,6 +405,7 @@ func randomAccount(username string) db.Account {
 		Owner:    username,
 		Balance:  util.GenerateRandomMoney(),
 		Currency: util.GenerateRandomCurrency(),
+		Status:   db.AccountActive,
 	}
 }
 
@@ -289,12 +420,13 @@ func requireBodyMatchAccount(t *testing.T, body *bytes.Buffer, account db.Accoun
 	require.Equal(t, account, responseAccount)
 }
 
-func requireBodyMatchAccounts(t *testing.T, body *bytes.Buffer, accounts []db.Account) {
+func requireBodyMatchAccounts(t *testing.T, body *bytes.Buffer, accounts []db.Account, nextPageToken string) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var responseAccounts []db.Account
-	err = json.Unmarshal(data, &responseAccounts)
+	var response listAccountResponse
+	err = json.Unmarshal(data, &response)
 	require.NoError(t, err)
-	require.Equal(t, accounts, responseAccounts)
+	require.Equal(t, accounts, response.Accounts)
+	require.Equal(t, nextPageToken, response.NextPageToken)
 }
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/gin-gonic/gin"
+)
+
+type listEntriesQuery struct {
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=100"`
+	PageToken string `form:"page_token"`
+}
+
+type listEntriesResponse struct {
+	Entries       []db.Entry `json:"entries"`
+	NextPageToken string     `json:"next_page_token"`
+}
+
+func (server *Server) listEntries(ctx *gin.Context) {
+	var uri accountUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var query listEntriesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cursorCreatedAt, cursorID, err := util.DecodePageToken(query.PageToken)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, ok := server.authorizedAccount(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	arg := db.ListEntriesParams{
+		AccountID:       account.ID,
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		PageSize:        query.PageSize + 1,
+	}
+
+	entries, err := server.store.ListEntries(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := listEntriesResponse{
+		Entries: entries,
+	}
+	if len(entries) > int(query.PageSize) {
+		response.Entries = entries[:query.PageSize]
+		last := response.Entries[query.PageSize-1]
+		response.NextPageToken = util.EncodePageToken(last.CreatedAt, last.ID)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -17,7 +17,7 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 		TokenDuration: time.Minute,
 	}
 
-	server, err := NewServer(config, store)
+	server, err := NewServer(config, store, nil)
 	require.NoError(t, err)
 	return server
 }
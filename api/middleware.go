@@ -16,7 +16,8 @@ const (
 	authorizationPayloadKey = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+// authMiddleware authenticates the request, when accessibleRoles are given the user must have one of them
+func authMiddleware(tokenMaker token.Maker, accessibleRoles ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		if len(authorizationHeader) == 0 {
@@ -46,6 +47,12 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 
+		if len(accessibleRoles) > 0 && !payload.HasRole(accessibleRoles...) {
+			err := fmt.Errorf("role %s is not allowed to access this resource", payload.Role)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+
 		ctx.Set(authorizationPayloadKey, payload)
 		ctx.Next()
 	}
@@ -6,6 +6,7 @@ import (
 	db "github.com/Cell6969/go_bank/db/sqlc"
 	"github.com/Cell6969/go_bank/token"
 	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/worker"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
@@ -13,23 +14,32 @@ import (
 
 // Server serves HTTP request for banking service
 type Server struct {
-	config     util.Config
-	store      db.Store
-	tokenMaker token.Maker
-	router     *gin.Engine
+	config          util.Config
+	store           db.Store
+	tokenMaker      token.Maker
+	fxRateProvider  util.FXRateProvider
+	taskDistributor worker.TaskDistributor
+	router          *gin.Engine
 }
 
 // Create New Server instance
-func NewServer(config util.Config, store db.Store) (*Server, error) {
+func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.TokenKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	fxRateProvider, err := util.LoadFXRateProvider(config.FXRatesFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create fx rate provider: %w", err)
+	}
+
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:          config,
+		store:           store,
+		tokenMaker:      tokenMaker,
+		fxRateProvider:  fxRateProvider,
+		taskDistributor: taskDistributor,
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
@@ -50,6 +60,7 @@ func (server *Server) setupRouter() {
 	router.POST("/users", server.createUser)
 	router.POST("/users/login", server.loginUser)
 	router.POST("/token/renew", server.renewToken)
+	router.GET("/verify_email", server.verifyEmail)
 
 	// Add Middleware
 	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
@@ -58,6 +69,9 @@ func (server *Server) setupRouter() {
 	authRoutes.POST("/accounts", server.createAccount)
 	authRoutes.GET("/accounts", server.listAccount)
 	authRoutes.GET("/accounts/:id", server.getAccount)
+	authRoutes.GET("/accounts/:id/statement", server.getAccountStatement)
+	authRoutes.GET("/accounts/:id/entries", server.listEntries)
+	authRoutes.GET("/accounts/:id/transfers", server.listTransfers)
 
 	// Transfer Route
 	authRoutes.POST("/transfers", server.createTransfer)
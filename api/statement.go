@@ -0,0 +1,88 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"github.com/gin-gonic/gin"
+)
+
+type accountUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type accountStatementQuery struct {
+	FromTime  time.Time `form:"from_time" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	ToTime    time.Time `form:"to_time" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	PageSize  int32     `form:"page_size" binding:"required,min=5,max=100"`
+	PageToken string    `form:"page_token"`
+}
+
+type accountStatementResponse struct {
+	db.AccountStatementTxResult
+	NextPageToken string `json:"next_page_token"`
+}
+
+func (server *Server) getAccountStatement(ctx *gin.Context) {
+	var uri accountUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var query accountStatementQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := valid.ValidateStatementPeriod(query.FromTime, query.ToTime); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cursorCreatedAt, cursorID, err := util.DecodePageToken(query.PageToken)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, ok := server.authorizedAccount(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	arg := db.AccountStatementTxParams{
+		AccountID:       account.ID,
+		FromTime:        query.FromTime.UTC(),
+		ToTime:          query.ToTime.UTC(),
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		PageSize:        query.PageSize + 1,
+	}
+
+	statement, err := server.store.AccountStatementTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := accountStatementResponse{
+		AccountStatementTxResult: statement,
+	}
+	if len(statement.Lines) > int(query.PageSize) {
+		response.Lines = statement.Lines[:query.PageSize]
+		last := response.Lines[query.PageSize-1]
+		response.NextPageToken = util.EncodePageToken(last.CreatedAt, last.EntryID)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
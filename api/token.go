@@ -1,11 +1,15 @@
 package api
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	db "github.com/Cell6969/go_bank/db/sqlc"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type renewTokenRequest struct {
@@ -13,10 +17,15 @@ type renewTokenRequest struct {
 }
 
 type renewTokenResponse struct {
-	Token          string    `json:"_token"`
-	TokenExpiredAt time.Time `json:"token_expired_at"`
+	SessionID             uuid.UUID `json:"session"`
+	Token                 string    `json:"_token"`
+	TokenExpiredAt        time.Time `json:"token_expired_at"`
+	RefreshToken          string    `json:"_refresh_token"`
+	RefreshTokenExpiredAt time.Time `json:"refresh_token_expired_at"`
 }
 
+// renewToken exchanges a refresh token for a new access token and a new refresh token,
+// the presented refresh token can't be used again afterwards
 func (server *Server) renewToken(ctx *gin.Context) {
 	var req renewTokenRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -26,12 +35,16 @@ func (server *Server) renewToken(ctx *gin.Context) {
 
 	refresh_payload, err := server.tokenMaker.VerifyToken(req.RefreshToken)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
 	session, err := server.store.GetSession(ctx, refresh_payload.ID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -60,16 +73,60 @@ func (server *Server) renewToken(ctx *gin.Context) {
 		return
 	}
 
-	token, token_payload, err := server.tokenMaker.CreateToken(refresh_payload.Username, server.config.TokenDuration)
+	// the role is read again so that role changes apply on the next renewal
+	user, err := server.store.GetUser(ctx, session.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	token, token_payload, err := server.tokenMaker.CreateToken(user.Username, user.Role, server.config.TokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the family of the login has an absolute expiry, renewals don't extend it
+	refreshTokenDuration := server.config.RefreshTokenDuration
+	if remaining := time.Until(session.FamilyExpiredAt); remaining < refreshTokenDuration {
+		refreshTokenDuration = remaining
+	}
+
+	refresh_token, new_refresh_payload, err := server.tokenMaker.CreateToken(user.Username, user.Role, refreshTokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.RotateSessionTxParams{
+		ParentID: session.ID,
+		Session: db.CreateSessionParams{
+			ID:           new_refresh_payload.ID,
+			Username:     session.Username,
+			RefreshToken: refresh_token,
+			UserAgent:    ctx.Request.UserAgent(),
+			ClientIp:     ctx.ClientIP(),
+			IsBlocked:    false,
+			ExpiredAt:    new_refresh_payload.ExpiredAt,
+		},
+	}
 
+	result, err := server.store.RotateSessionTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrRefreshTokenReused) || errors.Is(err, db.ErrSessionBlocked) || errors.Is(err, db.ErrSessionExpired) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
 	response := renewTokenResponse{
-		Token:          token,
-		TokenExpiredAt: token_payload.ExpiredAt,
+		SessionID:             result.Session.ID,
+		Token:                 token,
+		TokenExpiredAt:        token_payload.ExpiredAt,
+		RefreshToken:          refresh_token,
+		RefreshTokenExpiredAt: result.Session.ExpiredAt,
 	}
 
 	ctx.JSON(http.StatusOK, response)
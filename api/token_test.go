@@ -0,0 +1,236 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/Cell6969/go_bank/db/mock"
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/token"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenewToken(t *testing.T) {
+	username := util.GenerateRandomName()
+
+	testCases := []struct {
+		name          string
+		buildSession  func(session *db.Session)
+		buildStubs    func(store *mockdb.MockStore, session db.Session)
+		checkResponse func(recorder *httptest.ResponseRecorder, session db.Session, tokenMaker token.Maker)
+	}{
+		{
+			name:         "OK",
+			buildSession: func(session *db.Session) {},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(session.Username)).
+					Times(1).
+					Return(db.User{Username: session.Username, Role: util.BankerRole}, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RotateSessionTxParams) (db.RotateSessionTxResult, error) {
+						require.Equal(t, session.ID, arg.ParentID)
+						require.Equal(t, session.Username, arg.Session.Username)
+						require.NotEqual(t, session.RefreshToken, arg.Session.RefreshToken)
+
+						child := db.Session{
+							ID:              arg.Session.ID,
+							Username:        arg.Session.Username,
+							RefreshToken:    arg.Session.RefreshToken,
+							ParentID:        uuid.NullUUID{UUID: session.ID, Valid: true},
+							FamilyID:        session.FamilyID,
+							FamilyExpiredAt: session.FamilyExpiredAt,
+							ExpiredAt:       arg.Session.ExpiredAt,
+						}
+						return db.RotateSessionTxResult{ParentSession: session, Session: child}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, session db.Session, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var response renewTokenResponse
+				err = json.Unmarshal(data, &response)
+				require.NoError(t, err)
+				require.NotEqual(t, session.ID, response.SessionID)
+				require.NotEmpty(t, response.Token)
+				require.NotEmpty(t, response.RefreshToken)
+				require.NotEqual(t, session.RefreshToken, response.RefreshToken)
+
+				// the new tokens carry the current role of the user
+				payload, err := tokenMaker.VerifyToken(response.Token)
+				require.NoError(t, err)
+				require.Equal(t, util.BankerRole, payload.Role)
+			},
+		},
+		{
+			name:         "Reused",
+			buildSession: func(session *db.Session) {},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(session.Username)).
+					Times(1).
+					Return(db.User{Username: session.Username, Role: util.BankerRole}, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RotateSessionTxResult{}, db.ErrRefreshTokenReused)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, session db.Session, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Family Expiry",
+			buildSession: func(session *db.Session) {
+				session.FamilyExpiredAt = time.Now().Add(10 * time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(session.Username)).
+					Times(1).
+					Return(db.User{Username: session.Username, Role: util.DepositorRole}, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RotateSessionTxParams) (db.RotateSessionTxResult, error) {
+						child := db.Session{ID: arg.Session.ID, ExpiredAt: arg.Session.ExpiredAt}
+						return db.RotateSessionTxResult{ParentSession: session, Session: child}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, session db.Session, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response renewTokenResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				// the new refresh token doesn't outlive the family
+				payload, err := tokenMaker.VerifyToken(response.RefreshToken)
+				require.NoError(t, err)
+				require.WithinDuration(t, session.FamilyExpiredAt, payload.ExpiredAt, time.Second)
+				require.WithinDuration(t, session.FamilyExpiredAt, response.RefreshTokenExpiredAt, time.Second)
+			},
+		},
+		{
+			name:         "Concurrently Blocked",
+			buildSession: func(session *db.Session) {},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(session.Username)).
+					Times(1).
+					Return(db.User{Username: session.Username, Role: util.DepositorRole}, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RotateSessionTxResult{}, db.ErrSessionBlocked)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, session db.Session, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Blocked",
+			buildSession: func(session *db.Session) {
+				session.IsBlocked = true
+			},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, session db.Session, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Expired",
+			buildSession: func(session *db.Session) {
+				session.ExpiredAt = time.Now().Add(-time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, session db.Session, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+			server.config.RefreshTokenDuration = time.Hour
+
+			refreshToken, payload, err := server.tokenMaker.CreateToken(username, util.DepositorRole, time.Hour)
+			require.NoError(t, err)
+
+			session := db.Session{
+				ID:              payload.ID,
+				Username:        username,
+				RefreshToken:    refreshToken,
+				FamilyID:        payload.ID,
+				FamilyExpiredAt: payload.ExpiredAt,
+				ExpiredAt:       payload.ExpiredAt,
+			}
+			tc.buildSession(&session)
+			tc.buildStubs(store, session)
+
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"_refresh_token": refreshToken})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/token/renew", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder, session, server.tokenMaker)
+		})
+	}
+}
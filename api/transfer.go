@@ -8,9 +8,17 @@ import (
 
 	db "github.com/Cell6969/go_bank/db/sqlc"
 	"github.com/Cell6969/go_bank/token"
+	"github.com/Cell6969/go_bank/util"
 	"github.com/gin-gonic/gin"
 )
 
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// errStepUpRequired is returned by createTransfer for transfers above the step-up amount of the currency
+var errStepUpRequired = errors.New("transfer needs step-up verification, send it with /v1/create_transfer and confirm it with /v1/transfer_challenges/{challenge_id}/confirm")
+
+// Currency is the currency of the amount and must match the source account,
+// the destination account may hold another currency in which case the amount is converted
 type transferRequest struct {
 	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
 	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
@@ -38,27 +46,72 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+	toAccount, valid := server.findAccount(ctx, req.ToAccountID)
 
 	if !valid {
 		return
 	}
 
+	exchangeRate, err := server.fxRateProvider.GetRate(ctx, fromAccount.Currency, toAccount.Currency)
+	if err != nil {
+		if errors.Is(err, util.ErrUnsupportedFXPair) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	idempotencyKey := ctx.GetHeader(idempotencyKeyHeader)
+	if len(idempotencyKey) > 255 {
+		err := fmt.Errorf("%s header must not exceed 255 characters", idempotencyKeyHeader)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.TransferTxParams{
-		FromAccountId: req.FromAccountID,
-		ToAccountId:   req.ToAccountID,
-		Amount:        req.Amount,
+		FromAccountId:  req.FromAccountID,
+		ToAccountId:    req.ToAccountID,
+		Amount:         req.Amount,
+		ExchangeRate:   exchangeRate,
+		Owner:          authPayload.Username,
+		IdempotencyKey: idempotencyKey,
+	}
+
+	// this API can't ask for a fresh proof of the user, larger transfers go through the gateway
+	defaults, err := server.store.GetTransferLimitDefault(ctx, fromAccount.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if req.Amount > defaults.StepUpAmount {
+		ctx.JSON(http.StatusForbidden, errorResponse(errStepUpRequired))
+		return
 	}
 
 	result, err := server.store.TransferTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountFrozen) || errors.Is(err, db.ErrAccountClosed) ||
+			errors.Is(err, db.ErrTransferLimitExceeded) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrConvertedAmountTooSmall) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
 	ctx.JSON(http.StatusOK, result)
 }
 
-func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
+func (server *Server) findAccount(ctx *gin.Context, accountID int64) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -69,11 +122,84 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 		return account, false
 	}
 
+	return account, true
+}
+
+func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
+	account, valid := server.findAccount(ctx, accountID)
+	if !valid {
+		return account, false
+	}
+
 	if account.Currency != currency {
 		err := fmt.Errorf("account [%d] currency missmatch: %s vs %s", accountID, account.Currency, currency)
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return account, false
 	}
 
+	if err := db.CheckAccountActive(account); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		return account, false
+	}
+
 	return account, true
 }
+
+type listTransfersQuery struct {
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=100"`
+	PageToken string `form:"page_token"`
+}
+
+type listTransfersResponse struct {
+	Transfers     []db.Transfer `json:"transfers"`
+	NextPageToken string        `json:"next_page_token"`
+}
+
+func (server *Server) listTransfers(ctx *gin.Context) {
+	var uri accountUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var query listTransfersQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cursorCreatedAt, cursorID, err := util.DecodePageToken(query.PageToken)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, ok := server.authorizedAccount(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	arg := db.ListTransfersParams{
+		AccountID:       account.ID,
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		PageSize:        query.PageSize + 1,
+	}
+
+	transfers, err := server.store.ListTransfers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := listTransfersResponse{
+		Transfers: transfers,
+	}
+	if len(transfers) > int(query.PageSize) {
+		response.Transfers = transfers[:query.PageSize]
+		last := response.Transfers[query.PageSize-1]
+		response.NextPageToken = util.EncodePageToken(last.CreatedAt, last.ID)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
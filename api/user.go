@@ -2,11 +2,13 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"time"
 
 	db "github.com/Cell6969/go_bank/db/sqlc"
 	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/worker"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -24,6 +26,8 @@ type createUserResponse struct {
 	Username          string    `json:"username"`
 	FullName          string    `json:"full_name"`
 	Email             string    `json:"email"`
+	Role              string    `json:"role"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
 }
@@ -33,6 +37,8 @@ func newUserResponse(user db.User) createUserResponse {
 		Username:          user.Username,
 		FullName:          user.FullName,
 		Email:             user.Email,
+		Role:              user.Role,
+		IsEmailVerified:   user.IsEmailVerified,
 		PasswordChangedAt: user.PasswordChangedAt,
 		CreatedAt:         user.CreatedAt,
 	}
@@ -52,14 +58,24 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.CreateUserParams{
-		Username: req.Username,
-		Password: hashedPassword,
-		FullName: req.FullName,
-		Email:    req.Email,
+	arg := db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username: req.Username,
+			Password: hashedPassword,
+			FullName: req.FullName,
+			Email:    req.Email,
+		},
+		SecretCode: util.RandomString(32),
+		VerifyEmailTask: func(user db.User, verifyEmail db.VerifyEmail) (db.CreateTaskParams, error) {
+			payload := &worker.PayloadSendVerifyEmail{
+				Username:      user.Username,
+				VerifyEmailID: verifyEmail.ID,
+			}
+			return worker.NewTaskSendVerifyEmail(payload, worker.Queue(worker.QueueCritical))
+		},
 	}
 
-	user, err := server.store.CreateUser(ctx, arg)
+	result, err := server.store.CreateUserTx(ctx, arg)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
@@ -72,12 +88,55 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
-	response := newUserResponse(user)
+	response := newUserResponse(result.User)
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// For Verify Email API
+type verifyEmailRequest struct {
+	EmailID    int64  `form:"email_id" binding:"required,min=1"`
+	SecretCode string `form:"secret_code" binding:"required,min=32,max=128"`
+}
+
+type verifyEmailResponse struct {
+	IsVerified bool `json:"is_verified"`
+}
+
+func (server *Server) verifyEmail(ctx *gin.Context) {
+	var req verifyEmailRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.VerifyEmailTxParams{
+		EmailID:    req.EmailID,
+		SecretCode: req.SecretCode,
+	}
+
+	result, err := server.store.VerifyEmailTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := errors.New("invalid or expired verification code")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := verifyEmailResponse{
+		IsVerified: result.User.IsEmailVerified,
+	}
 
 	ctx.JSON(http.StatusOK, response)
 }
 
 // For Login API
+// errMFARequired is returned by loginUser for users who enabled multi-factor authentication
+var errMFARequired = errors.New("multi-factor authentication is enabled, log in with /v1/login_user and /v1/verify_mfa")
+
 type loginUserRequest struct {
 	Username string `json:"username" binding:"required,alphanum"`
 	Password string `json:"password" binding:"required,min=6"`
@@ -116,8 +175,20 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
+	// this API has no second login step, users with multi-factor authentication log in through the gateway
+	enrollment, err := server.store.GetMFAEnrollment(ctx, user.Username)
+	if err != nil && err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if err == nil && enrollment.IsEnabled {
+		ctx.JSON(http.StatusForbidden, errorResponse(errMFARequired))
+		return
+	}
+
 	token, access_payload, err := server.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
 		server.config.TokenDuration,
 	)
 	if err != nil {
@@ -127,6 +198,7 @@ func (server *Server) loginUser(ctx *gin.Context) {
 
 	refresh_token, refresh_payload, err := server.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
 		server.config.RefreshTokenDuration,
 	)
 
@@ -137,13 +209,15 @@ func (server *Server) loginUser(ctx *gin.Context) {
 
 	// Store Refresh Token on Session
 	arg_ref_token := db.CreateSessionParams{
-		ID:           refresh_payload.ID,
-		Username:     user.Username,
-		RefreshToken: refresh_token,
-		UserAgent:    ctx.Request.UserAgent(),
-		ClientIp:     ctx.ClientIP(),
-		IsBlocked:    false,
-		ExpiredAt:    refresh_payload.ExpiredAt,
+		ID:              refresh_payload.ID,
+		Username:        user.Username,
+		RefreshToken:    refresh_token,
+		UserAgent:       ctx.Request.UserAgent(),
+		ClientIp:        ctx.ClientIP(),
+		IsBlocked:       false,
+		FamilyID:        refresh_payload.ID,
+		FamilyExpiredAt: refresh_payload.ExpiredAt,
+		ExpiredAt:       refresh_payload.ExpiredAt,
 	}
 	session, err := server.store.CreateSession(ctx, arg_ref_token)
 	if err != nil {
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,18 +14,19 @@ import (
 	mockdb "github.com/Cell6969/go_bank/db/mock"
 	db "github.com/Cell6969/go_bank/db/sqlc"
 	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/worker"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
-type eqCreateUserParamMatcher struct {
+type eqCreateUserTxParamMatcher struct {
 	arg      db.CreateUserParams
 	password string
 }
 
-func (e eqCreateUserParamMatcher) Matches(x interface{}) bool {
-	args, ok := x.(db.CreateUserParams)
+func (e eqCreateUserTxParamMatcher) Matches(x interface{}) bool {
+	args, ok := x.(db.CreateUserTxParams)
 	if !ok {
 		return false
 	}
@@ -35,15 +37,15 @@ func (e eqCreateUserParamMatcher) Matches(x interface{}) bool {
 	}
 
 	e.arg.Password = args.Password
-	return reflect.DeepEqual(e.arg, args)
+	return reflect.DeepEqual(e.arg, args.CreateUserParams)
 }
 
-func (e eqCreateUserParamMatcher) String() string {
+func (e eqCreateUserTxParamMatcher) String() string {
 	return fmt.Sprintf("matches arg %v and password %v", e.arg, e.password)
 }
 
-func EqCreateUserParams(arg db.CreateUserParams, password string) gomock.Matcher {
-	return eqCreateUserParamMatcher{arg: arg, password: password}
+func EqCreateUserTxParams(arg db.CreateUserParams, password string) gomock.Matcher {
+	return eqCreateUserTxParamMatcher{arg: arg, password: password}
 }
 
 func TestCreateUser(t *testing.T) {
@@ -70,10 +72,30 @@ func TestCreateUser(t *testing.T) {
 					Email:    user.Email,
 				}
 
+				verifyEmail := db.VerifyEmail{
+					ID:       1,
+					Username: user.Username,
+					Email:    user.Email,
+				}
+
+				// the verification email task is inserted together with the user
 				store.EXPECT().
-					CreateUser(gomock.Any(), EqCreateUserParams(arg, password)).
+					CreateUserTx(gomock.Any(), EqCreateUserTxParams(arg, password)).
 					Times(1).
-					Return(user, nil)
+					DoAndReturn(func(_ interface{}, arg db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+						verifyEmail.SecretCode = arg.SecretCode
+						task, err := arg.VerifyEmailTask(user, verifyEmail)
+						require.NoError(t, err)
+						require.Equal(t, worker.TaskSendVerifyEmail, task.Type)
+						require.Equal(t, worker.QueueCritical, task.Queue)
+
+						var payload worker.PayloadSendVerifyEmail
+						require.NoError(t, json.Unmarshal(task.Payload, &payload))
+						require.Equal(t, user.Username, payload.Username)
+						require.Equal(t, verifyEmail.ID, payload.VerifyEmailID)
+
+						return db.CreateUserTxResult{User: user, VerifyEmail: verifyEmail}, nil
+					})
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -108,6 +130,190 @@ func TestCreateUser(t *testing.T) {
 	}
 }
 
+func TestVerifyEmail(t *testing.T) {
+	user, _ := randomUser(t)
+	secretCode := util.RandomString(32)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("email_id=%d&secret_code=%s", 1, secretCode),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.VerifyEmailTxParams{
+					EmailID:    1,
+					SecretCode: secretCode,
+				}
+
+				verifiedUser := user
+				verifiedUser.IsEmailVerified = true
+
+				store.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.VerifyEmailTxResult{User: verifiedUser}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"is_verified":true}`, recorder.Body.String())
+			},
+		},
+		{
+			name:  "Invalid Code",
+			query: fmt.Sprintf("email_id=%d&secret_code=%s", 1, secretCode),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.VerifyEmailTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Missing Code",
+			query: "email_id=1",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/verify_email?" + tc.query
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestLoginUser(t *testing.T) {
+	user, password := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"username": user.Username,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetMFAEnrollment(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.MfaEnrollment{}, sql.ErrNoRows)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Pending MFA Enrollment",
+			body: gin.H{
+				"username": user.Username,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					GetMFAEnrollment(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(db.MfaEnrollment{Username: user.Username, IsEnabled: false}, nil)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "MFA Enabled",
+			body: gin.H{
+				"username": user.Username,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					GetMFAEnrollment(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(db.MfaEnrollment{Username: user.Username, IsEnabled: true}, nil)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Wrong Password",
+			body: gin.H{
+				"username": user.Username,
+				"password": "wrong_password",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetMFAEnrollment(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/users/login", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 func randomUser(t *testing.T) (user db.User, password string) {
 	password = util.RandomString(7)
 	hashedPassword, err := util.HashPassword(password)
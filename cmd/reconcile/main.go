@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"os"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/reconcile"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	_ "github.com/lib/pq"
+)
+
+// reconcile prints the balance reconciliation report as JSON and exits with status 1 when something drifts
+func main() {
+	configPath := flag.String("config", ".", "directory of the app.env file")
+	fix := flag.Bool("fix", false, "write adjustment journal entries for the drifting accounts")
+	flag.Parse()
+
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	config, err := util.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load config")
+	}
+
+	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot connect to db")
+	}
+	defer conn.Close()
+
+	reconciler := reconcile.NewReconciler(db.NewStore(conn))
+	report, err := reconciler.Run(context.Background(), *fix)
+	if err != nil {
+		log.Fatal().Err(err).Msg("reconciliation failed")
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatal().Err(err).Msg("cannot write report")
+	}
+
+	if len(report.UnbalancedJournals) > 0 || (len(report.Drifts) > 0 && !*fix) {
+		os.Exit(1)
+	}
+}
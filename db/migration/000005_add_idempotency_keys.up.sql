@@ -0,0 +1,12 @@
+CREATE TABLE "idempotency_keys" (
+    "owner" varchar NOT NULL,
+    "key" varchar NOT NULL,
+    "transfer_id" bigint NOT NULL,
+    "result" jsonb NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("owner", "key")
+);
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "destination_amount";
+
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "source_amount";
+
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "exchange_rate";
+
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "to_amount";
+
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "exchange_rate";
\ No newline at end of file
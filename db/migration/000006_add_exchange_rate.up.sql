@@ -0,0 +1,19 @@
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" numeric NOT NULL DEFAULT 1;
+
+ALTER TABLE "transfers" ADD COLUMN "to_amount" bigint;
+
+UPDATE "transfers" SET "to_amount" = "amount";
+
+ALTER TABLE "transfers" ALTER COLUMN "to_amount" SET NOT NULL;
+
+ALTER TABLE "entries" ADD COLUMN "exchange_rate" numeric NOT NULL DEFAULT 1;
+
+ALTER TABLE "entries" ADD COLUMN "source_amount" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "entries" ADD COLUMN "destination_amount" bigint NOT NULL DEFAULT 0;
+
+UPDATE "entries" SET "source_amount" = abs("amount"), "destination_amount" = abs("amount");
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'to_amount = amount * exchange_rate';
+
+COMMENT ON COLUMN "transfers"."to_amount" IS 'amount credited in the destination account currency';
\ No newline at end of file
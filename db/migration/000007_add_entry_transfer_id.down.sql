@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "transfer_id";
+
+DROP INDEX IF EXISTS "entries_account_id_created_at_idx";
\ No newline at end of file
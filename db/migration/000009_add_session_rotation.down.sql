@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "sessions_family_id_idx";
+
+ALTER TABLE IF EXISTS "sessions" DROP CONSTRAINT IF EXISTS "sessions_parent_id_fkey";
+
+ALTER TABLE IF EXISTS "sessions" DROP COLUMN IF EXISTS "rotated_at";
+
+ALTER TABLE IF EXISTS "sessions" DROP COLUMN IF EXISTS "family_id";
+
+ALTER TABLE IF EXISTS "sessions" DROP COLUMN IF EXISTS "parent_id";
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE "tasks" (
+  "id" bigserial PRIMARY KEY,
+  "type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "queue" varchar NOT NULL DEFAULT 'default',
+  "priority" int NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "max_attempts" int NOT NULL DEFAULT 10,
+  "last_error" varchar,
+  "run_at" timestamp NOT NULL DEFAULT (now()),
+  "locked_until" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "tasks" ADD CONSTRAINT "tasks_status_check" CHECK ("status" IN ('pending', 'running', 'completed', 'dead'));
+
+CREATE INDEX ON "tasks" ("status", "priority", "run_at");
\ No newline at end of file
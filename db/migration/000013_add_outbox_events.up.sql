@@ -0,0 +1,11 @@
+CREATE TABLE "outbox_events" (
+  "id" uuid PRIMARY KEY,
+  "aggregate_type" varchar NOT NULL,
+  "aggregate_id" varchar NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "published_at" timestamp
+);
+
+CREATE INDEX ON "outbox_events" ("created_at") WHERE "published_at" IS NULL;
\ No newline at end of file
@@ -0,0 +1,35 @@
+CREATE TABLE "webhook_endpoints" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "url" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "event_types" varchar[] NOT NULL,
+  "is_active" bool NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "endpoint_id" bigint NOT NULL,
+  "event_id" uuid NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "response_status" int,
+  "last_error" varchar,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "delivered_at" timestamp
+);
+
+ALTER TABLE "webhook_endpoints" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints" ("id");
+
+ALTER TABLE "webhook_deliveries" ADD CONSTRAINT "webhook_deliveries_status_check" CHECK ("status" IN ('pending', 'succeeded', 'failed'));
+
+CREATE INDEX ON "webhook_endpoints" ("owner");
+
+CREATE UNIQUE INDEX ON "webhook_deliveries" ("endpoint_id", "event_id");
+
+CREATE INDEX ON "webhook_deliveries" ("endpoint_id", "created_at", "id");
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS "entries_notify_created" ON "entries";
+
+DROP FUNCTION IF EXISTS notify_entry_created();
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE FUNCTION notify_entry_created() RETURNS trigger AS $$
+BEGIN
+  PERFORM pg_notify('entry_created', json_build_object('account_id', NEW.account_id, 'entry_id', NEW.id)::text);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- the notification is sent on commit, so listeners never see entries of a rolled back transaction
+CREATE TRIGGER "entries_notify_created" AFTER INSERT ON "entries"
+FOR EACH ROW EXECUTE FUNCTION notify_entry_created();
\ No newline at end of file
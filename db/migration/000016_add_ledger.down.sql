@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS "accounts_create_ledger_account" ON "accounts";
+
+DROP FUNCTION IF EXISTS create_account_ledger_account();
+
+DROP TABLE IF EXISTS "postings";
+
+DROP TABLE IF EXISTS "journal_entries";
+
+DROP TABLE IF EXISTS "ledger_accounts";
\ No newline at end of file
@@ -0,0 +1,72 @@
+CREATE TABLE "ledger_accounts" (
+  "id" bigserial PRIMARY KEY,
+  "code" varchar UNIQUE NOT NULL,
+  "name" varchar NOT NULL,
+  "type" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "account_id" bigint UNIQUE,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "journal_entries" (
+  "id" bigserial PRIMARY KEY,
+  "type" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "transfer_id" bigint,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "postings" (
+  "id" bigserial PRIMARY KEY,
+  "journal_entry_id" bigint NOT NULL,
+  "ledger_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "entry_id" bigint UNIQUE,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "ledger_accounts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "ledger_accounts" ADD CONSTRAINT "ledger_accounts_type_check" CHECK ("type" IN ('asset', 'liability', 'equity', 'revenue', 'expense'));
+
+ALTER TABLE "journal_entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entries" ("id");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("ledger_account_id") REFERENCES "ledger_accounts" ("id");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+CREATE INDEX ON "journal_entries" ("transfer_id");
+
+CREATE INDEX ON "postings" ("journal_entry_id");
+
+CREATE INDEX ON "postings" ("ledger_account_id");
+
+-- system accounts are not owned by any user, there is one of each kind per currency
+INSERT INTO "ledger_accounts" ("code", "name", "type", "currency")
+SELECT kind.code || ':' || currency.code, kind.name, kind.type, currency.code
+FROM (VALUES
+  ('cash', 'Cash and clearing', 'asset'),
+  ('fees', 'Fee income', 'revenue'),
+  ('fx', 'Currency exchange', 'equity'),
+  ('suspense', 'Suspense', 'liability')
+) AS kind (code, name, type)
+CROSS JOIN (VALUES ('USD'), ('EUR'), ('CAD')) AS currency (code);
+
+-- every customer account is a liability of the bank
+INSERT INTO "ledger_accounts" ("code", "name", "type", "currency", "account_id")
+SELECT 'account:' || "id", "owner", 'liability', "currency", "id"
+FROM "accounts";
+
+CREATE FUNCTION create_account_ledger_account() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO ledger_accounts (code, name, type, currency, account_id)
+  VALUES ('account:' || NEW.id, NEW.owner, 'liability', NEW.currency, NEW.id);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "accounts_create_ledger_account" AFTER INSERT ON "accounts"
+FOR EACH ROW EXECUTE FUNCTION create_account_ledger_account();
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "cash_transactions";
+
+UPDATE "users" SET "role" = 'depositor' WHERE "role" = 'integration';
+
+ALTER TABLE IF EXISTS "users" DROP CONSTRAINT IF EXISTS "users_role_check";
+
+ALTER TABLE IF EXISTS "users" ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('depositor', 'support', 'banker'));
\ No newline at end of file
@@ -0,0 +1,33 @@
+CREATE TABLE "cash_transactions" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "type" varchar NOT NULL,
+  "amount" bigint NOT NULL,
+  "channel" varchar NOT NULL,
+  "reference" varchar NOT NULL,
+  "journal_entry_id" bigint NOT NULL,
+  "entry_id" bigint NOT NULL,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "cash_transactions" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "cash_transactions" ADD FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entries" ("id");
+
+ALTER TABLE "cash_transactions" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "cash_transactions" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+ALTER TABLE "cash_transactions" ADD CONSTRAINT "cash_transactions_type_check" CHECK ("type" IN ('deposit', 'withdrawal'));
+
+ALTER TABLE "cash_transactions" ADD CONSTRAINT "cash_transactions_amount_check" CHECK ("amount" > 0);
+
+-- a reference identifies the operation within its channel, so a retried request can't move the money twice
+CREATE UNIQUE INDEX ON "cash_transactions" ("channel", "reference");
+
+CREATE INDEX ON "cash_transactions" ("account_id", "created_at", "id");
+
+ALTER TABLE "users" DROP CONSTRAINT "users_role_check";
+
+ALTER TABLE "users" ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('depositor', 'support', 'banker', 'integration'));
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "transfer_reversals";
\ No newline at end of file
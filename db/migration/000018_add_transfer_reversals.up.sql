@@ -0,0 +1,24 @@
+CREATE TABLE "transfer_reversals" (
+  "id" bigserial PRIMARY KEY,
+  "transfer_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "to_amount" bigint NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "journal_entry_id" bigint NOT NULL,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entries" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_reversals" ADD CONSTRAINT "transfer_reversals_amount_check" CHECK ("amount" > 0 AND "to_amount" >= 0);
+
+CREATE INDEX ON "transfer_reversals" ("transfer_id");
+
+COMMENT ON COLUMN "transfer_reversals"."amount" IS 'amount given back to the sender, in the source account currency';
+
+COMMENT ON COLUMN "transfer_reversals"."to_amount" IS 'amount taken back from the recipient, in the destination account currency';
\ No newline at end of file
@@ -0,0 +1,53 @@
+CREATE TABLE "scheduled_transfers" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "recurrence" varchar NOT NULL DEFAULT '',
+  "start_at" timestamp NOT NULL,
+  "end_at" timestamp,
+  "next_run_at" timestamp NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "locked_until" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "scheduled_transfer_runs" (
+  "id" bigserial PRIMARY KEY,
+  "scheduled_transfer_id" bigint NOT NULL,
+  "occurrence_at" timestamp NOT NULL,
+  "status" varchar NOT NULL,
+  "transfer_id" bigint,
+  "error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfers" ADD CONSTRAINT "scheduled_transfers_status_check" CHECK ("status" IN ('active', 'completed', 'cancelled'));
+
+ALTER TABLE "scheduled_transfers" ADD CONSTRAINT "scheduled_transfers_amount_check" CHECK ("amount" > 0);
+
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("scheduled_transfer_id") REFERENCES "scheduled_transfers" ("id");
+
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "scheduled_transfer_runs" ADD CONSTRAINT "scheduled_transfer_runs_status_check" CHECK ("status" IN ('succeeded', 'failed'));
+
+CREATE INDEX ON "scheduled_transfers" ("owner");
+
+CREATE INDEX ON "scheduled_transfers" ("status", "next_run_at");
+
+-- an occurrence is only recorded once, however many schedulers picked it up
+CREATE UNIQUE INDEX ON "scheduled_transfer_runs" ("scheduled_transfer_id", "occurrence_at");
+
+CREATE INDEX ON "scheduled_transfer_runs" ("scheduled_transfer_id", "created_at", "id");
+
+COMMENT ON COLUMN "scheduled_transfers"."recurrence" IS 'RRULE subset like FREQ=MONTHLY;BYMONTHDAY=1, empty for a one-off transfer';
\ No newline at end of file
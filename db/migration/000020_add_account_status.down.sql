@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "account_status_changes";
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "accounts_status_check";
+
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "status";
\ No newline at end of file
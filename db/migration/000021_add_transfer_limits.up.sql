@@ -0,0 +1,33 @@
+CREATE TABLE "transfer_limit_defaults" (
+  "currency" varchar PRIMARY KEY,
+  "max_per_transfer" bigint NOT NULL,
+  "daily_amount" bigint NOT NULL,
+  "daily_count" int NOT NULL
+);
+
+CREATE TABLE "account_transfer_limits" (
+  "account_id" bigint PRIMARY KEY,
+  "max_per_transfer" bigint,
+  "daily_amount" bigint,
+  "daily_count" int,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "account_transfer_limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "account_transfer_limits" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_limit_defaults" ADD CONSTRAINT "transfer_limit_defaults_check" CHECK ("max_per_transfer" > 0 AND "daily_amount" > 0 AND "daily_count" > 0);
+
+ALTER TABLE "account_transfer_limits" ADD CONSTRAINT "account_transfer_limits_check" CHECK ("max_per_transfer" > 0 AND "daily_amount" > 0 AND "daily_count" > 0);
+
+-- the daily allowance sums the outgoing transfers of the day
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+COMMENT ON TABLE "account_transfer_limits" IS 'a null limit falls back to the default of the account currency';
+
+INSERT INTO "transfer_limit_defaults" ("currency", "max_per_transfer", "daily_amount", "daily_count") VALUES
+  ('USD', 1000000, 5000000, 100),
+  ('EUR', 1000000, 5000000, 100),
+  ('CAD', 1300000, 6500000, 100);
\ No newline at end of file
@@ -0,0 +1,44 @@
+CREATE TABLE "mfa_enrollments" (
+  "username" varchar PRIMARY KEY,
+  "totp_secret" varchar NOT NULL,
+  "is_enabled" bool NOT NULL DEFAULT false,
+  "last_used_step" bigint NOT NULL DEFAULT 0,
+  "enabled_at" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "mfa_recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "code_hash" varchar NOT NULL,
+  "used_at" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "mfa_challenges" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "token_hash" varchar UNIQUE NOT NULL,
+  "attempts" int NOT NULL DEFAULT 0,
+  "is_used" bool NOT NULL DEFAULT false,
+  "user_agent" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "expired_at" timestamp NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "mfa_enrollments" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "mfa_recovery_codes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "mfa_challenges" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX ON "mfa_recovery_codes" ("username", "code_hash");
+
+CREATE INDEX ON "mfa_challenges" ("username");
+
+COMMENT ON COLUMN "mfa_enrollments"."last_used_step" IS 'TOTP period of the last accepted code, older codes are refused';
+
+COMMENT ON COLUMN "mfa_recovery_codes"."code_hash" IS 'SHA-256 of the recovery code, the code itself is only shown once';
+
+COMMENT ON COLUMN "mfa_challenges"."token_hash" IS 'SHA-256 of the challenge token returned by the first login step';
\ No newline at end of file
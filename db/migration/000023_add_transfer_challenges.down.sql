@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "transfer_challenges";
+
+ALTER TABLE IF EXISTS "transfer_limit_defaults" DROP CONSTRAINT IF EXISTS "transfer_limit_defaults_step_up_amount_check";
+
+ALTER TABLE IF EXISTS "transfer_limit_defaults" DROP COLUMN IF EXISTS "step_up_amount";
\ No newline at end of file
@@ -0,0 +1,42 @@
+ALTER TABLE "transfer_limit_defaults" ADD COLUMN "step_up_amount" bigint NOT NULL DEFAULT 100000;
+
+ALTER TABLE "transfer_limit_defaults" ADD CONSTRAINT "transfer_limit_defaults_step_up_amount_check" CHECK ("step_up_amount" > 0);
+
+UPDATE "transfer_limit_defaults" SET "step_up_amount" = 130000 WHERE "currency" = 'CAD';
+
+CREATE TABLE "transfer_challenges" (
+  "id" bigserial PRIMARY KEY,
+  "token_id" uuid NOT NULL,
+  "username" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "exchange_rate" numeric NOT NULL,
+  "idempotency_key" varchar NOT NULL DEFAULT '',
+  "method" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "transfer_id" bigint,
+  "expired_at" timestamp NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_challenges" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_challenges" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_challenges" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_challenges" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_challenges" ADD CONSTRAINT "transfer_challenges_amount_check" CHECK ("amount" > 0);
+
+ALTER TABLE "transfer_challenges" ADD CONSTRAINT "transfer_challenges_method_check" CHECK ("method" IN ('password', 'mfa'));
+
+ALTER TABLE "transfer_challenges" ADD CONSTRAINT "transfer_challenges_status_check" CHECK ("status" IN ('pending', 'completed'));
+
+CREATE INDEX ON "transfer_challenges" ("username");
+
+COMMENT ON COLUMN "transfer_limit_defaults"."step_up_amount" IS 'transfers above it wait for the user to prove their identity again';
+
+COMMENT ON COLUMN "transfer_challenges"."token_id" IS 'id of the access token payload that asked for the transfer, only it can confirm the transfer';
\ No newline at end of file
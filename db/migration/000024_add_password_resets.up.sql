@@ -0,0 +1,14 @@
+CREATE TABLE "password_resets" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "token_hash" varchar UNIQUE NOT NULL,
+  "is_used" bool NOT NULL DEFAULT false,
+  "expired_at" timestamp NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "password_resets" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+CREATE INDEX ON "password_resets" ("username");
+
+COMMENT ON COLUMN "password_resets"."token_hash" IS 'SHA-256 of the token sent by email, the token itself is never stored';
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "sessions" DROP COLUMN IF EXISTS "family_expired_at";
\ No newline at end of file
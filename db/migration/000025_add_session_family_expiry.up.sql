@@ -0,0 +1,5 @@
+ALTER TABLE "sessions" ADD COLUMN "family_expired_at" timestamp;
+
+UPDATE "sessions" SET "family_expired_at" = "expired_at";
+
+ALTER TABLE "sessions" ALTER COLUMN "family_expired_at" SET NOT NULL;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE "auth_failures" (
+  "username" varchar PRIMARY KEY,
+  "failed_attempts" int NOT NULL DEFAULT 0,
+  "locked_until" timestamp,
+  "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "auth_failures" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+COMMENT ON COLUMN "auth_failures"."failed_attempts" IS 'wrong codes since the last success or lockout, shared by every challenge of the user';
\ No newline at end of file
@@ -0,0 +1 @@
+COMMENT ON COLUMN "auth_failures"."failed_attempts" IS 'wrong codes since the last success or lockout, shared by every challenge of the user';
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS "outbox_events" DROP COLUMN IF EXISTS "last_error";
+
+ALTER TABLE IF EXISTS "outbox_events" DROP COLUMN IF EXISTS "next_attempt_at";
+
+ALTER TABLE IF EXISTS "outbox_events" DROP COLUMN IF EXISTS "attempts";
\ No newline at end of file
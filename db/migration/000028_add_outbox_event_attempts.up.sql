@@ -0,0 +1,7 @@
+ALTER TABLE "outbox_events" ADD COLUMN "attempts" int NOT NULL DEFAULT 0;
+
+ALTER TABLE "outbox_events" ADD COLUMN "next_attempt_at" timestamp NOT NULL DEFAULT (now());
+
+ALTER TABLE "outbox_events" ADD COLUMN "last_error" varchar;
+
+COMMENT ON COLUMN "outbox_events"."next_attempt_at" IS 'the relay skips the event until then after a failed publish';
\ No newline at end of file
@@ -18,10 +18,10 @@ FOR NO KEY UPDATE;
 
 -- name: ListAccount :many
 SELECT * FROM accounts
-WHERE owner = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3;
+WHERE owner = sqlc.arg(owner)
+  AND (created_at, id) > (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::bigint)
+ORDER BY created_at, id
+LIMIT sqlc.arg(page_size);
 
 -- name: UpdateAccount :one
 UPDATE accounts
@@ -35,6 +35,32 @@ SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit = sqlc.arg(overdraft_limit)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = sqlc.arg(status)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListBalanceDrifts :many
+-- accounts whose balance is not the sum of their entries
+SELECT
+  a.id,
+  a.owner,
+  a.currency,
+  a.balance,
+  COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id;
+
 -- name: DeleteAccount :exec
 DELETE FROM accounts
 WHERE id = $1;
@@ -0,0 +1,17 @@
+-- name: CreateAccountStatusChange :one
+INSERT INTO account_status_changes (
+    account_id,
+    from_status,
+    to_status,
+    reason,
+    changed_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListAccountStatusChanges :many
+SELECT * FROM account_status_changes
+WHERE account_id = sqlc.arg(account_id)
+  AND (created_at, id) > (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::bigint)
+ORDER BY created_at, id
+LIMIT sqlc.arg(page_size);
\ No newline at end of file
@@ -0,0 +1,29 @@
+-- name: GetAuthFailure :one
+SELECT * FROM auth_failures
+WHERE username = $1 LIMIT 1;
+
+-- name: AddAuthFailure :one
+-- the counter starts over once it reaches max_attempts and the user is locked out until locked_until
+INSERT INTO auth_failures (
+  username,
+  failed_attempts
+) VALUES (
+  sqlc.arg(username), 1
+) ON CONFLICT (username) DO UPDATE
+SET failed_attempts = CASE
+      WHEN auth_failures.failed_attempts + 1 >= sqlc.arg(max_attempts)::int THEN 0
+      ELSE auth_failures.failed_attempts + 1
+    END,
+    locked_until = CASE
+      WHEN auth_failures.failed_attempts + 1 >= sqlc.arg(max_attempts)::int THEN sqlc.arg(locked_until)::timestamp
+      ELSE auth_failures.locked_until
+    END,
+    updated_at = now()
+RETURNING *;
+
+-- name: ResetAuthFailures :exec
+UPDATE auth_failures
+SET failed_attempts = 0,
+    locked_until = NULL,
+    updated_at = now()
+WHERE username = $1;
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- name: CreateCashTransaction :one
+INSERT INTO cash_transactions (
+    account_id,
+    type,
+    amount,
+    channel,
+    reference,
+    journal_entry_id,
+    entry_id,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetCashTransaction :one
+SELECT * FROM cash_transactions
+WHERE id = $1 LIMIT 1;
\ No newline at end of file
@@ -1,9 +1,13 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  exchange_rate,
+  source_amount,
+  destination_amount,
+  transfer_id
 ) VALUES (
-  $1, $2
+  $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -12,10 +16,54 @@ WHERE id = $1 LIMIT 1;
 
 -- name: ListEntries :many
 SELECT * FROM entries
-WHERE account_id = $1
+WHERE account_id = sqlc.arg(account_id)
+  AND (created_at, id) > (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::bigint)
+ORDER BY created_at, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListEntriesAfter :many
+SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND id > sqlc.arg(after_id)::bigint
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg(page_size);
+
+-- name: GetEntriesTotal :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = $1;
+
+-- name: GetEntriesSumSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = $1 AND created_at >= $2;
+
+-- name: GetStatementEntriesSumAfter :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND created_at >= sqlc.arg(from_time)
+  AND (created_at, id) > (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::bigint);
+
+-- name: ListStatementEntries :many
+SELECT
+  e.id,
+  e.account_id,
+  e.amount,
+  e.transfer_id,
+  e.created_at,
+  COALESCE(
+    CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END,
+    0
+  )::bigint AS counterparty_account_id
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.created_at >= sqlc.arg(from_time)
+  AND e.created_at < sqlc.arg(to_time)
+  AND (e.created_at, e.id) > (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::bigint)
+ORDER BY e.created_at, e.id
+LIMIT sqlc.arg(page_size);
 
 -- name: ResetEntryTable :exec
 DELETE FROM entries;
@@ -0,0 +1,17 @@
+-- name: LockIdempotencyKey :exec
+-- the first key is the namespace of the lock, see LockAccountTransferLimit
+SELECT pg_advisory_xact_lock(2, hashtext(sqlc.arg(owner)::text || ':' || sqlc.arg(key)::text));
+
+-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+    owner,
+    key,
+    transfer_id,
+    result
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE owner = $1 AND key = $2 LIMIT 1;
\ No newline at end of file
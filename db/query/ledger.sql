@@ -0,0 +1,70 @@
+-- name: GetLedgerAccount :one
+SELECT * FROM ledger_accounts
+WHERE id = $1 LIMIT 1;
+
+-- name: GetLedgerAccountByCode :one
+SELECT * FROM ledger_accounts
+WHERE code = $1 LIMIT 1;
+
+-- name: GetLedgerAccountByAccountID :one
+SELECT * FROM ledger_accounts
+WHERE account_id = $1 LIMIT 1;
+
+-- name: ListSystemLedgerAccountBalances :many
+-- customer accounts keep their balance in accounts.balance, system accounts only have postings
+SELECT
+  l.id,
+  l.code,
+  l.name,
+  l.type,
+  l.currency,
+  COALESCE(SUM(p.amount), 0)::bigint AS balance
+FROM ledger_accounts l
+LEFT JOIN postings p ON p.ledger_account_id = l.id
+WHERE l.account_id IS NULL
+GROUP BY l.id
+ORDER BY l.code;
+
+-- name: CreateJournalEntry :one
+INSERT INTO journal_entries (
+  type,
+  description,
+  transfer_id
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetJournalEntry :one
+SELECT * FROM journal_entries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListJournalEntriesByTransfer :many
+SELECT * FROM journal_entries
+WHERE transfer_id = $1
+ORDER BY id;
+
+-- name: CreatePosting :one
+INSERT INTO postings (
+  journal_entry_id,
+  ledger_account_id,
+  amount,
+  currency,
+  entry_id
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListPostings :many
+SELECT * FROM postings
+WHERE journal_entry_id = $1
+ORDER BY id;
+
+-- name: ListUnbalancedJournalEntries :many
+SELECT
+  journal_entry_id,
+  currency,
+  SUM(amount)::bigint AS total
+FROM postings
+GROUP BY journal_entry_id, currency
+HAVING SUM(amount) <> 0
+ORDER BY journal_entry_id;
\ No newline at end of file
@@ -0,0 +1,87 @@
+-- name: CreateMFAEnrollment :one
+-- starts or restarts the enrollment of the user, nothing is returned once it is enabled
+INSERT INTO mfa_enrollments (
+  username,
+  totp_secret
+) VALUES (
+  $1, $2
+) ON CONFLICT (username) DO UPDATE
+SET totp_secret = EXCLUDED.totp_secret,
+    last_used_step = 0,
+    created_at = now()
+WHERE mfa_enrollments.is_enabled = false
+RETURNING *;
+
+-- name: GetMFAEnrollment :one
+SELECT * FROM mfa_enrollments
+WHERE username = $1 LIMIT 1;
+
+-- name: GetMFAEnrollmentForUpdate :one
+SELECT * FROM mfa_enrollments
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: EnableMFAEnrollment :one
+UPDATE mfa_enrollments
+SET is_enabled = true,
+    last_used_step = sqlc.arg(last_used_step),
+    enabled_at = now()
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: UpdateMFALastUsedStep :exec
+UPDATE mfa_enrollments
+SET last_used_step = sqlc.arg(last_used_step)
+WHERE username = sqlc.arg(username);
+
+-- name: DeleteMFAEnrollment :exec
+DELETE FROM mfa_enrollments
+WHERE username = $1;
+
+-- name: CreateMFARecoveryCode :one
+INSERT INTO mfa_recovery_codes (
+  username,
+  code_hash
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: UseMFARecoveryCode :one
+UPDATE mfa_recovery_codes
+SET used_at = now()
+WHERE username = $1
+  AND code_hash = $2
+  AND used_at IS NULL
+RETURNING *;
+
+-- name: DeleteMFARecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE username = $1;
+
+-- name: CreateMFAChallenge :one
+INSERT INTO mfa_challenges (
+  username,
+  token_hash,
+  user_agent,
+  client_ip,
+  expired_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetMFAChallengeForUpdate :one
+SELECT * FROM mfa_challenges
+WHERE token_hash = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: AddMFAChallengeAttempt :one
+UPDATE mfa_challenges
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING *;
+
+-- name: UseMFAChallenge :one
+UPDATE mfa_challenges
+SET is_used = true
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
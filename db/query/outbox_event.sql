@@ -0,0 +1,34 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox_events (
+    id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    payload
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ClaimUnpublishedOutboxEvents :many
+SELECT * FROM outbox_events
+WHERE published_at IS NULL AND next_attempt_at <= now()
+ORDER BY created_at, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE outbox_events
+SET published_at = now()
+WHERE id = $1;
+
+-- name: RecordOutboxEventFailure :exec
+UPDATE outbox_events
+SET attempts = attempts + 1,
+    next_attempt_at = sqlc.arg(next_attempt_at),
+    last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
+
+-- name: ListOutboxEventsByAggregate :many
+SELECT * FROM outbox_events
+WHERE aggregate_type = $1 AND aggregate_id = $2
+ORDER BY created_at, id;
\ No newline at end of file
@@ -0,0 +1,33 @@
+-- name: CreatePasswordReset :one
+-- nothing is created while the user has an unused and unexpired reset, so the requests can't flood the inbox of the user
+INSERT INTO password_resets (
+  username,
+  token_hash,
+  expired_at
+)
+SELECT sqlc.arg(username)::varchar, sqlc.arg(token_hash)::varchar, sqlc.arg(expired_at)::timestamp
+WHERE NOT EXISTS (
+  SELECT 1 FROM password_resets
+  WHERE username = sqlc.arg(username)::varchar
+    AND is_used = false
+    AND expired_at > now()
+)
+RETURNING *;
+
+-- name: DeletePasswordReset :exec
+DELETE FROM password_resets
+WHERE id = $1;
+
+-- name: UsePasswordReset :one
+UPDATE password_resets
+SET is_used = true
+WHERE token_hash = $1
+  AND is_used = false
+  AND expired_at > now()
+RETURNING *;
+
+-- name: UseUserPasswordResets :exec
+-- the other outstanding tokens of the user can no longer be used once the password is reset
+UPDATE password_resets
+SET is_used = true
+WHERE username = $1 AND is_used = false;
\ No newline at end of file
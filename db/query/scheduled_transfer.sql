@@ -0,0 +1,56 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  recurrence,
+  start_at,
+  end_at,
+  next_run_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: ListScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id;
+
+-- name: CancelScheduledTransfer :one
+UPDATE scheduled_transfers
+SET status = 'cancelled',
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND owner = sqlc.arg(owner) AND status = 'active'
+RETURNING *;
+
+-- name: ClaimDueScheduledTransfer :one
+-- picks the next due scheduled transfer, a lock that expired belongs to a crashed scheduler
+UPDATE scheduled_transfers
+SET locked_until = sqlc.arg(locked_until)::timestamp,
+    updated_at = now()
+WHERE id = (
+  SELECT s.id FROM scheduled_transfers s
+  WHERE s.status = 'active'
+    AND s.next_run_at <= now()
+    AND (s.locked_until IS NULL OR s.locked_until < now())
+  ORDER BY s.next_run_at, s.id
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: AdvanceScheduledTransfer :one
+-- moves the schedule past the occurrence that just ran, it does nothing when another scheduler already did
+UPDATE scheduled_transfers
+SET next_run_at = sqlc.arg(next_run_at),
+    status = sqlc.arg(status),
+    locked_until = NULL,
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND next_run_at = sqlc.arg(occurrence_at) AND status = 'active'
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,18 @@
+-- name: CreateScheduledTransferRun :one
+INSERT INTO scheduled_transfer_runs (
+  scheduled_transfer_id,
+  occurrence_at,
+  status,
+  transfer_id,
+  error
+) VALUES (
+  $1, $2, $3, $4, $5
+) ON CONFLICT (scheduled_transfer_id, occurrence_at) DO NOTHING
+RETURNING *;
+
+-- name: ListScheduledTransferRuns :many
+SELECT * FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = sqlc.arg(scheduled_transfer_id)
+  AND (created_at, id) > (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::bigint)
+ORDER BY created_at, id
+LIMIT sqlc.arg(page_size);
\ No newline at end of file
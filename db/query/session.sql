@@ -6,11 +6,49 @@ INSERT INTO sessions (
     user_agent,
     client_ip,
     is_blocked,
+    parent_id,
+    family_id,
+    family_expired_at,
     expired_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
 
 -- name: GetSession :one
 SELECT * FROM sessions
-WHERE id = $1 LIMIT 1;
\ No newline at end of file
+WHERE id = $1 LIMIT 1;
+
+-- name: ListActiveSessions :many
+SELECT * FROM sessions
+WHERE username = $1
+  AND is_blocked = false
+  AND rotated_at IS NULL
+  AND expired_at > now()
+ORDER BY created_at DESC;
+
+-- name: BlockSession :one
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1 AND username = $2
+RETURNING *;
+
+-- name: BlockUserSessions :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND is_blocked = false;
+
+-- name: GetSessionForUpdate :one
+SELECT * FROM sessions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: RotateSession :one
+UPDATE sessions
+SET rotated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: BlockSessionFamily :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1 AND is_blocked = false;
\ No newline at end of file
@@ -0,0 +1,75 @@
+-- name: CreateTask :one
+INSERT INTO tasks (
+    type,
+    payload,
+    queue,
+    priority,
+    max_attempts,
+    run_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetTask :one
+SELECT * FROM tasks
+WHERE id = $1 LIMIT 1;
+
+-- name: ClaimTask :one
+-- picks the next due task of the highest priority, a running task whose lock expired
+-- belongs to a crashed worker and is picked up again
+UPDATE tasks
+SET status = 'running',
+    attempts = attempts + 1,
+    locked_until = sqlc.arg(locked_until)::timestamp,
+    updated_at = now()
+WHERE id = (
+    SELECT t.id FROM tasks t
+    WHERE (t.status = 'pending' AND t.run_at <= now())
+       OR (t.status = 'running' AND t.locked_until < now())
+    ORDER BY t.priority DESC, t.run_at, t.id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteTask :one
+UPDATE tasks
+SET status = 'completed',
+    locked_until = NULL,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: RetryTask :one
+UPDATE tasks
+SET status = 'pending',
+    run_at = sqlc.arg(run_at),
+    last_error = sqlc.arg(last_error),
+    locked_until = NULL,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: KillTask :one
+UPDATE tasks
+SET status = 'dead',
+    last_error = sqlc.arg(last_error),
+    locked_until = NULL,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListDeadTasks :many
+SELECT * FROM tasks
+WHERE status = 'dead'
+ORDER BY updated_at DESC
+LIMIT $1;
+
+-- name: RequeueDeadTask :one
+UPDATE tasks
+SET status = 'pending',
+    attempts = 0,
+    run_at = now(),
+    updated_at = now()
+WHERE id = $1 AND status = 'dead'
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,32 @@
+-- name: CreateTransferChallenge :one
+INSERT INTO transfer_challenges (
+  token_id,
+  username,
+  from_account_id,
+  to_account_id,
+  amount,
+  exchange_rate,
+  idempotency_key,
+  method,
+  expired_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetTransferChallengeForUpdate :one
+SELECT * FROM transfer_challenges
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: AddTransferChallengeAttempt :one
+UPDATE transfer_challenges
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING *;
+
+-- name: CompleteTransferChallenge :one
+UPDATE transfer_challenges
+SET status = 'completed',
+    transfer_id = sqlc.arg(transfer_id)
+WHERE id = sqlc.arg(id)
+RETURNING *;
\ No newline at end of file
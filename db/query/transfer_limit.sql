@@ -0,0 +1,47 @@
+-- name: GetAccountTransferLimit :one
+-- the limits of the account, each one falls back to the default of the account currency
+SELECT
+  a.id AS account_id,
+  a.currency,
+  COALESCE(l.max_per_transfer, d.max_per_transfer)::bigint AS max_per_transfer,
+  COALESCE(l.daily_amount, d.daily_amount)::bigint AS daily_amount,
+  COALESCE(l.daily_count, d.daily_count)::int AS daily_count
+FROM accounts a
+JOIN transfer_limit_defaults d ON d.currency = a.currency
+LEFT JOIN account_transfer_limits l ON l.account_id = a.id
+WHERE a.id = $1 LIMIT 1;
+
+-- name: GetTransferLimitDefault :one
+SELECT * FROM transfer_limit_defaults
+WHERE currency = $1 LIMIT 1;
+
+-- name: UpsertAccountTransferLimit :one
+INSERT INTO account_transfer_limits (
+  account_id,
+  max_per_transfer,
+  daily_amount,
+  daily_count,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4, $5
+) ON CONFLICT (account_id) DO UPDATE
+SET max_per_transfer = EXCLUDED.max_per_transfer,
+    daily_amount = EXCLUDED.daily_amount,
+    daily_count = EXCLUDED.daily_count,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: LockAccountTransferLimit :exec
+-- serializes the limit checks of the transfers out of an account without locking its row,
+-- so the balances can still be locked in the order of the account ids.
+-- The first key is the namespace of the lock, 1 for transfer limits and 2 for idempotency keys
+SELECT pg_advisory_xact_lock(1, (sqlc.arg(account_id)::bigint % 2147483647)::int);
+
+-- name: GetDailyTransferTotal :one
+-- the outgoing transfers of the account since the start of the day
+SELECT
+  COUNT(*)::int AS count,
+  COALESCE(SUM(amount), 0)::bigint AS amount
+FROM transfers
+WHERE from_account_id = $1 AND created_at >= date_trunc('day', now());
\ No newline at end of file
@@ -0,0 +1,23 @@
+-- name: CreateTransferReversal :one
+INSERT INTO transfer_reversals (
+  transfer_id,
+  amount,
+  to_amount,
+  reason,
+  journal_entry_id,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListTransferReversals :many
+SELECT * FROM transfer_reversals
+WHERE transfer_id = $1
+ORDER BY id;
+
+-- name: GetTransferReversedTotal :one
+SELECT
+  COALESCE(SUM(amount), 0)::bigint AS amount,
+  COALESCE(SUM(to_amount), 0)::bigint AS to_amount
+FROM transfer_reversals
+WHERE transfer_id = $1;
\ No newline at end of file
@@ -18,11 +18,18 @@ SET
     password = COALESCE(sqlc.narg(password),password),
     password_changed_at = COALESCE(sqlc.narg(password_changed_at),password_changed_at),
     full_name = COALESCE(sqlc.narg(full_name), full_name), 
-    email = COALESCE(sqlc.narg(email), email)
+    email = COALESCE(sqlc.narg(email), email),
+    is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified)
 WHERE 
     username = sqlc.arg(username) 
 RETURNING *; 
 
 
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2
+WHERE username = $1
+RETURNING *;
+
 -- name: ResetUserTable :exec
 DELETE FROM users;
@@ -0,0 +1,46 @@
+-- name: CreateWebhookDelivery :one
+-- a delivery exists once per endpoint and event, fanning out the same event again returns the existing row
+INSERT INTO webhook_deliveries (
+    endpoint_id,
+    event_id,
+    event_type,
+    payload
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (endpoint_id, event_id) DO UPDATE SET event_type = EXCLUDED.event_type
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE endpoint_id = sqlc.arg(endpoint_id)
+  AND (created_at, id) > (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::bigint)
+ORDER BY created_at, id
+LIMIT sqlc.arg(page_size);
+
+-- name: UpdateWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries
+SET status = sqlc.arg(status),
+    attempts = attempts + 1,
+    response_status = sqlc.narg(response_status),
+    last_error = sqlc.narg(last_error),
+    delivered_at = sqlc.narg(delivered_at)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: FailWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'failed',
+    last_error = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ResetWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pending'
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
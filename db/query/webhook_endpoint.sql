@@ -0,0 +1,31 @@
+-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (
+    owner,
+    url,
+    secret,
+    event_types
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetWebhookEndpoint :one
+SELECT * FROM webhook_endpoints
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookEndpoints :many
+SELECT * FROM webhook_endpoints
+WHERE owner = $1 AND is_active = true
+ORDER BY id;
+
+-- name: ListSubscribedWebhookEndpoints :many
+SELECT * FROM webhook_endpoints
+WHERE owner = $1
+  AND is_active = true
+  AND sqlc.arg(event_type)::varchar = ANY(event_types)
+ORDER BY id;
+
+-- name: DeactivateWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET is_active = false
+WHERE id = $1 AND owner = $2 AND is_active = true
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// constant for all account statuses
+const (
+	AccountActive = "active"
+	AccountFrozen = "frozen"
+	AccountClosed = "closed"
+)
+
+var (
+	// ErrAccountFrozen is returned when money would move in or out of a frozen account
+	ErrAccountFrozen = errors.New("account is frozen")
+	// ErrAccountClosed is returned when money would move in or out of a closed account
+	ErrAccountClosed = errors.New("account is closed")
+	// ErrInvalidStatusChange is returned when an account can't go from its status to the requested one
+	ErrInvalidStatusChange = errors.New("invalid account status change")
+	// ErrAccountBalanceNotZero is returned when closing an account that still holds money
+	ErrAccountBalanceNotZero = errors.New("account balance must be zero to close it")
+)
+
+// accountStatusChanges lists the statuses each status can change to,
+// a closed account can only be reopened and a frozen one has to be unfrozen before anything else but closing
+var accountStatusChanges = map[string][]string{
+	AccountActive: {AccountFrozen, AccountClosed},
+	AccountFrozen: {AccountActive, AccountClosed},
+	AccountClosed: {AccountActive},
+}
+
+// IsSupportedAccountStatus returns true if the status is one of the account statuses
+func IsSupportedAccountStatus(status string) bool {
+	_, ok := accountStatusChanges[status]
+	return ok
+}
+
+// CheckAccountActive returns ErrAccountFrozen or ErrAccountClosed when money can't move in or out of the account
+func CheckAccountActive(account Account) error {
+	switch account.Status {
+	case AccountFrozen:
+		return fmt.Errorf("%w: account [%d]", ErrAccountFrozen, account.ID)
+	case AccountClosed:
+		return fmt.Errorf("%w: account [%d]", ErrAccountClosed, account.ID)
+	}
+	return nil
+}
+
+// ChangeAccountStatusTxParams contains input parameters of the change account status transaction.
+// When FromStatus is set the change is refused unless the account has that status
+type ChangeAccountStatusTxParams struct {
+	AccountID  int64  `json:"account_id"`
+	Status     string `json:"status"`
+	FromStatus string `json:"from_status"`
+	Reason     string `json:"reason"`
+	ChangedBy  string `json:"changed_by"`
+}
+
+// ChangeAccountStatusTxResult contains result of ChangeAccountStatusTx
+type ChangeAccountStatusTxResult struct {
+	Account      Account             `json:"account"`
+	StatusChange AccountStatusChange `json:"status_change"`
+}
+
+// ChangeAccountStatusTx moves the account to a new status and records who did it and why.
+// The account row is locked while the change is checked, so a transfer can't credit an account being closed
+func (store *SQLStore) ChangeAccountStatusTx(ctx context.Context, arg ChangeAccountStatusTxParams) (ChangeAccountStatusTxResult, error) {
+	var result ChangeAccountStatusTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if arg.FromStatus != "" && account.Status != arg.FromStatus {
+			return fmt.Errorf("%w: account is %s, not %s", ErrInvalidStatusChange, account.Status, arg.FromStatus)
+		}
+
+		if !canChangeAccountStatus(account.Status, arg.Status) {
+			return fmt.Errorf("%w: from %s to %s", ErrInvalidStatusChange, account.Status, arg.Status)
+		}
+
+		if arg.Status == AccountClosed && account.Balance != 0 {
+			return ErrAccountBalanceNotZero
+		}
+
+		result.Account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:     arg.AccountID,
+			Status: arg.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.StatusChange, err = q.CreateAccountStatusChange(ctx, CreateAccountStatusChangeParams{
+			AccountID:  arg.AccountID,
+			FromStatus: account.Status,
+			ToStatus:   arg.Status,
+			Reason:     arg.Reason,
+			ChangedBy:  arg.ChangedBy,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+func canChangeAccountStatus(from string, to string) bool {
+	for _, status := range accountStatusChanges[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
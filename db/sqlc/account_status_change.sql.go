@@ -0,0 +1,103 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: account_status_change.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const createAccountStatusChange = `-- name: CreateAccountStatusChange :one
+INSERT INTO account_status_changes (
+    account_id,
+    from_status,
+    to_status,
+    reason,
+    changed_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, account_id, from_status, to_status, reason, changed_by, created_at
+`
+
+type CreateAccountStatusChangeParams struct {
+	AccountID  int64  `json:"account_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Reason     string `json:"reason"`
+	ChangedBy  string `json:"changed_by"`
+}
+
+func (q *Queries) CreateAccountStatusChange(ctx context.Context, arg CreateAccountStatusChangeParams) (AccountStatusChange, error) {
+	row := q.queryRow(ctx, q.createAccountStatusChangeStmt, createAccountStatusChange,
+		arg.AccountID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reason,
+		arg.ChangedBy,
+	)
+	var i AccountStatusChange
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Reason,
+		&i.ChangedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountStatusChanges = `-- name: ListAccountStatusChanges :many
+SELECT id, account_id, from_status, to_status, reason, changed_by, created_at FROM account_status_changes
+WHERE account_id = $1
+  AND (created_at, id) > ($2::timestamp, $3::bigint)
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListAccountStatusChangesParams struct {
+	AccountID       int64     `json:"account_id"`
+	CursorCreatedAt time.Time `json:"cursor_created_at"`
+	CursorID        int64     `json:"cursor_id"`
+	PageSize        int32     `json:"page_size"`
+}
+
+func (q *Queries) ListAccountStatusChanges(ctx context.Context, arg ListAccountStatusChangesParams) ([]AccountStatusChange, error) {
+	rows, err := q.query(ctx, q.listAccountStatusChangesStmt, listAccountStatusChanges,
+		arg.AccountID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AccountStatusChange{}
+	for rows.Next() {
+		var i AccountStatusChange
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.ChangedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Cell6969/go_bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeAccountStatusTxFreeze(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account := createRandomAccount(t)
+	require.Equal(t, AccountActive, account.Status)
+
+	result, err := store.ChangeAccountStatusTx(ctx, ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountFrozen,
+		Reason:    "suspicious activity",
+		ChangedBy: account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountFrozen, result.Account.Status)
+	require.Equal(t, AccountActive, result.StatusChange.FromStatus)
+	require.Equal(t, AccountFrozen, result.StatusChange.ToStatus)
+	require.Equal(t, "suspicious activity", result.StatusChange.Reason)
+	require.Equal(t, account.Owner, result.StatusChange.ChangedBy)
+
+	// money can't move in or out of a frozen account
+	_, err = store.DepositTx(ctx, CashTxParams{
+		AccountID: account.ID,
+		Amount:    10,
+		Channel:   util.ChannelBranch,
+		Reference: util.RandomString(12),
+		CreatedBy: account.Owner,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	_, err = store.TransferTx(ctx, TransferTxParams{
+		FromAccountId: createFundedAccount(t, 10).ID,
+		ToAccountId:   account.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	// freezing twice is not a change
+	_, err = store.ChangeAccountStatusTx(ctx, ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountFrozen,
+		Reason:    "again",
+		ChangedBy: account.Owner,
+	})
+	require.ErrorIs(t, err, ErrInvalidStatusChange)
+
+	statusChanges, err := testQueries.ListAccountStatusChanges(ctx, ListAccountStatusChangesParams{
+		AccountID: account.ID,
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, statusChanges, 1)
+}
+
+func TestChangeAccountStatusTxClose(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account := createRandomAccount(t)
+	require.NotZero(t, account.Balance)
+
+	arg := ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountClosed,
+		Reason:    "customer request",
+		ChangedBy: account.Owner,
+	}
+
+	_, err := store.ChangeAccountStatusTx(ctx, arg)
+	require.ErrorIs(t, err, ErrAccountBalanceNotZero)
+
+	_, err = testQueries.UpdateAccount(ctx, UpdateAccountParams{ID: account.ID, Balance: 0})
+	require.NoError(t, err)
+
+	result, err := store.ChangeAccountStatusTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, AccountClosed, result.Account.Status)
+
+	_, err = store.TransferTx(ctx, TransferTxParams{
+		FromAccountId: account.ID,
+		ToAccountId:   createRandomAccount(t).ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	// a closed account can't be frozen, only reopened
+	arg.Status = AccountFrozen
+	_, err = store.ChangeAccountStatusTx(ctx, arg)
+	require.ErrorIs(t, err, ErrInvalidStatusChange)
+
+	arg.Status = AccountActive
+	arg.Reason = "reopened by the customer"
+	result, err = store.ChangeAccountStatusTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, AccountActive, result.Account.Status)
+	require.Equal(t, AccountClosed, result.StatusChange.FromStatus)
+}
+
+func TestChangeAccountStatusTxFromStatus(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account := createRandomAccount(t)
+	_, err := testQueries.UpdateAccount(ctx, UpdateAccountParams{ID: account.ID, Balance: 0})
+	require.NoError(t, err)
+
+	_, err = store.ChangeAccountStatusTx(ctx, ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountFrozen,
+		Reason:    "suspicious activity",
+		ChangedBy: "banker",
+	})
+	require.NoError(t, err)
+
+	// an owner closing the account only closes it while it is active, the freeze of the bank stays
+	arg := ChangeAccountStatusTxParams{
+		AccountID:  account.ID,
+		Status:     AccountClosed,
+		FromStatus: AccountActive,
+		Reason:     "customer request",
+		ChangedBy:  account.Owner,
+	}
+	_, err = store.ChangeAccountStatusTx(ctx, arg)
+	require.ErrorIs(t, err, ErrInvalidStatusChange)
+
+	updatedAccount, err := testQueries.GetAccount(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountFrozen, updatedAccount.Status)
+
+	arg.FromStatus = ""
+	result, err := store.ChangeAccountStatusTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, AccountClosed, result.Account.Status)
+}
@@ -104,9 +104,8 @@ func TestListAccount(t *testing.T) {
 	}
 
 	arg := ListAccountParams{
-		Owner:  lastAccount.Owner,
-		Limit:  5,
-		Offset: 0,
+		Owner:    lastAccount.Owner,
+		PageSize: 5,
 	}
 
 	accounts, err := testQueries.ListAccount(ctx, arg)
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxAuthFailures is how many wrong codes or passwords a user can send, across all of their MFA and step-up challenges,
+// before being locked out
+const MaxAuthFailures = 5
+
+// AuthLockoutDuration is how long a user who reached MaxAuthFailures can't be challenged
+const AuthLockoutDuration = 15 * time.Minute
+
+var ErrAuthLocked = errors.New("too many failed attempts, try again later")
+
+// checkAuthLockout returns ErrAuthLocked while the user is locked out
+func checkAuthLockout(ctx context.Context, q *Queries, username string) error {
+	failure, err := q.GetAuthFailure(ctx, username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	if failure.LockedUntil.Valid && time.Now().Before(failure.LockedUntil.Time) {
+		return ErrAuthLocked
+	}
+
+	return nil
+}
+
+// CheckAuthLockout returns ErrAuthLocked while the user is locked out, so no new challenge is issued
+func (store *SQLStore) CheckAuthLockout(ctx context.Context, username string) error {
+	return checkAuthLockout(ctx, store.Queries, username)
+}
+
+// recordAuthFailure counts a wrong code or password of the user and wraps err with the error of the count, if any.
+// It runs outside of the transaction of the attempt, otherwise it would be rolled back with it
+func (store *SQLStore) recordAuthFailure(ctx context.Context, username string, err error) error {
+	_, failureErr := store.AddAuthFailure(ctx, AddAuthFailureParams{
+		Username:    username,
+		MaxAttempts: MaxAuthFailures,
+		LockedUntil: time.Now().Add(AuthLockoutDuration),
+	})
+	if failureErr != nil {
+		return fmt.Errorf("%w, add auth failure err: %v", err, failureErr)
+	}
+
+	return err
+}
@@ -0,0 +1,79 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: auth_failure.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const addAuthFailure = `-- name: AddAuthFailure :one
+INSERT INTO auth_failures (
+  username,
+  failed_attempts
+) VALUES (
+  $1, 1
+) ON CONFLICT (username) DO UPDATE
+SET failed_attempts = CASE
+      WHEN auth_failures.failed_attempts + 1 >= $2::int THEN 0
+      ELSE auth_failures.failed_attempts + 1
+    END,
+    locked_until = CASE
+      WHEN auth_failures.failed_attempts + 1 >= $2::int THEN $3::timestamp
+      ELSE auth_failures.locked_until
+    END,
+    updated_at = now()
+RETURNING username, failed_attempts, locked_until, updated_at
+`
+
+type AddAuthFailureParams struct {
+	Username    string    `json:"username"`
+	MaxAttempts int32     `json:"max_attempts"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// the counter starts over once it reaches max_attempts and the user is locked out until locked_until
+func (q *Queries) AddAuthFailure(ctx context.Context, arg AddAuthFailureParams) (AuthFailure, error) {
+	row := q.queryRow(ctx, q.addAuthFailureStmt, addAuthFailure, arg.Username, arg.MaxAttempts, arg.LockedUntil)
+	var i AuthFailure
+	err := row.Scan(
+		&i.Username,
+		&i.FailedAttempts,
+		&i.LockedUntil,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getAuthFailure = `-- name: GetAuthFailure :one
+SELECT username, failed_attempts, locked_until, updated_at FROM auth_failures
+WHERE username = $1 LIMIT 1
+`
+
+func (q *Queries) GetAuthFailure(ctx context.Context, username string) (AuthFailure, error) {
+	row := q.queryRow(ctx, q.getAuthFailureStmt, getAuthFailure, username)
+	var i AuthFailure
+	err := row.Scan(
+		&i.Username,
+		&i.FailedAttempts,
+		&i.LockedUntil,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const resetAuthFailures = `-- name: ResetAuthFailures :exec
+UPDATE auth_failures
+SET failed_attempts = 0,
+    locked_until = NULL,
+    updated_at = now()
+WHERE username = $1
+`
+
+func (q *Queries) ResetAuthFailures(ctx context.Context, username string) error {
+	_, err := q.exec(ctx, q.resetAuthFailuresStmt, resetAuthFailures, username)
+	return err
+}
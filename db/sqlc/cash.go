@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// constant for all cash transaction types
+const (
+	CashDeposit    = "deposit"
+	CashWithdrawal = "withdrawal"
+)
+
+// CashTxParams contains input parameters of the deposit and withdraw transactions.
+// Amount is always positive, Channel and Reference identify the operation on the outside,
+// CreatedBy is the operator or integration doing it
+type CashTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Amount    int64  `json:"amount"`
+	Channel   string `json:"channel"`
+	Reference string `json:"reference"`
+	CreatedBy string `json:"created_by"`
+}
+
+// CashTxResult contains result of DepositTx and WithdrawTx
+type CashTxResult struct {
+	CashTransaction CashTransaction `json:"cash_transaction"`
+	JournalEntry    JournalEntry    `json:"journal_entry"`
+	Account         Account         `json:"account"`
+	Entry           Entry           `json:"entry"`
+}
+
+// DepositTx credits the account against the cash system account of its currency
+func (store *SQLStore) DepositTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return store.cashTx(ctx, CashDeposit, arg)
+}
+
+// WithdrawTx debits the account against the cash system account of its currency,
+// it fails with ErrInsufficientFunds when the account would go past its overdraft limit
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return store.cashTx(ctx, CashWithdrawal, arg)
+}
+
+// cashTx posts the deposit or withdrawal journal entry, records the cash transaction and writes its outbox event
+// in one database transaction
+func (store *SQLStore) cashTx(ctx context.Context, cashType string, arg CashTxParams) (CashTxResult, error) {
+	var result CashTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		accountLedger, err := q.GetLedgerAccountByAccountID(ctx, sql.NullInt64{Int64: arg.AccountID, Valid: true})
+		if err != nil {
+			return fmt.Errorf("failed to get ledger account of account %d: %w", arg.AccountID, err)
+		}
+
+		cashLedger, err := q.GetLedgerAccountByCode(ctx, SystemLedgerCode(LedgerCash, accountLedger.Currency))
+		if err != nil {
+			return fmt.Errorf("failed to get cash ledger account: %w", err)
+		}
+
+		journalType, amount := JournalDeposit, arg.Amount
+		if cashType == CashWithdrawal {
+			journalType, amount = JournalWithdrawal, -arg.Amount
+		}
+
+		journal, err := postJournal(ctx, q, PostJournalTxParams{
+			Type:        journalType,
+			Description: fmt.Sprintf("%s through %s, reference %s", cashType, arg.Channel, arg.Reference),
+			Postings: []JournalPosting{
+				{LedgerAccountID: accountLedger.ID, Amount: amount},
+				{LedgerAccountID: cashLedger.ID, Amount: -amount},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.JournalEntry = journal.JournalEntry
+		result.Entry = journal.Entries[0]
+		result.Account = journal.Accounts[0]
+
+		result.CashTransaction, err = q.CreateCashTransaction(ctx, CreateCashTransactionParams{
+			AccountID:      arg.AccountID,
+			Type:           cashType,
+			Amount:         arg.Amount,
+			Channel:        arg.Channel,
+			Reference:      arg.Reference,
+			JournalEntryID: result.JournalEntry.ID,
+			EntryID:        result.Entry.ID,
+			CreatedBy:      arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		return createCashEvent(ctx, q, result)
+	})
+
+	return result, err
+}
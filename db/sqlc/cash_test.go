@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/Cell6969/go_bank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// requireCashEvent checks the outbox event written with the deposit or withdrawal
+func requireCashEvent(t *testing.T, result CashTxResult, eventType string) {
+	events, err := testQueries.ListOutboxEventsByAggregate(context.Background(), ListOutboxEventsByAggregateParams{
+		AggregateType: AggregateCashTransaction,
+		AggregateID:   strconv.FormatInt(result.CashTransaction.ID, 10),
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, eventType, events[0].EventType)
+
+	var payload CashPayload
+	err = json.Unmarshal(events[0].Payload, &payload)
+	require.NoError(t, err)
+	require.Equal(t, result.CashTransaction.ID, payload.CashTransactionID)
+	require.Equal(t, result.CashTransaction.AccountID, payload.AccountID)
+	require.Equal(t, result.Entry.ID, payload.EntryID)
+}
+
+func TestDepositTx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account := createRandomAccount(t)
+	arg := CashTxParams{
+		AccountID: account.ID,
+		Amount:    util.GenerateRandomMoney(),
+		Channel:   util.ChannelBranch,
+		Reference: util.RandomString(12),
+		CreatedBy: account.Owner,
+	}
+
+	result, err := store.DepositTx(ctx, arg)
+	require.NoError(t, err)
+
+	require.Equal(t, CashDeposit, result.CashTransaction.Type)
+	require.Equal(t, arg.Amount, result.CashTransaction.Amount)
+	require.Equal(t, arg.Channel, result.CashTransaction.Channel)
+	require.Equal(t, arg.Reference, result.CashTransaction.Reference)
+	require.Equal(t, arg.CreatedBy, result.CashTransaction.CreatedBy)
+	require.Equal(t, result.JournalEntry.ID, result.CashTransaction.JournalEntryID)
+	require.Equal(t, result.Entry.ID, result.CashTransaction.EntryID)
+
+	require.Equal(t, JournalDeposit, result.JournalEntry.Type)
+	require.Equal(t, arg.Amount, result.Entry.Amount)
+	require.Equal(t, account.Balance+arg.Amount, result.Account.Balance)
+
+	postings := requireBalancedJournal(t, result.JournalEntry.ID)
+	require.Len(t, postings, 2)
+	require.Equal(t, getAccountLedger(t, account).ID, postings[0].LedgerAccountID)
+
+	requireCashEvent(t, result, EventCashDeposited)
+	require.Equal(t, getSystemLedger(t, LedgerCash, account.Currency).ID, postings[1].LedgerAccountID)
+
+	// the reference can't be used twice on the same channel
+	_, err = store.DepositTx(ctx, arg)
+	require.Error(t, err)
+	pqErr, ok := err.(*pq.Error)
+	require.True(t, ok)
+	require.Equal(t, "unique_violation", pqErr.Code.Name())
+
+	arg.Channel = util.ChannelATM
+	_, err = store.DepositTx(ctx, arg)
+	require.NoError(t, err)
+}
+
+func TestWithdrawTx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	amount := int64(10)
+	account := createFundedAccount(t, amount)
+	arg := CashTxParams{
+		AccountID: account.ID,
+		Amount:    amount,
+		Channel:   util.ChannelATM,
+		Reference: util.RandomString(12),
+		CreatedBy: account.Owner,
+	}
+
+	result, err := store.WithdrawTx(ctx, arg)
+	require.NoError(t, err)
+
+	require.Equal(t, CashWithdrawal, result.CashTransaction.Type)
+	require.Equal(t, amount, result.CashTransaction.Amount)
+	require.Equal(t, JournalWithdrawal, result.JournalEntry.Type)
+	require.Equal(t, -amount, result.Entry.Amount)
+	require.Equal(t, account.Balance-amount, result.Account.Balance)
+
+	postings := requireBalancedJournal(t, result.JournalEntry.ID)
+	require.Len(t, postings, 2)
+	require.Equal(t, getSystemLedger(t, LedgerCash, account.Currency).ID, postings[1].LedgerAccountID)
+
+	requireCashEvent(t, result, EventCashWithdrawn)
+
+	// more than the balance and the overdraft limit is refused and nothing is written
+	arg.Amount = result.Account.Balance + result.Account.OverdraftLimit + 1
+	arg.Reference = util.RandomString(12)
+	_, err = store.WithdrawTx(ctx, arg)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	updatedAccount, err := testQueries.GetAccount(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Account.Balance, updatedAccount.Balance)
+}
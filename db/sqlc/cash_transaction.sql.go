@@ -0,0 +1,86 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: cash_transaction.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createCashTransaction = `-- name: CreateCashTransaction :one
+INSERT INTO cash_transactions (
+    account_id,
+    type,
+    amount,
+    channel,
+    reference,
+    journal_entry_id,
+    entry_id,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING id, account_id, type, amount, channel, reference, journal_entry_id, entry_id, created_by, created_at
+`
+
+type CreateCashTransactionParams struct {
+	AccountID      int64  `json:"account_id"`
+	Type           string `json:"type"`
+	Amount         int64  `json:"amount"`
+	Channel        string `json:"channel"`
+	Reference      string `json:"reference"`
+	JournalEntryID int64  `json:"journal_entry_id"`
+	EntryID        int64  `json:"entry_id"`
+	CreatedBy      string `json:"created_by"`
+}
+
+func (q *Queries) CreateCashTransaction(ctx context.Context, arg CreateCashTransactionParams) (CashTransaction, error) {
+	row := q.queryRow(ctx, q.createCashTransactionStmt, createCashTransaction,
+		arg.AccountID,
+		arg.Type,
+		arg.Amount,
+		arg.Channel,
+		arg.Reference,
+		arg.JournalEntryID,
+		arg.EntryID,
+		arg.CreatedBy,
+	)
+	var i CashTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Type,
+		&i.Amount,
+		&i.Channel,
+		&i.Reference,
+		&i.JournalEntryID,
+		&i.EntryID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCashTransaction = `-- name: GetCashTransaction :one
+SELECT id, account_id, type, amount, channel, reference, journal_entry_id, entry_id, created_by, created_at FROM cash_transactions
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetCashTransaction(ctx context.Context, id int64) (CashTransaction, error) {
+	row := q.queryRow(ctx, q.getCashTransactionStmt, getCashTransaction, id)
+	var i CashTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Type,
+		&i.Amount,
+		&i.Channel,
+		&i.Reference,
+		&i.JournalEntryID,
+		&i.EntryID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -27,54 +27,312 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.addAccountBalanceStmt, err = db.PrepareContext(ctx, addAccountBalance); err != nil {
 		return nil, fmt.Errorf("error preparing query AddAccountBalance: %w", err)
 	}
+	if q.addAuthFailureStmt, err = db.PrepareContext(ctx, addAuthFailure); err != nil {
+		return nil, fmt.Errorf("error preparing query AddAuthFailure: %w", err)
+	}
+	if q.addMFAChallengeAttemptStmt, err = db.PrepareContext(ctx, addMFAChallengeAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query AddMFAChallengeAttempt: %w", err)
+	}
+	if q.addTransferChallengeAttemptStmt, err = db.PrepareContext(ctx, addTransferChallengeAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query AddTransferChallengeAttempt: %w", err)
+	}
+	if q.advanceScheduledTransferStmt, err = db.PrepareContext(ctx, advanceScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query AdvanceScheduledTransfer: %w", err)
+	}
+	if q.blockSessionStmt, err = db.PrepareContext(ctx, blockSession); err != nil {
+		return nil, fmt.Errorf("error preparing query BlockSession: %w", err)
+	}
+	if q.blockSessionFamilyStmt, err = db.PrepareContext(ctx, blockSessionFamily); err != nil {
+		return nil, fmt.Errorf("error preparing query BlockSessionFamily: %w", err)
+	}
+	if q.blockUserSessionsStmt, err = db.PrepareContext(ctx, blockUserSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query BlockUserSessions: %w", err)
+	}
+	if q.cancelScheduledTransferStmt, err = db.PrepareContext(ctx, cancelScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query CancelScheduledTransfer: %w", err)
+	}
+	if q.claimDueScheduledTransferStmt, err = db.PrepareContext(ctx, claimDueScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimDueScheduledTransfer: %w", err)
+	}
+	if q.claimTaskStmt, err = db.PrepareContext(ctx, claimTask); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimTask: %w", err)
+	}
+	if q.claimUnpublishedOutboxEventsStmt, err = db.PrepareContext(ctx, claimUnpublishedOutboxEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimUnpublishedOutboxEvents: %w", err)
+	}
+	if q.completeTaskStmt, err = db.PrepareContext(ctx, completeTask); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteTask: %w", err)
+	}
+	if q.completeTransferChallengeStmt, err = db.PrepareContext(ctx, completeTransferChallenge); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteTransferChallenge: %w", err)
+	}
 	if q.createAccountStmt, err = db.PrepareContext(ctx, createAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateAccount: %w", err)
 	}
+	if q.createAccountStatusChangeStmt, err = db.PrepareContext(ctx, createAccountStatusChange); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAccountStatusChange: %w", err)
+	}
+	if q.createCashTransactionStmt, err = db.PrepareContext(ctx, createCashTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCashTransaction: %w", err)
+	}
 	if q.createEntryStmt, err = db.PrepareContext(ctx, createEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateEntry: %w", err)
 	}
+	if q.createIdempotencyKeyStmt, err = db.PrepareContext(ctx, createIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateIdempotencyKey: %w", err)
+	}
+	if q.createJournalEntryStmt, err = db.PrepareContext(ctx, createJournalEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateJournalEntry: %w", err)
+	}
+	if q.createMFAChallengeStmt, err = db.PrepareContext(ctx, createMFAChallenge); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMFAChallenge: %w", err)
+	}
+	if q.createMFAEnrollmentStmt, err = db.PrepareContext(ctx, createMFAEnrollment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMFAEnrollment: %w", err)
+	}
+	if q.createMFARecoveryCodeStmt, err = db.PrepareContext(ctx, createMFARecoveryCode); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMFARecoveryCode: %w", err)
+	}
+	if q.createOutboxEventStmt, err = db.PrepareContext(ctx, createOutboxEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOutboxEvent: %w", err)
+	}
+	if q.createPasswordResetStmt, err = db.PrepareContext(ctx, createPasswordReset); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePasswordReset: %w", err)
+	}
+	if q.createPostingStmt, err = db.PrepareContext(ctx, createPosting); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePosting: %w", err)
+	}
+	if q.createScheduledTransferStmt, err = db.PrepareContext(ctx, createScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateScheduledTransfer: %w", err)
+	}
+	if q.createScheduledTransferRunStmt, err = db.PrepareContext(ctx, createScheduledTransferRun); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateScheduledTransferRun: %w", err)
+	}
 	if q.createSessionStmt, err = db.PrepareContext(ctx, createSession); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateSession: %w", err)
 	}
+	if q.createTaskStmt, err = db.PrepareContext(ctx, createTask); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTask: %w", err)
+	}
 	if q.createTransferStmt, err = db.PrepareContext(ctx, createTransfer); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateTransfer: %w", err)
 	}
+	if q.createTransferChallengeStmt, err = db.PrepareContext(ctx, createTransferChallenge); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTransferChallenge: %w", err)
+	}
+	if q.createTransferReversalStmt, err = db.PrepareContext(ctx, createTransferReversal); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTransferReversal: %w", err)
+	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
+	if q.createVerifyEmailStmt, err = db.PrepareContext(ctx, createVerifyEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateVerifyEmail: %w", err)
+	}
+	if q.createWebhookDeliveryStmt, err = db.PrepareContext(ctx, createWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookDelivery: %w", err)
+	}
+	if q.createWebhookEndpointStmt, err = db.PrepareContext(ctx, createWebhookEndpoint); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookEndpoint: %w", err)
+	}
+	if q.deactivateWebhookEndpointStmt, err = db.PrepareContext(ctx, deactivateWebhookEndpoint); err != nil {
+		return nil, fmt.Errorf("error preparing query DeactivateWebhookEndpoint: %w", err)
+	}
 	if q.deleteAccountStmt, err = db.PrepareContext(ctx, deleteAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteAccount: %w", err)
 	}
+	if q.deleteMFAEnrollmentStmt, err = db.PrepareContext(ctx, deleteMFAEnrollment); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteMFAEnrollment: %w", err)
+	}
+	if q.deleteMFARecoveryCodesStmt, err = db.PrepareContext(ctx, deleteMFARecoveryCodes); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteMFARecoveryCodes: %w", err)
+	}
+	if q.deletePasswordResetStmt, err = db.PrepareContext(ctx, deletePasswordReset); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePasswordReset: %w", err)
+	}
+	if q.enableMFAEnrollmentStmt, err = db.PrepareContext(ctx, enableMFAEnrollment); err != nil {
+		return nil, fmt.Errorf("error preparing query EnableMFAEnrollment: %w", err)
+	}
+	if q.failWebhookDeliveryStmt, err = db.PrepareContext(ctx, failWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query FailWebhookDelivery: %w", err)
+	}
 	if q.getAccountStmt, err = db.PrepareContext(ctx, getAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAccount: %w", err)
 	}
 	if q.getAccountForUpdateStmt, err = db.PrepareContext(ctx, getAccountForUpdate); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAccountForUpdate: %w", err)
 	}
+	if q.getAccountTransferLimitStmt, err = db.PrepareContext(ctx, getAccountTransferLimit); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAccountTransferLimit: %w", err)
+	}
+	if q.getAuthFailureStmt, err = db.PrepareContext(ctx, getAuthFailure); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAuthFailure: %w", err)
+	}
+	if q.getCashTransactionStmt, err = db.PrepareContext(ctx, getCashTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCashTransaction: %w", err)
+	}
+	if q.getDailyTransferTotalStmt, err = db.PrepareContext(ctx, getDailyTransferTotal); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDailyTransferTotal: %w", err)
+	}
+	if q.getEntriesSumSinceStmt, err = db.PrepareContext(ctx, getEntriesSumSince); err != nil {
+		return nil, fmt.Errorf("error preparing query GetEntriesSumSince: %w", err)
+	}
+	if q.getEntriesTotalStmt, err = db.PrepareContext(ctx, getEntriesTotal); err != nil {
+		return nil, fmt.Errorf("error preparing query GetEntriesTotal: %w", err)
+	}
 	if q.getEntryStmt, err = db.PrepareContext(ctx, getEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query GetEntry: %w", err)
 	}
+	if q.getIdempotencyKeyStmt, err = db.PrepareContext(ctx, getIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query GetIdempotencyKey: %w", err)
+	}
+	if q.getJournalEntryStmt, err = db.PrepareContext(ctx, getJournalEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query GetJournalEntry: %w", err)
+	}
+	if q.getLedgerAccountStmt, err = db.PrepareContext(ctx, getLedgerAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLedgerAccount: %w", err)
+	}
+	if q.getLedgerAccountByAccountIDStmt, err = db.PrepareContext(ctx, getLedgerAccountByAccountID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLedgerAccountByAccountID: %w", err)
+	}
+	if q.getLedgerAccountByCodeStmt, err = db.PrepareContext(ctx, getLedgerAccountByCode); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLedgerAccountByCode: %w", err)
+	}
+	if q.getMFAChallengeForUpdateStmt, err = db.PrepareContext(ctx, getMFAChallengeForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMFAChallengeForUpdate: %w", err)
+	}
+	if q.getMFAEnrollmentStmt, err = db.PrepareContext(ctx, getMFAEnrollment); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMFAEnrollment: %w", err)
+	}
+	if q.getMFAEnrollmentForUpdateStmt, err = db.PrepareContext(ctx, getMFAEnrollmentForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMFAEnrollmentForUpdate: %w", err)
+	}
+	if q.getScheduledTransferStmt, err = db.PrepareContext(ctx, getScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query GetScheduledTransfer: %w", err)
+	}
 	if q.getSessionStmt, err = db.PrepareContext(ctx, getSession); err != nil {
 		return nil, fmt.Errorf("error preparing query GetSession: %w", err)
 	}
+	if q.getSessionForUpdateStmt, err = db.PrepareContext(ctx, getSessionForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSessionForUpdate: %w", err)
+	}
+	if q.getStatementEntriesSumAfterStmt, err = db.PrepareContext(ctx, getStatementEntriesSumAfter); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStatementEntriesSumAfter: %w", err)
+	}
+	if q.getTaskStmt, err = db.PrepareContext(ctx, getTask); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTask: %w", err)
+	}
 	if q.getTransferStmt, err = db.PrepareContext(ctx, getTransfer); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTransfer: %w", err)
 	}
+	if q.getTransferChallengeForUpdateStmt, err = db.PrepareContext(ctx, getTransferChallengeForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferChallengeForUpdate: %w", err)
+	}
+	if q.getTransferForUpdateStmt, err = db.PrepareContext(ctx, getTransferForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferForUpdate: %w", err)
+	}
+	if q.getTransferLimitDefaultStmt, err = db.PrepareContext(ctx, getTransferLimitDefault); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferLimitDefault: %w", err)
+	}
+	if q.getTransferReversedTotalStmt, err = db.PrepareContext(ctx, getTransferReversedTotal); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferReversedTotal: %w", err)
+	}
 	if q.getUserStmt, err = db.PrepareContext(ctx, getUser); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUser: %w", err)
 	}
+	if q.getVerifyEmailStmt, err = db.PrepareContext(ctx, getVerifyEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query GetVerifyEmail: %w", err)
+	}
+	if q.getWebhookDeliveryStmt, err = db.PrepareContext(ctx, getWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookDelivery: %w", err)
+	}
+	if q.getWebhookEndpointStmt, err = db.PrepareContext(ctx, getWebhookEndpoint); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookEndpoint: %w", err)
+	}
+	if q.killTaskStmt, err = db.PrepareContext(ctx, killTask); err != nil {
+		return nil, fmt.Errorf("error preparing query KillTask: %w", err)
+	}
 	if q.listAccountStmt, err = db.PrepareContext(ctx, listAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query ListAccount: %w", err)
 	}
+	if q.listAccountStatusChangesStmt, err = db.PrepareContext(ctx, listAccountStatusChanges); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAccountStatusChanges: %w", err)
+	}
+	if q.listActiveSessionsStmt, err = db.PrepareContext(ctx, listActiveSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveSessions: %w", err)
+	}
+	if q.listBalanceDriftsStmt, err = db.PrepareContext(ctx, listBalanceDrifts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBalanceDrifts: %w", err)
+	}
+	if q.listDeadTasksStmt, err = db.PrepareContext(ctx, listDeadTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDeadTasks: %w", err)
+	}
 	if q.listEntriesStmt, err = db.PrepareContext(ctx, listEntries); err != nil {
 		return nil, fmt.Errorf("error preparing query ListEntries: %w", err)
 	}
+	if q.listEntriesAfterStmt, err = db.PrepareContext(ctx, listEntriesAfter); err != nil {
+		return nil, fmt.Errorf("error preparing query ListEntriesAfter: %w", err)
+	}
+	if q.listJournalEntriesByTransferStmt, err = db.PrepareContext(ctx, listJournalEntriesByTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query ListJournalEntriesByTransfer: %w", err)
+	}
+	if q.listOutboxEventsByAggregateStmt, err = db.PrepareContext(ctx, listOutboxEventsByAggregate); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOutboxEventsByAggregate: %w", err)
+	}
+	if q.listPostingsStmt, err = db.PrepareContext(ctx, listPostings); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPostings: %w", err)
+	}
+	if q.listScheduledTransferRunsStmt, err = db.PrepareContext(ctx, listScheduledTransferRuns); err != nil {
+		return nil, fmt.Errorf("error preparing query ListScheduledTransferRuns: %w", err)
+	}
+	if q.listScheduledTransfersStmt, err = db.PrepareContext(ctx, listScheduledTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListScheduledTransfers: %w", err)
+	}
+	if q.listStatementEntriesStmt, err = db.PrepareContext(ctx, listStatementEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatementEntries: %w", err)
+	}
+	if q.listSubscribedWebhookEndpointsStmt, err = db.PrepareContext(ctx, listSubscribedWebhookEndpoints); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSubscribedWebhookEndpoints: %w", err)
+	}
+	if q.listSystemLedgerAccountBalancesStmt, err = db.PrepareContext(ctx, listSystemLedgerAccountBalances); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSystemLedgerAccountBalances: %w", err)
+	}
+	if q.listTransferReversalsStmt, err = db.PrepareContext(ctx, listTransferReversals); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransferReversals: %w", err)
+	}
 	if q.listTransfersStmt, err = db.PrepareContext(ctx, listTransfers); err != nil {
 		return nil, fmt.Errorf("error preparing query ListTransfers: %w", err)
 	}
+	if q.listUnbalancedJournalEntriesStmt, err = db.PrepareContext(ctx, listUnbalancedJournalEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnbalancedJournalEntries: %w", err)
+	}
+	if q.listWebhookDeliveriesStmt, err = db.PrepareContext(ctx, listWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebhookDeliveries: %w", err)
+	}
+	if q.listWebhookEndpointsStmt, err = db.PrepareContext(ctx, listWebhookEndpoints); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebhookEndpoints: %w", err)
+	}
+	if q.lockAccountTransferLimitStmt, err = db.PrepareContext(ctx, lockAccountTransferLimit); err != nil {
+		return nil, fmt.Errorf("error preparing query LockAccountTransferLimit: %w", err)
+	}
+	if q.lockIdempotencyKeyStmt, err = db.PrepareContext(ctx, lockIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query LockIdempotencyKey: %w", err)
+	}
+	if q.markOutboxEventPublishedStmt, err = db.PrepareContext(ctx, markOutboxEventPublished); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkOutboxEventPublished: %w", err)
+	}
+	if q.recordOutboxEventFailureStmt, err = db.PrepareContext(ctx, recordOutboxEventFailure); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordOutboxEventFailure: %w", err)
+	}
+	if q.requeueDeadTaskStmt, err = db.PrepareContext(ctx, requeueDeadTask); err != nil {
+		return nil, fmt.Errorf("error preparing query RequeueDeadTask: %w", err)
+	}
 	if q.resetAccountTableStmt, err = db.PrepareContext(ctx, resetAccountTable); err != nil {
 		return nil, fmt.Errorf("error preparing query ResetAccountTable: %w", err)
 	}
+	if q.resetAuthFailuresStmt, err = db.PrepareContext(ctx, resetAuthFailures); err != nil {
+		return nil, fmt.Errorf("error preparing query ResetAuthFailures: %w", err)
+	}
 	if q.resetEntryTableStmt, err = db.PrepareContext(ctx, resetEntryTable); err != nil {
 		return nil, fmt.Errorf("error preparing query ResetEntryTable: %w", err)
 	}
@@ -84,12 +342,54 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.resetUserTableStmt, err = db.PrepareContext(ctx, resetUserTable); err != nil {
 		return nil, fmt.Errorf("error preparing query ResetUserTable: %w", err)
 	}
+	if q.resetWebhookDeliveryStmt, err = db.PrepareContext(ctx, resetWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query ResetWebhookDelivery: %w", err)
+	}
+	if q.retryTaskStmt, err = db.PrepareContext(ctx, retryTask); err != nil {
+		return nil, fmt.Errorf("error preparing query RetryTask: %w", err)
+	}
+	if q.rotateSessionStmt, err = db.PrepareContext(ctx, rotateSession); err != nil {
+		return nil, fmt.Errorf("error preparing query RotateSession: %w", err)
+	}
 	if q.updateAccountStmt, err = db.PrepareContext(ctx, updateAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateAccount: %w", err)
 	}
+	if q.updateAccountOverdraftLimitStmt, err = db.PrepareContext(ctx, updateAccountOverdraftLimit); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateAccountOverdraftLimit: %w", err)
+	}
+	if q.updateAccountStatusStmt, err = db.PrepareContext(ctx, updateAccountStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateAccountStatus: %w", err)
+	}
+	if q.updateMFALastUsedStepStmt, err = db.PrepareContext(ctx, updateMFALastUsedStep); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateMFALastUsedStep: %w", err)
+	}
 	if q.updateUserStmt, err = db.PrepareContext(ctx, updateUser); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUser: %w", err)
 	}
+	if q.updateUserRoleStmt, err = db.PrepareContext(ctx, updateUserRole); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserRole: %w", err)
+	}
+	if q.updateVerifyEmailStmt, err = db.PrepareContext(ctx, updateVerifyEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateVerifyEmail: %w", err)
+	}
+	if q.updateWebhookDeliveryAttemptStmt, err = db.PrepareContext(ctx, updateWebhookDeliveryAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateWebhookDeliveryAttempt: %w", err)
+	}
+	if q.upsertAccountTransferLimitStmt, err = db.PrepareContext(ctx, upsertAccountTransferLimit); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertAccountTransferLimit: %w", err)
+	}
+	if q.useMFAChallengeStmt, err = db.PrepareContext(ctx, useMFAChallenge); err != nil {
+		return nil, fmt.Errorf("error preparing query UseMFAChallenge: %w", err)
+	}
+	if q.useMFARecoveryCodeStmt, err = db.PrepareContext(ctx, useMFARecoveryCode); err != nil {
+		return nil, fmt.Errorf("error preparing query UseMFARecoveryCode: %w", err)
+	}
+	if q.usePasswordResetStmt, err = db.PrepareContext(ctx, usePasswordReset); err != nil {
+		return nil, fmt.Errorf("error preparing query UsePasswordReset: %w", err)
+	}
+	if q.useUserPasswordResetsStmt, err = db.PrepareContext(ctx, useUserPasswordResets); err != nil {
+		return nil, fmt.Errorf("error preparing query UseUserPasswordResets: %w", err)
+	}
 	return &q, nil
 }
 
@@ -100,36 +400,221 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addAccountBalanceStmt: %w", cerr)
 		}
 	}
+	if q.addAuthFailureStmt != nil {
+		if cerr := q.addAuthFailureStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addAuthFailureStmt: %w", cerr)
+		}
+	}
+	if q.addMFAChallengeAttemptStmt != nil {
+		if cerr := q.addMFAChallengeAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addMFAChallengeAttemptStmt: %w", cerr)
+		}
+	}
+	if q.addTransferChallengeAttemptStmt != nil {
+		if cerr := q.addTransferChallengeAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addTransferChallengeAttemptStmt: %w", cerr)
+		}
+	}
+	if q.advanceScheduledTransferStmt != nil {
+		if cerr := q.advanceScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing advanceScheduledTransferStmt: %w", cerr)
+		}
+	}
+	if q.blockSessionStmt != nil {
+		if cerr := q.blockSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing blockSessionStmt: %w", cerr)
+		}
+	}
+	if q.blockSessionFamilyStmt != nil {
+		if cerr := q.blockSessionFamilyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing blockSessionFamilyStmt: %w", cerr)
+		}
+	}
+	if q.blockUserSessionsStmt != nil {
+		if cerr := q.blockUserSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing blockUserSessionsStmt: %w", cerr)
+		}
+	}
+	if q.cancelScheduledTransferStmt != nil {
+		if cerr := q.cancelScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cancelScheduledTransferStmt: %w", cerr)
+		}
+	}
+	if q.claimDueScheduledTransferStmt != nil {
+		if cerr := q.claimDueScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimDueScheduledTransferStmt: %w", cerr)
+		}
+	}
+	if q.claimTaskStmt != nil {
+		if cerr := q.claimTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimTaskStmt: %w", cerr)
+		}
+	}
+	if q.claimUnpublishedOutboxEventsStmt != nil {
+		if cerr := q.claimUnpublishedOutboxEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimUnpublishedOutboxEventsStmt: %w", cerr)
+		}
+	}
+	if q.completeTaskStmt != nil {
+		if cerr := q.completeTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeTaskStmt: %w", cerr)
+		}
+	}
+	if q.completeTransferChallengeStmt != nil {
+		if cerr := q.completeTransferChallengeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeTransferChallengeStmt: %w", cerr)
+		}
+	}
 	if q.createAccountStmt != nil {
 		if cerr := q.createAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createAccountStmt: %w", cerr)
 		}
 	}
+	if q.createAccountStatusChangeStmt != nil {
+		if cerr := q.createAccountStatusChangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAccountStatusChangeStmt: %w", cerr)
+		}
+	}
+	if q.createCashTransactionStmt != nil {
+		if cerr := q.createCashTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCashTransactionStmt: %w", cerr)
+		}
+	}
 	if q.createEntryStmt != nil {
 		if cerr := q.createEntryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createEntryStmt: %w", cerr)
 		}
 	}
+	if q.createIdempotencyKeyStmt != nil {
+		if cerr := q.createIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.createJournalEntryStmt != nil {
+		if cerr := q.createJournalEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createJournalEntryStmt: %w", cerr)
+		}
+	}
+	if q.createMFAChallengeStmt != nil {
+		if cerr := q.createMFAChallengeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMFAChallengeStmt: %w", cerr)
+		}
+	}
+	if q.createMFAEnrollmentStmt != nil {
+		if cerr := q.createMFAEnrollmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMFAEnrollmentStmt: %w", cerr)
+		}
+	}
+	if q.createMFARecoveryCodeStmt != nil {
+		if cerr := q.createMFARecoveryCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMFARecoveryCodeStmt: %w", cerr)
+		}
+	}
+	if q.createOutboxEventStmt != nil {
+		if cerr := q.createOutboxEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOutboxEventStmt: %w", cerr)
+		}
+	}
+	if q.createPasswordResetStmt != nil {
+		if cerr := q.createPasswordResetStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPasswordResetStmt: %w", cerr)
+		}
+	}
+	if q.createPostingStmt != nil {
+		if cerr := q.createPostingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPostingStmt: %w", cerr)
+		}
+	}
+	if q.createScheduledTransferStmt != nil {
+		if cerr := q.createScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createScheduledTransferStmt: %w", cerr)
+		}
+	}
+	if q.createScheduledTransferRunStmt != nil {
+		if cerr := q.createScheduledTransferRunStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createScheduledTransferRunStmt: %w", cerr)
+		}
+	}
 	if q.createSessionStmt != nil {
 		if cerr := q.createSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createSessionStmt: %w", cerr)
 		}
 	}
+	if q.createTaskStmt != nil {
+		if cerr := q.createTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTaskStmt: %w", cerr)
+		}
+	}
 	if q.createTransferStmt != nil {
 		if cerr := q.createTransferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createTransferStmt: %w", cerr)
 		}
 	}
+	if q.createTransferChallengeStmt != nil {
+		if cerr := q.createTransferChallengeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTransferChallengeStmt: %w", cerr)
+		}
+	}
+	if q.createTransferReversalStmt != nil {
+		if cerr := q.createTransferReversalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTransferReversalStmt: %w", cerr)
+		}
+	}
 	if q.createUserStmt != nil {
 		if cerr := q.createUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
 		}
 	}
+	if q.createVerifyEmailStmt != nil {
+		if cerr := q.createVerifyEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createVerifyEmailStmt: %w", cerr)
+		}
+	}
+	if q.createWebhookDeliveryStmt != nil {
+		if cerr := q.createWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.createWebhookEndpointStmt != nil {
+		if cerr := q.createWebhookEndpointStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookEndpointStmt: %w", cerr)
+		}
+	}
+	if q.deactivateWebhookEndpointStmt != nil {
+		if cerr := q.deactivateWebhookEndpointStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deactivateWebhookEndpointStmt: %w", cerr)
+		}
+	}
 	if q.deleteAccountStmt != nil {
 		if cerr := q.deleteAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteAccountStmt: %w", cerr)
 		}
 	}
+	if q.deleteMFAEnrollmentStmt != nil {
+		if cerr := q.deleteMFAEnrollmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteMFAEnrollmentStmt: %w", cerr)
+		}
+	}
+	if q.deleteMFARecoveryCodesStmt != nil {
+		if cerr := q.deleteMFARecoveryCodesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteMFARecoveryCodesStmt: %w", cerr)
+		}
+	}
+	if q.deletePasswordResetStmt != nil {
+		if cerr := q.deletePasswordResetStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePasswordResetStmt: %w", cerr)
+		}
+	}
+	if q.enableMFAEnrollmentStmt != nil {
+		if cerr := q.enableMFAEnrollmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing enableMFAEnrollmentStmt: %w", cerr)
+		}
+	}
+	if q.failWebhookDeliveryStmt != nil {
+		if cerr := q.failWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing failWebhookDeliveryStmt: %w", cerr)
+		}
+	}
 	if q.getAccountStmt != nil {
 		if cerr := q.getAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAccountStmt: %w", cerr)
@@ -140,46 +625,291 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getAccountForUpdateStmt: %w", cerr)
 		}
 	}
+	if q.getAccountTransferLimitStmt != nil {
+		if cerr := q.getAccountTransferLimitStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAccountTransferLimitStmt: %w", cerr)
+		}
+	}
+	if q.getAuthFailureStmt != nil {
+		if cerr := q.getAuthFailureStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAuthFailureStmt: %w", cerr)
+		}
+	}
+	if q.getCashTransactionStmt != nil {
+		if cerr := q.getCashTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCashTransactionStmt: %w", cerr)
+		}
+	}
+	if q.getDailyTransferTotalStmt != nil {
+		if cerr := q.getDailyTransferTotalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDailyTransferTotalStmt: %w", cerr)
+		}
+	}
+	if q.getEntriesSumSinceStmt != nil {
+		if cerr := q.getEntriesSumSinceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getEntriesSumSinceStmt: %w", cerr)
+		}
+	}
+	if q.getEntriesTotalStmt != nil {
+		if cerr := q.getEntriesTotalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getEntriesTotalStmt: %w", cerr)
+		}
+	}
 	if q.getEntryStmt != nil {
 		if cerr := q.getEntryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getEntryStmt: %w", cerr)
 		}
 	}
+	if q.getIdempotencyKeyStmt != nil {
+		if cerr := q.getIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.getJournalEntryStmt != nil {
+		if cerr := q.getJournalEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getJournalEntryStmt: %w", cerr)
+		}
+	}
+	if q.getLedgerAccountStmt != nil {
+		if cerr := q.getLedgerAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLedgerAccountStmt: %w", cerr)
+		}
+	}
+	if q.getLedgerAccountByAccountIDStmt != nil {
+		if cerr := q.getLedgerAccountByAccountIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLedgerAccountByAccountIDStmt: %w", cerr)
+		}
+	}
+	if q.getLedgerAccountByCodeStmt != nil {
+		if cerr := q.getLedgerAccountByCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLedgerAccountByCodeStmt: %w", cerr)
+		}
+	}
+	if q.getMFAChallengeForUpdateStmt != nil {
+		if cerr := q.getMFAChallengeForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMFAChallengeForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getMFAEnrollmentStmt != nil {
+		if cerr := q.getMFAEnrollmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMFAEnrollmentStmt: %w", cerr)
+		}
+	}
+	if q.getMFAEnrollmentForUpdateStmt != nil {
+		if cerr := q.getMFAEnrollmentForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMFAEnrollmentForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getScheduledTransferStmt != nil {
+		if cerr := q.getScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getScheduledTransferStmt: %w", cerr)
+		}
+	}
 	if q.getSessionStmt != nil {
 		if cerr := q.getSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getSessionStmt: %w", cerr)
 		}
 	}
+	if q.getSessionForUpdateStmt != nil {
+		if cerr := q.getSessionForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSessionForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getStatementEntriesSumAfterStmt != nil {
+		if cerr := q.getStatementEntriesSumAfterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStatementEntriesSumAfterStmt: %w", cerr)
+		}
+	}
+	if q.getTaskStmt != nil {
+		if cerr := q.getTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTaskStmt: %w", cerr)
+		}
+	}
 	if q.getTransferStmt != nil {
 		if cerr := q.getTransferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTransferStmt: %w", cerr)
 		}
 	}
+	if q.getTransferChallengeForUpdateStmt != nil {
+		if cerr := q.getTransferChallengeForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferChallengeForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getTransferForUpdateStmt != nil {
+		if cerr := q.getTransferForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getTransferLimitDefaultStmt != nil {
+		if cerr := q.getTransferLimitDefaultStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferLimitDefaultStmt: %w", cerr)
+		}
+	}
+	if q.getTransferReversedTotalStmt != nil {
+		if cerr := q.getTransferReversedTotalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferReversedTotalStmt: %w", cerr)
+		}
+	}
 	if q.getUserStmt != nil {
 		if cerr := q.getUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserStmt: %w", cerr)
 		}
 	}
+	if q.getVerifyEmailStmt != nil {
+		if cerr := q.getVerifyEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getVerifyEmailStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookDeliveryStmt != nil {
+		if cerr := q.getWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookEndpointStmt != nil {
+		if cerr := q.getWebhookEndpointStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookEndpointStmt: %w", cerr)
+		}
+	}
+	if q.killTaskStmt != nil {
+		if cerr := q.killTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing killTaskStmt: %w", cerr)
+		}
+	}
 	if q.listAccountStmt != nil {
 		if cerr := q.listAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listAccountStmt: %w", cerr)
 		}
 	}
+	if q.listAccountStatusChangesStmt != nil {
+		if cerr := q.listAccountStatusChangesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAccountStatusChangesStmt: %w", cerr)
+		}
+	}
+	if q.listActiveSessionsStmt != nil {
+		if cerr := q.listActiveSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveSessionsStmt: %w", cerr)
+		}
+	}
+	if q.listBalanceDriftsStmt != nil {
+		if cerr := q.listBalanceDriftsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBalanceDriftsStmt: %w", cerr)
+		}
+	}
+	if q.listDeadTasksStmt != nil {
+		if cerr := q.listDeadTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDeadTasksStmt: %w", cerr)
+		}
+	}
 	if q.listEntriesStmt != nil {
 		if cerr := q.listEntriesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listEntriesStmt: %w", cerr)
 		}
 	}
+	if q.listEntriesAfterStmt != nil {
+		if cerr := q.listEntriesAfterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listEntriesAfterStmt: %w", cerr)
+		}
+	}
+	if q.listJournalEntriesByTransferStmt != nil {
+		if cerr := q.listJournalEntriesByTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listJournalEntriesByTransferStmt: %w", cerr)
+		}
+	}
+	if q.listOutboxEventsByAggregateStmt != nil {
+		if cerr := q.listOutboxEventsByAggregateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOutboxEventsByAggregateStmt: %w", cerr)
+		}
+	}
+	if q.listPostingsStmt != nil {
+		if cerr := q.listPostingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPostingsStmt: %w", cerr)
+		}
+	}
+	if q.listScheduledTransferRunsStmt != nil {
+		if cerr := q.listScheduledTransferRunsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listScheduledTransferRunsStmt: %w", cerr)
+		}
+	}
+	if q.listScheduledTransfersStmt != nil {
+		if cerr := q.listScheduledTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listScheduledTransfersStmt: %w", cerr)
+		}
+	}
+	if q.listStatementEntriesStmt != nil {
+		if cerr := q.listStatementEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatementEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listSubscribedWebhookEndpointsStmt != nil {
+		if cerr := q.listSubscribedWebhookEndpointsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSubscribedWebhookEndpointsStmt: %w", cerr)
+		}
+	}
+	if q.listSystemLedgerAccountBalancesStmt != nil {
+		if cerr := q.listSystemLedgerAccountBalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSystemLedgerAccountBalancesStmt: %w", cerr)
+		}
+	}
+	if q.listTransferReversalsStmt != nil {
+		if cerr := q.listTransferReversalsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransferReversalsStmt: %w", cerr)
+		}
+	}
 	if q.listTransfersStmt != nil {
 		if cerr := q.listTransfersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listTransfersStmt: %w", cerr)
 		}
 	}
+	if q.listUnbalancedJournalEntriesStmt != nil {
+		if cerr := q.listUnbalancedJournalEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnbalancedJournalEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listWebhookDeliveriesStmt != nil {
+		if cerr := q.listWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.listWebhookEndpointsStmt != nil {
+		if cerr := q.listWebhookEndpointsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebhookEndpointsStmt: %w", cerr)
+		}
+	}
+	if q.lockAccountTransferLimitStmt != nil {
+		if cerr := q.lockAccountTransferLimitStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockAccountTransferLimitStmt: %w", cerr)
+		}
+	}
+	if q.lockIdempotencyKeyStmt != nil {
+		if cerr := q.lockIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.markOutboxEventPublishedStmt != nil {
+		if cerr := q.markOutboxEventPublishedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markOutboxEventPublishedStmt: %w", cerr)
+		}
+	}
+	if q.recordOutboxEventFailureStmt != nil {
+		if cerr := q.recordOutboxEventFailureStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordOutboxEventFailureStmt: %w", cerr)
+		}
+	}
+	if q.requeueDeadTaskStmt != nil {
+		if cerr := q.requeueDeadTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing requeueDeadTaskStmt: %w", cerr)
+		}
+	}
 	if q.resetAccountTableStmt != nil {
 		if cerr := q.resetAccountTableStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing resetAccountTableStmt: %w", cerr)
 		}
 	}
+	if q.resetAuthFailuresStmt != nil {
+		if cerr := q.resetAuthFailuresStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resetAuthFailuresStmt: %w", cerr)
+		}
+	}
 	if q.resetEntryTableStmt != nil {
 		if cerr := q.resetEntryTableStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing resetEntryTableStmt: %w", cerr)
@@ -195,16 +925,86 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing resetUserTableStmt: %w", cerr)
 		}
 	}
+	if q.resetWebhookDeliveryStmt != nil {
+		if cerr := q.resetWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resetWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.retryTaskStmt != nil {
+		if cerr := q.retryTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing retryTaskStmt: %w", cerr)
+		}
+	}
+	if q.rotateSessionStmt != nil {
+		if cerr := q.rotateSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rotateSessionStmt: %w", cerr)
+		}
+	}
 	if q.updateAccountStmt != nil {
 		if cerr := q.updateAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateAccountStmt: %w", cerr)
 		}
 	}
+	if q.updateAccountOverdraftLimitStmt != nil {
+		if cerr := q.updateAccountOverdraftLimitStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateAccountOverdraftLimitStmt: %w", cerr)
+		}
+	}
+	if q.updateAccountStatusStmt != nil {
+		if cerr := q.updateAccountStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateAccountStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateMFALastUsedStepStmt != nil {
+		if cerr := q.updateMFALastUsedStepStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateMFALastUsedStepStmt: %w", cerr)
+		}
+	}
 	if q.updateUserStmt != nil {
 		if cerr := q.updateUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserStmt: %w", cerr)
 		}
 	}
+	if q.updateUserRoleStmt != nil {
+		if cerr := q.updateUserRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserRoleStmt: %w", cerr)
+		}
+	}
+	if q.updateVerifyEmailStmt != nil {
+		if cerr := q.updateVerifyEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateVerifyEmailStmt: %w", cerr)
+		}
+	}
+	if q.updateWebhookDeliveryAttemptStmt != nil {
+		if cerr := q.updateWebhookDeliveryAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateWebhookDeliveryAttemptStmt: %w", cerr)
+		}
+	}
+	if q.upsertAccountTransferLimitStmt != nil {
+		if cerr := q.upsertAccountTransferLimitStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertAccountTransferLimitStmt: %w", cerr)
+		}
+	}
+	if q.useMFAChallengeStmt != nil {
+		if cerr := q.useMFAChallengeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useMFAChallengeStmt: %w", cerr)
+		}
+	}
+	if q.useMFARecoveryCodeStmt != nil {
+		if cerr := q.useMFARecoveryCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useMFARecoveryCodeStmt: %w", cerr)
+		}
+	}
+	if q.usePasswordResetStmt != nil {
+		if cerr := q.usePasswordResetStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing usePasswordResetStmt: %w", cerr)
+		}
+	}
+	if q.useUserPasswordResetsStmt != nil {
+		if cerr := q.useUserPasswordResetsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useUserPasswordResetsStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -242,57 +1042,257 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                      DBTX
-	tx                      *sql.Tx
-	addAccountBalanceStmt   *sql.Stmt
-	createAccountStmt       *sql.Stmt
-	createEntryStmt         *sql.Stmt
-	createSessionStmt       *sql.Stmt
-	createTransferStmt      *sql.Stmt
-	createUserStmt          *sql.Stmt
-	deleteAccountStmt       *sql.Stmt
-	getAccountStmt          *sql.Stmt
-	getAccountForUpdateStmt *sql.Stmt
-	getEntryStmt            *sql.Stmt
-	getSessionStmt          *sql.Stmt
-	getTransferStmt         *sql.Stmt
-	getUserStmt             *sql.Stmt
-	listAccountStmt         *sql.Stmt
-	listEntriesStmt         *sql.Stmt
-	listTransfersStmt       *sql.Stmt
-	resetAccountTableStmt   *sql.Stmt
-	resetEntryTableStmt     *sql.Stmt
-	resetTransferTableStmt  *sql.Stmt
-	resetUserTableStmt      *sql.Stmt
-	updateAccountStmt       *sql.Stmt
-	updateUserStmt          *sql.Stmt
+	db                                  DBTX
+	tx                                  *sql.Tx
+	addAccountBalanceStmt               *sql.Stmt
+	addAuthFailureStmt                  *sql.Stmt
+	addMFAChallengeAttemptStmt          *sql.Stmt
+	addTransferChallengeAttemptStmt     *sql.Stmt
+	advanceScheduledTransferStmt        *sql.Stmt
+	blockSessionStmt                    *sql.Stmt
+	blockSessionFamilyStmt              *sql.Stmt
+	blockUserSessionsStmt               *sql.Stmt
+	cancelScheduledTransferStmt         *sql.Stmt
+	claimDueScheduledTransferStmt       *sql.Stmt
+	claimTaskStmt                       *sql.Stmt
+	claimUnpublishedOutboxEventsStmt    *sql.Stmt
+	completeTaskStmt                    *sql.Stmt
+	completeTransferChallengeStmt       *sql.Stmt
+	createAccountStmt                   *sql.Stmt
+	createAccountStatusChangeStmt       *sql.Stmt
+	createCashTransactionStmt           *sql.Stmt
+	createEntryStmt                     *sql.Stmt
+	createIdempotencyKeyStmt            *sql.Stmt
+	createJournalEntryStmt              *sql.Stmt
+	createMFAChallengeStmt              *sql.Stmt
+	createMFAEnrollmentStmt             *sql.Stmt
+	createMFARecoveryCodeStmt           *sql.Stmt
+	createOutboxEventStmt               *sql.Stmt
+	createPasswordResetStmt             *sql.Stmt
+	createPostingStmt                   *sql.Stmt
+	createScheduledTransferStmt         *sql.Stmt
+	createScheduledTransferRunStmt      *sql.Stmt
+	createSessionStmt                   *sql.Stmt
+	createTaskStmt                      *sql.Stmt
+	createTransferStmt                  *sql.Stmt
+	createTransferChallengeStmt         *sql.Stmt
+	createTransferReversalStmt          *sql.Stmt
+	createUserStmt                      *sql.Stmt
+	createVerifyEmailStmt               *sql.Stmt
+	createWebhookDeliveryStmt           *sql.Stmt
+	createWebhookEndpointStmt           *sql.Stmt
+	deactivateWebhookEndpointStmt       *sql.Stmt
+	deleteAccountStmt                   *sql.Stmt
+	deleteMFAEnrollmentStmt             *sql.Stmt
+	deleteMFARecoveryCodesStmt          *sql.Stmt
+	deletePasswordResetStmt             *sql.Stmt
+	enableMFAEnrollmentStmt             *sql.Stmt
+	failWebhookDeliveryStmt             *sql.Stmt
+	getAccountStmt                      *sql.Stmt
+	getAccountForUpdateStmt             *sql.Stmt
+	getAccountTransferLimitStmt         *sql.Stmt
+	getAuthFailureStmt                  *sql.Stmt
+	getCashTransactionStmt              *sql.Stmt
+	getDailyTransferTotalStmt           *sql.Stmt
+	getEntriesSumSinceStmt              *sql.Stmt
+	getEntriesTotalStmt                 *sql.Stmt
+	getEntryStmt                        *sql.Stmt
+	getIdempotencyKeyStmt               *sql.Stmt
+	getJournalEntryStmt                 *sql.Stmt
+	getLedgerAccountStmt                *sql.Stmt
+	getLedgerAccountByAccountIDStmt     *sql.Stmt
+	getLedgerAccountByCodeStmt          *sql.Stmt
+	getMFAChallengeForUpdateStmt        *sql.Stmt
+	getMFAEnrollmentStmt                *sql.Stmt
+	getMFAEnrollmentForUpdateStmt       *sql.Stmt
+	getScheduledTransferStmt            *sql.Stmt
+	getSessionStmt                      *sql.Stmt
+	getSessionForUpdateStmt             *sql.Stmt
+	getStatementEntriesSumAfterStmt     *sql.Stmt
+	getTaskStmt                         *sql.Stmt
+	getTransferStmt                     *sql.Stmt
+	getTransferChallengeForUpdateStmt   *sql.Stmt
+	getTransferForUpdateStmt            *sql.Stmt
+	getTransferLimitDefaultStmt         *sql.Stmt
+	getTransferReversedTotalStmt        *sql.Stmt
+	getUserStmt                         *sql.Stmt
+	getVerifyEmailStmt                  *sql.Stmt
+	getWebhookDeliveryStmt              *sql.Stmt
+	getWebhookEndpointStmt              *sql.Stmt
+	killTaskStmt                        *sql.Stmt
+	listAccountStmt                     *sql.Stmt
+	listAccountStatusChangesStmt        *sql.Stmt
+	listActiveSessionsStmt              *sql.Stmt
+	listBalanceDriftsStmt               *sql.Stmt
+	listDeadTasksStmt                   *sql.Stmt
+	listEntriesStmt                     *sql.Stmt
+	listEntriesAfterStmt                *sql.Stmt
+	listJournalEntriesByTransferStmt    *sql.Stmt
+	listOutboxEventsByAggregateStmt     *sql.Stmt
+	listPostingsStmt                    *sql.Stmt
+	listScheduledTransferRunsStmt       *sql.Stmt
+	listScheduledTransfersStmt          *sql.Stmt
+	listStatementEntriesStmt            *sql.Stmt
+	listSubscribedWebhookEndpointsStmt  *sql.Stmt
+	listSystemLedgerAccountBalancesStmt *sql.Stmt
+	listTransferReversalsStmt           *sql.Stmt
+	listTransfersStmt                   *sql.Stmt
+	listUnbalancedJournalEntriesStmt    *sql.Stmt
+	listWebhookDeliveriesStmt           *sql.Stmt
+	listWebhookEndpointsStmt            *sql.Stmt
+	lockAccountTransferLimitStmt        *sql.Stmt
+	lockIdempotencyKeyStmt              *sql.Stmt
+	markOutboxEventPublishedStmt        *sql.Stmt
+	recordOutboxEventFailureStmt        *sql.Stmt
+	requeueDeadTaskStmt                 *sql.Stmt
+	resetAccountTableStmt               *sql.Stmt
+	resetAuthFailuresStmt               *sql.Stmt
+	resetEntryTableStmt                 *sql.Stmt
+	resetTransferTableStmt              *sql.Stmt
+	resetUserTableStmt                  *sql.Stmt
+	resetWebhookDeliveryStmt            *sql.Stmt
+	retryTaskStmt                       *sql.Stmt
+	rotateSessionStmt                   *sql.Stmt
+	updateAccountStmt                   *sql.Stmt
+	updateAccountOverdraftLimitStmt     *sql.Stmt
+	updateAccountStatusStmt             *sql.Stmt
+	updateMFALastUsedStepStmt           *sql.Stmt
+	updateUserStmt                      *sql.Stmt
+	updateUserRoleStmt                  *sql.Stmt
+	updateVerifyEmailStmt               *sql.Stmt
+	updateWebhookDeliveryAttemptStmt    *sql.Stmt
+	upsertAccountTransferLimitStmt      *sql.Stmt
+	useMFAChallengeStmt                 *sql.Stmt
+	useMFARecoveryCodeStmt              *sql.Stmt
+	usePasswordResetStmt                *sql.Stmt
+	useUserPasswordResetsStmt           *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                      tx,
-		tx:                      tx,
-		addAccountBalanceStmt:   q.addAccountBalanceStmt,
-		createAccountStmt:       q.createAccountStmt,
-		createEntryStmt:         q.createEntryStmt,
-		createSessionStmt:       q.createSessionStmt,
-		createTransferStmt:      q.createTransferStmt,
-		createUserStmt:          q.createUserStmt,
-		deleteAccountStmt:       q.deleteAccountStmt,
-		getAccountStmt:          q.getAccountStmt,
-		getAccountForUpdateStmt: q.getAccountForUpdateStmt,
-		getEntryStmt:            q.getEntryStmt,
-		getSessionStmt:          q.getSessionStmt,
-		getTransferStmt:         q.getTransferStmt,
-		getUserStmt:             q.getUserStmt,
-		listAccountStmt:         q.listAccountStmt,
-		listEntriesStmt:         q.listEntriesStmt,
-		listTransfersStmt:       q.listTransfersStmt,
-		resetAccountTableStmt:   q.resetAccountTableStmt,
-		resetEntryTableStmt:     q.resetEntryTableStmt,
-		resetTransferTableStmt:  q.resetTransferTableStmt,
-		resetUserTableStmt:      q.resetUserTableStmt,
-		updateAccountStmt:       q.updateAccountStmt,
-		updateUserStmt:          q.updateUserStmt,
+		db:                                  tx,
+		tx:                                  tx,
+		addAccountBalanceStmt:               q.addAccountBalanceStmt,
+		addAuthFailureStmt:                  q.addAuthFailureStmt,
+		addMFAChallengeAttemptStmt:          q.addMFAChallengeAttemptStmt,
+		addTransferChallengeAttemptStmt:     q.addTransferChallengeAttemptStmt,
+		advanceScheduledTransferStmt:        q.advanceScheduledTransferStmt,
+		blockSessionStmt:                    q.blockSessionStmt,
+		blockSessionFamilyStmt:              q.blockSessionFamilyStmt,
+		blockUserSessionsStmt:               q.blockUserSessionsStmt,
+		cancelScheduledTransferStmt:         q.cancelScheduledTransferStmt,
+		claimDueScheduledTransferStmt:       q.claimDueScheduledTransferStmt,
+		claimTaskStmt:                       q.claimTaskStmt,
+		claimUnpublishedOutboxEventsStmt:    q.claimUnpublishedOutboxEventsStmt,
+		completeTaskStmt:                    q.completeTaskStmt,
+		completeTransferChallengeStmt:       q.completeTransferChallengeStmt,
+		createAccountStmt:                   q.createAccountStmt,
+		createAccountStatusChangeStmt:       q.createAccountStatusChangeStmt,
+		createCashTransactionStmt:           q.createCashTransactionStmt,
+		createEntryStmt:                     q.createEntryStmt,
+		createIdempotencyKeyStmt:            q.createIdempotencyKeyStmt,
+		createJournalEntryStmt:              q.createJournalEntryStmt,
+		createMFAChallengeStmt:              q.createMFAChallengeStmt,
+		createMFAEnrollmentStmt:             q.createMFAEnrollmentStmt,
+		createMFARecoveryCodeStmt:           q.createMFARecoveryCodeStmt,
+		createOutboxEventStmt:               q.createOutboxEventStmt,
+		createPasswordResetStmt:             q.createPasswordResetStmt,
+		createPostingStmt:                   q.createPostingStmt,
+		createScheduledTransferStmt:         q.createScheduledTransferStmt,
+		createScheduledTransferRunStmt:      q.createScheduledTransferRunStmt,
+		createSessionStmt:                   q.createSessionStmt,
+		createTaskStmt:                      q.createTaskStmt,
+		createTransferStmt:                  q.createTransferStmt,
+		createTransferChallengeStmt:         q.createTransferChallengeStmt,
+		createTransferReversalStmt:          q.createTransferReversalStmt,
+		createUserStmt:                      q.createUserStmt,
+		createVerifyEmailStmt:               q.createVerifyEmailStmt,
+		createWebhookDeliveryStmt:           q.createWebhookDeliveryStmt,
+		createWebhookEndpointStmt:           q.createWebhookEndpointStmt,
+		deactivateWebhookEndpointStmt:       q.deactivateWebhookEndpointStmt,
+		deleteAccountStmt:                   q.deleteAccountStmt,
+		deleteMFAEnrollmentStmt:             q.deleteMFAEnrollmentStmt,
+		deleteMFARecoveryCodesStmt:          q.deleteMFARecoveryCodesStmt,
+		deletePasswordResetStmt:             q.deletePasswordResetStmt,
+		enableMFAEnrollmentStmt:             q.enableMFAEnrollmentStmt,
+		failWebhookDeliveryStmt:             q.failWebhookDeliveryStmt,
+		getAccountStmt:                      q.getAccountStmt,
+		getAccountForUpdateStmt:             q.getAccountForUpdateStmt,
+		getAccountTransferLimitStmt:         q.getAccountTransferLimitStmt,
+		getAuthFailureStmt:                  q.getAuthFailureStmt,
+		getCashTransactionStmt:              q.getCashTransactionStmt,
+		getDailyTransferTotalStmt:           q.getDailyTransferTotalStmt,
+		getEntriesSumSinceStmt:              q.getEntriesSumSinceStmt,
+		getEntriesTotalStmt:                 q.getEntriesTotalStmt,
+		getEntryStmt:                        q.getEntryStmt,
+		getIdempotencyKeyStmt:               q.getIdempotencyKeyStmt,
+		getJournalEntryStmt:                 q.getJournalEntryStmt,
+		getLedgerAccountStmt:                q.getLedgerAccountStmt,
+		getLedgerAccountByAccountIDStmt:     q.getLedgerAccountByAccountIDStmt,
+		getLedgerAccountByCodeStmt:          q.getLedgerAccountByCodeStmt,
+		getMFAChallengeForUpdateStmt:        q.getMFAChallengeForUpdateStmt,
+		getMFAEnrollmentStmt:                q.getMFAEnrollmentStmt,
+		getMFAEnrollmentForUpdateStmt:       q.getMFAEnrollmentForUpdateStmt,
+		getScheduledTransferStmt:            q.getScheduledTransferStmt,
+		getSessionStmt:                      q.getSessionStmt,
+		getSessionForUpdateStmt:             q.getSessionForUpdateStmt,
+		getStatementEntriesSumAfterStmt:     q.getStatementEntriesSumAfterStmt,
+		getTaskStmt:                         q.getTaskStmt,
+		getTransferStmt:                     q.getTransferStmt,
+		getTransferChallengeForUpdateStmt:   q.getTransferChallengeForUpdateStmt,
+		getTransferForUpdateStmt:            q.getTransferForUpdateStmt,
+		getTransferLimitDefaultStmt:         q.getTransferLimitDefaultStmt,
+		getTransferReversedTotalStmt:        q.getTransferReversedTotalStmt,
+		getUserStmt:                         q.getUserStmt,
+		getVerifyEmailStmt:                  q.getVerifyEmailStmt,
+		getWebhookDeliveryStmt:              q.getWebhookDeliveryStmt,
+		getWebhookEndpointStmt:              q.getWebhookEndpointStmt,
+		killTaskStmt:                        q.killTaskStmt,
+		listAccountStmt:                     q.listAccountStmt,
+		listAccountStatusChangesStmt:        q.listAccountStatusChangesStmt,
+		listActiveSessionsStmt:              q.listActiveSessionsStmt,
+		listBalanceDriftsStmt:               q.listBalanceDriftsStmt,
+		listDeadTasksStmt:                   q.listDeadTasksStmt,
+		listEntriesStmt:                     q.listEntriesStmt,
+		listEntriesAfterStmt:                q.listEntriesAfterStmt,
+		listJournalEntriesByTransferStmt:    q.listJournalEntriesByTransferStmt,
+		listOutboxEventsByAggregateStmt:     q.listOutboxEventsByAggregateStmt,
+		listPostingsStmt:                    q.listPostingsStmt,
+		listScheduledTransferRunsStmt:       q.listScheduledTransferRunsStmt,
+		listScheduledTransfersStmt:          q.listScheduledTransfersStmt,
+		listStatementEntriesStmt:            q.listStatementEntriesStmt,
+		listSubscribedWebhookEndpointsStmt:  q.listSubscribedWebhookEndpointsStmt,
+		listSystemLedgerAccountBalancesStmt: q.listSystemLedgerAccountBalancesStmt,
+		listTransferReversalsStmt:           q.listTransferReversalsStmt,
+		listTransfersStmt:                   q.listTransfersStmt,
+		listUnbalancedJournalEntriesStmt:    q.listUnbalancedJournalEntriesStmt,
+		listWebhookDeliveriesStmt:           q.listWebhookDeliveriesStmt,
+		listWebhookEndpointsStmt:            q.listWebhookEndpointsStmt,
+		lockAccountTransferLimitStmt:        q.lockAccountTransferLimitStmt,
+		lockIdempotencyKeyStmt:              q.lockIdempotencyKeyStmt,
+		markOutboxEventPublishedStmt:        q.markOutboxEventPublishedStmt,
+		recordOutboxEventFailureStmt:        q.recordOutboxEventFailureStmt,
+		requeueDeadTaskStmt:                 q.requeueDeadTaskStmt,
+		resetAccountTableStmt:               q.resetAccountTableStmt,
+		resetAuthFailuresStmt:               q.resetAuthFailuresStmt,
+		resetEntryTableStmt:                 q.resetEntryTableStmt,
+		resetTransferTableStmt:              q.resetTransferTableStmt,
+		resetUserTableStmt:                  q.resetUserTableStmt,
+		resetWebhookDeliveryStmt:            q.resetWebhookDeliveryStmt,
+		retryTaskStmt:                       q.retryTaskStmt,
+		rotateSessionStmt:                   q.rotateSessionStmt,
+		updateAccountStmt:                   q.updateAccountStmt,
+		updateAccountOverdraftLimitStmt:     q.updateAccountOverdraftLimitStmt,
+		updateAccountStatusStmt:             q.updateAccountStatusStmt,
+		updateMFALastUsedStepStmt:           q.updateMFALastUsedStepStmt,
+		updateUserStmt:                      q.updateUserStmt,
+		updateUserRoleStmt:                  q.updateUserRoleStmt,
+		updateVerifyEmailStmt:               q.updateVerifyEmailStmt,
+		updateWebhookDeliveryAttemptStmt:    q.updateWebhookDeliveryAttemptStmt,
+		upsertAccountTransferLimitStmt:      q.upsertAccountTransferLimitStmt,
+		useMFAChallengeStmt:                 q.useMFAChallengeStmt,
+		useMFARecoveryCodeStmt:              q.useMFARecoveryCodeStmt,
+		usePasswordResetStmt:                q.usePasswordResetStmt,
+		useUserPasswordResetsStmt:           q.useUserPasswordResetsStmt,
 	}
 }
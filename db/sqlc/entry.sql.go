@@ -7,36 +7,88 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  exchange_rate,
+  source_amount,
+  destination_amount,
+  transfer_id
 ) VALUES (
-  $1, $2
-) RETURNING id, account_id, amount, created_at
+  $1, $2, $3, $4, $5, $6
+) RETURNING id, account_id, amount, created_at, exchange_rate, source_amount, destination_amount, transfer_id
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID         int64         `json:"account_id"`
+	Amount            int64         `json:"amount"`
+	ExchangeRate      string        `json:"exchange_rate"`
+	SourceAmount      int64         `json:"source_amount"`
+	DestinationAmount int64         `json:"destination_amount"`
+	TransferID        sql.NullInt64 `json:"transfer_id"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.queryRow(ctx, q.createEntryStmt, createEntry, arg.AccountID, arg.Amount)
+	row := q.queryRow(ctx, q.createEntryStmt, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.ExchangeRate,
+		arg.SourceAmount,
+		arg.DestinationAmount,
+		arg.TransferID,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ExchangeRate,
+		&i.SourceAmount,
+		&i.DestinationAmount,
+		&i.TransferID,
 	)
 	return i, err
 }
 
+const getEntriesSumSince = `-- name: GetEntriesSumSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = $1 AND created_at >= $2
+`
+
+type GetEntriesSumSinceParams struct {
+	AccountID int64     `json:"account_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) GetEntriesSumSince(ctx context.Context, arg GetEntriesSumSinceParams) (int64, error) {
+	row := q.queryRow(ctx, q.getEntriesSumSinceStmt, getEntriesSumSince, arg.AccountID, arg.CreatedAt)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
+const getEntriesTotal = `-- name: GetEntriesTotal :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = $1
+`
+
+func (q *Queries) GetEntriesTotal(ctx context.Context, accountID int64) (int64, error) {
+	row := q.queryRow(ctx, q.getEntriesTotalStmt, getEntriesTotal, accountID)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, exchange_rate, source_amount, destination_amount, transfer_id FROM entries
 WHERE id = $1 LIMIT 1
 `
 
@@ -48,26 +100,109 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ExchangeRate,
+		&i.SourceAmount,
+		&i.DestinationAmount,
+		&i.TransferID,
 	)
 	return i, err
 }
 
+const getStatementEntriesSumAfter = `-- name: GetStatementEntriesSumAfter :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = $1
+  AND created_at >= $2
+  AND (created_at, id) > ($3::timestamp, $4::bigint)
+`
+
+type GetStatementEntriesSumAfterParams struct {
+	AccountID       int64     `json:"account_id"`
+	FromTime        time.Time `json:"from_time"`
+	CursorCreatedAt time.Time `json:"cursor_created_at"`
+	CursorID        int64     `json:"cursor_id"`
+}
+
+func (q *Queries) GetStatementEntriesSumAfter(ctx context.Context, arg GetStatementEntriesSumAfterParams) (int64, error) {
+	row := q.queryRow(ctx, q.getStatementEntriesSumAfterStmt, getStatementEntriesSumAfter,
+		arg.AccountID,
+		arg.FromTime,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+	)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, exchange_rate, source_amount, destination_amount, transfer_id FROM entries
 WHERE account_id = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3
+  AND (created_at, id) > ($2::timestamp, $3::bigint)
+ORDER BY created_at, id
+LIMIT $4
 `
 
 type ListEntriesParams struct {
-	AccountID int64 `json:"account_id"`
-	Limit     int32 `json:"limit"`
-	Offset    int32 `json:"offset"`
+	AccountID       int64     `json:"account_id"`
+	CursorCreatedAt time.Time `json:"cursor_created_at"`
+	CursorID        int64     `json:"cursor_id"`
+	PageSize        int32     `json:"page_size"`
 }
 
 func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
-	rows, err := q.query(ctx, q.listEntriesStmt, listEntries, arg.AccountID, arg.Limit, arg.Offset)
+	rows, err := q.query(ctx, q.listEntriesStmt, listEntries,
+		arg.AccountID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.ExchangeRate,
+			&i.SourceAmount,
+			&i.DestinationAmount,
+			&i.TransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntriesAfter = `-- name: ListEntriesAfter :many
+SELECT id, account_id, amount, created_at, exchange_rate, source_amount, destination_amount, transfer_id FROM entries
+WHERE account_id = $1
+  AND id > $2::bigint
+ORDER BY id
+LIMIT $3
+`
+
+type ListEntriesAfterParams struct {
+	AccountID int64 `json:"account_id"`
+	AfterID   int64 `json:"after_id"`
+	PageSize  int32 `json:"page_size"`
+}
+
+func (q *Queries) ListEntriesAfter(ctx context.Context, arg ListEntriesAfterParams) ([]Entry, error) {
+	rows, err := q.query(ctx, q.listEntriesAfterStmt, listEntriesAfter, arg.AccountID, arg.AfterID, arg.PageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +215,86 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ExchangeRate,
+			&i.SourceAmount,
+			&i.DestinationAmount,
+			&i.TransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatementEntries = `-- name: ListStatementEntries :many
+SELECT
+  e.id,
+  e.account_id,
+  e.amount,
+  e.transfer_id,
+  e.created_at,
+  COALESCE(
+    CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END,
+    0
+  )::bigint AS counterparty_account_id
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+WHERE e.account_id = $1
+  AND e.created_at >= $2
+  AND e.created_at < $3
+  AND (e.created_at, e.id) > ($4::timestamp, $5::bigint)
+ORDER BY e.created_at, e.id
+LIMIT $6
+`
+
+type ListStatementEntriesParams struct {
+	AccountID       int64     `json:"account_id"`
+	FromTime        time.Time `json:"from_time"`
+	ToTime          time.Time `json:"to_time"`
+	CursorCreatedAt time.Time `json:"cursor_created_at"`
+	CursorID        int64     `json:"cursor_id"`
+	PageSize        int32     `json:"page_size"`
+}
+
+type ListStatementEntriesRow struct {
+	ID                    int64         `json:"id"`
+	AccountID             int64         `json:"account_id"`
+	Amount                int64         `json:"amount"`
+	TransferID            sql.NullInt64 `json:"transfer_id"`
+	CreatedAt             time.Time     `json:"created_at"`
+	CounterpartyAccountID int64         `json:"counterparty_account_id"`
+}
+
+func (q *Queries) ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error) {
+	rows, err := q.query(ctx, q.listStatementEntriesStmt, listStatementEntries,
+		arg.AccountID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStatementEntriesRow{}
+	for rows.Next() {
+		var i ListStatementEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.TransferID,
+			&i.CreatedAt,
+			&i.CounterpartyAccountID,
 		); err != nil {
 			return nil, err
 		}
@@ -12,9 +12,13 @@ import (
 func createRandomEntry(t *testing.T, account Account) Entry {
 	ctx := context.Background()
 
+	amount := util.GenerateRandomMoney()
 	arg := CreateEntryParams{
-		AccountID: account.ID,
-		Amount:    util.GenerateRandomMoney(),
+		AccountID:         account.ID,
+		Amount:            amount,
+		ExchangeRate:      "1",
+		SourceAmount:      amount,
+		DestinationAmount: amount,
 	}
 
 	entry, err := testQueries.CreateEntry(ctx, arg)
@@ -74,16 +78,42 @@ func TestListEntry(t *testing.T) {
 
 	arg := ListEntriesParams{
 		AccountID: account.ID,
-		Limit:     5,
-		Offset:    5,
+		PageSize:  5,
 	}
 
+	firstPage, err := testQueries.ListEntries(ctx, arg)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 5)
+
+	last := firstPage[len(firstPage)-1]
+	arg.CursorCreatedAt = last.CreatedAt
+	arg.CursorID = last.ID
+
 	entries, err := testQueries.ListEntries(ctx, arg)
 	require.NoError(t, err)
 	require.Len(t, entries, 5)
+	require.Greater(t, entries[0].ID, last.ID)
 
 	for _, entry := range entries {
 		require.NotEmpty(t, entry)
 		require.Equal(t, arg.AccountID, entry.AccountID)
 	}
 }
+
+func TestListEntriesAfter(t *testing.T) {
+	account := createRandomAccount(t)
+	entries := make([]Entry, 3)
+	for i := range entries {
+		entries[i] = createRandomEntry(t, account)
+	}
+
+	missed, err := testQueries.ListEntriesAfter(context.Background(), ListEntriesAfterParams{
+		AccountID: account.ID,
+		AfterID:   entries[0].ID,
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, missed, 2)
+	require.Equal(t, entries[1].ID, missed[0].ID)
+	require.Equal(t, entries[2].ID, missed[1].ID)
+}
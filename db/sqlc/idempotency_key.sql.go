@@ -0,0 +1,85 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: idempotency_key.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const createIdempotencyKey = `-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+    owner,
+    key,
+    transfer_id,
+    result
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING owner, key, transfer_id, result, created_at
+`
+
+type CreateIdempotencyKeyParams struct {
+	Owner      string          `json:"owner"`
+	Key        string          `json:"key"`
+	TransferID int64           `json:"transfer_id"`
+	Result     json.RawMessage `json:"result"`
+}
+
+func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.queryRow(ctx, q.createIdempotencyKeyStmt, createIdempotencyKey,
+		arg.Owner,
+		arg.Key,
+		arg.TransferID,
+		arg.Result,
+	)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Owner,
+		&i.Key,
+		&i.TransferID,
+		&i.Result,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT owner, key, transfer_id, result, created_at FROM idempotency_keys
+WHERE owner = $1 AND key = $2 LIMIT 1
+`
+
+type GetIdempotencyKeyParams struct {
+	Owner string `json:"owner"`
+	Key   string `json:"key"`
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.queryRow(ctx, q.getIdempotencyKeyStmt, getIdempotencyKey, arg.Owner, arg.Key)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Owner,
+		&i.Key,
+		&i.TransferID,
+		&i.Result,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const lockIdempotencyKey = `-- name: LockIdempotencyKey :exec
+SELECT pg_advisory_xact_lock(2, hashtext($1::text || ':' || $2::text))
+`
+
+type LockIdempotencyKeyParams struct {
+	Owner string `json:"owner"`
+	Key   string `json:"key"`
+}
+
+// the first key is the namespace of the lock, see LockAccountTransferLimit
+func (q *Queries) LockIdempotencyKey(ctx context.Context, arg LockIdempotencyKeyParams) error {
+	_, err := q.exec(ctx, q.lockIdempotencyKeyStmt, lockIdempotencyKey, arg.Owner, arg.Key)
+	return err
+}
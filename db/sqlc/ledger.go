@@ -0,0 +1,224 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// constant for all kinds of system ledger accounts, the migration creates one of each per currency
+const (
+	LedgerCash     = "cash"
+	LedgerFees     = "fees"
+	LedgerFX       = "fx"
+	LedgerSuspense = "suspense"
+)
+
+// constant for all journal entry types
+const (
+	JournalTransfer   = "transfer"
+	JournalFee        = "fee"
+	JournalAdjustment = "adjustment"
+	JournalDeposit    = "deposit"
+	JournalWithdrawal = "withdrawal"
+	JournalReversal   = "reversal"
+)
+
+// ErrUnbalancedJournal is returned when the postings of a journal entry don't sum to zero in every currency
+var ErrUnbalancedJournal = errors.New("journal entry postings must sum to zero per currency")
+
+// SystemLedgerCode returns the code of the system ledger account of the given kind and currency
+func SystemLedgerCode(kind string, currency string) string {
+	return kind + ":" + currency
+}
+
+// JournalPosting is one leg of a journal entry.
+// A posting to the ledger account of a customer account also writes an entry and moves the account balance,
+// ExchangeRate, SourceAmount and DestinationAmount describe that entry and default to a rate of 1 and the posted amount.
+// SkipBalanceUpdate only writes the entry, it records a balance change that already happened
+type JournalPosting struct {
+	LedgerAccountID   int64  `json:"ledger_account_id"`
+	Amount            int64  `json:"amount"`
+	ExchangeRate      string `json:"exchange_rate"`
+	SourceAmount      int64  `json:"source_amount"`
+	DestinationAmount int64  `json:"destination_amount"`
+	SkipBalanceUpdate bool   `json:"skip_balance_update"`
+}
+
+// PostJournalTxParams contains input parameters of the post journal transaction
+type PostJournalTxParams struct {
+	Type        string           `json:"type"`
+	Description string           `json:"description"`
+	TransferID  sql.NullInt64    `json:"transfer_id"`
+	Postings    []JournalPosting `json:"postings"`
+}
+
+// PostJournalTxResult contains result of PostJournalTx.
+// Entries and Accounts are aligned with Postings, they are empty for postings to system accounts
+type PostJournalTxResult struct {
+	JournalEntry JournalEntry `json:"journal_entry"`
+	Postings     []Posting    `json:"postings"`
+	Entries      []Entry      `json:"entries"`
+	Accounts     []Account    `json:"accounts"`
+}
+
+// PostJournalTx writes a balanced journal entry in its own database transaction
+func (store *SQLStore) PostJournalTx(ctx context.Context, arg PostJournalTxParams) (PostJournalTxResult, error) {
+	var result PostJournalTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = postJournal(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// postJournal writes the journal entry and its postings, it must run inside a transaction.
+// Customer accounts whose balance changes must be active, debited ones are checked against their overdraft limit
+// once their balance is updated
+func postJournal(ctx context.Context, q *Queries, arg PostJournalTxParams) (PostJournalTxResult, error) {
+	var result PostJournalTxResult
+
+	if len(arg.Postings) < 2 {
+		return result, fmt.Errorf("%w: at least two postings are needed", ErrUnbalancedJournal)
+	}
+
+	ledgerAccounts := make([]LedgerAccount, len(arg.Postings))
+	totals := map[string]int64{}
+	for i, posting := range arg.Postings {
+		ledgerAccount, err := q.GetLedgerAccount(ctx, posting.LedgerAccountID)
+		if err != nil {
+			return result, fmt.Errorf("failed to get ledger account %d: %w", posting.LedgerAccountID, err)
+		}
+
+		ledgerAccounts[i] = ledgerAccount
+		totals[ledgerAccount.Currency] += posting.Amount
+	}
+
+	for currency, total := range totals {
+		if total != 0 {
+			return result, fmt.Errorf("%w: %s postings sum to %d", ErrUnbalancedJournal, currency, total)
+		}
+	}
+
+	var err error
+	result.JournalEntry, err = q.CreateJournalEntry(ctx, CreateJournalEntryParams{
+		Type:        arg.Type,
+		Description: arg.Description,
+		TransferID:  arg.TransferID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Postings = make([]Posting, len(arg.Postings))
+	result.Entries = make([]Entry, len(arg.Postings))
+	result.Accounts = make([]Account, len(arg.Postings))
+	balanceChanges := map[int64]int64{}
+
+	for i, posting := range arg.Postings {
+		ledgerAccount := ledgerAccounts[i]
+
+		var entryID sql.NullInt64
+		if ledgerAccount.AccountID.Valid {
+			result.Entries[i], err = q.CreateEntry(ctx, newPostingEntry(ledgerAccount.AccountID.Int64, posting, arg.TransferID))
+			if err != nil {
+				return result, err
+			}
+
+			entryID = sql.NullInt64{Int64: result.Entries[i].ID, Valid: true}
+			if !posting.SkipBalanceUpdate {
+				balanceChanges[ledgerAccount.AccountID.Int64] += posting.Amount
+			}
+		}
+
+		result.Postings[i], err = q.CreatePosting(ctx, CreatePostingParams{
+			JournalEntryID:  result.JournalEntry.ID,
+			LedgerAccountID: ledgerAccount.ID,
+			Amount:          posting.Amount,
+			Currency:        ledgerAccount.Currency,
+			EntryID:         entryID,
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	accounts, err := applyBalanceChanges(ctx, q, balanceChanges)
+	if err != nil {
+		return result, err
+	}
+
+	for i, ledgerAccount := range ledgerAccounts {
+		if account, ok := accounts[ledgerAccount.AccountID.Int64]; ok && ledgerAccount.AccountID.Valid {
+			result.Accounts[i] = account
+		}
+	}
+
+	return result, nil
+}
+
+// newPostingEntry returns the entry written for a posting to a customer account
+func newPostingEntry(accountID int64, posting JournalPosting, transferID sql.NullInt64) CreateEntryParams {
+	arg := CreateEntryParams{
+		AccountID:         accountID,
+		Amount:            posting.Amount,
+		ExchangeRate:      posting.ExchangeRate,
+		SourceAmount:      posting.SourceAmount,
+		DestinationAmount: posting.DestinationAmount,
+		TransferID:        transferID,
+	}
+
+	if arg.ExchangeRate == "" {
+		amount := posting.Amount
+		if amount < 0 {
+			amount = -amount
+		}
+
+		arg.ExchangeRate = "1"
+		arg.SourceAmount = amount
+		arg.DestinationAmount = amount
+	}
+
+	return arg
+}
+
+// applyBalanceChanges updates the balances in the order of the account ids, so concurrent journals
+// touching the same accounts always lock them in the same order and can't deadlock
+func applyBalanceChanges(ctx context.Context, q *Queries, changes map[int64]int64) (map[int64]Account, error) {
+	accountIDs := make([]int64, 0, len(changes))
+	for accountID := range changes {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+
+	accounts := make(map[int64]Account, len(changes))
+	for _, accountID := range accountIDs {
+		account, err := q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     accountID,
+			Amount: changes[accountID],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// the updated row stays locked until the transaction ends, so its status and balance can be checked safely
+		if err := CheckAccountActive(account); err != nil {
+			return nil, err
+		}
+
+		if changes[accountID] < 0 {
+			if err := checkSufficientFunds(account); err != nil {
+				return nil, err
+			}
+		}
+
+		accounts[accountID] = account
+	}
+
+	return accounts, nil
+}
@@ -0,0 +1,326 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: ledger.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createJournalEntry = `-- name: CreateJournalEntry :one
+INSERT INTO journal_entries (
+  type,
+  description,
+  transfer_id
+) VALUES (
+  $1, $2, $3
+) RETURNING id, type, description, transfer_id, created_at
+`
+
+type CreateJournalEntryParams struct {
+	Type        string        `json:"type"`
+	Description string        `json:"description"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+}
+
+func (q *Queries) CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (JournalEntry, error) {
+	row := q.queryRow(ctx, q.createJournalEntryStmt, createJournalEntry, arg.Type, arg.Description, arg.TransferID)
+	var i JournalEntry
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Description,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPosting = `-- name: CreatePosting :one
+INSERT INTO postings (
+  journal_entry_id,
+  ledger_account_id,
+  amount,
+  currency,
+  entry_id
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id, journal_entry_id, ledger_account_id, amount, currency, entry_id, created_at
+`
+
+type CreatePostingParams struct {
+	JournalEntryID  int64         `json:"journal_entry_id"`
+	LedgerAccountID int64         `json:"ledger_account_id"`
+	Amount          int64         `json:"amount"`
+	Currency        string        `json:"currency"`
+	EntryID         sql.NullInt64 `json:"entry_id"`
+}
+
+func (q *Queries) CreatePosting(ctx context.Context, arg CreatePostingParams) (Posting, error) {
+	row := q.queryRow(ctx, q.createPostingStmt, createPosting,
+		arg.JournalEntryID,
+		arg.LedgerAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.EntryID,
+	)
+	var i Posting
+	err := row.Scan(
+		&i.ID,
+		&i.JournalEntryID,
+		&i.LedgerAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.EntryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getJournalEntry = `-- name: GetJournalEntry :one
+SELECT id, type, description, transfer_id, created_at FROM journal_entries
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetJournalEntry(ctx context.Context, id int64) (JournalEntry, error) {
+	row := q.queryRow(ctx, q.getJournalEntryStmt, getJournalEntry, id)
+	var i JournalEntry
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Description,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLedgerAccount = `-- name: GetLedgerAccount :one
+SELECT id, code, name, type, currency, account_id, created_at FROM ledger_accounts
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetLedgerAccount(ctx context.Context, id int64) (LedgerAccount, error) {
+	row := q.queryRow(ctx, q.getLedgerAccountStmt, getLedgerAccount, id)
+	var i LedgerAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.Type,
+		&i.Currency,
+		&i.AccountID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLedgerAccountByAccountID = `-- name: GetLedgerAccountByAccountID :one
+SELECT id, code, name, type, currency, account_id, created_at FROM ledger_accounts
+WHERE account_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetLedgerAccountByAccountID(ctx context.Context, accountID sql.NullInt64) (LedgerAccount, error) {
+	row := q.queryRow(ctx, q.getLedgerAccountByAccountIDStmt, getLedgerAccountByAccountID, accountID)
+	var i LedgerAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.Type,
+		&i.Currency,
+		&i.AccountID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLedgerAccountByCode = `-- name: GetLedgerAccountByCode :one
+SELECT id, code, name, type, currency, account_id, created_at FROM ledger_accounts
+WHERE code = $1 LIMIT 1
+`
+
+func (q *Queries) GetLedgerAccountByCode(ctx context.Context, code string) (LedgerAccount, error) {
+	row := q.queryRow(ctx, q.getLedgerAccountByCodeStmt, getLedgerAccountByCode, code)
+	var i LedgerAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.Type,
+		&i.Currency,
+		&i.AccountID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listJournalEntriesByTransfer = `-- name: ListJournalEntriesByTransfer :many
+SELECT id, type, description, transfer_id, created_at FROM journal_entries
+WHERE transfer_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListJournalEntriesByTransfer(ctx context.Context, transferID sql.NullInt64) ([]JournalEntry, error) {
+	rows, err := q.query(ctx, q.listJournalEntriesByTransferStmt, listJournalEntriesByTransfer, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []JournalEntry{}
+	for rows.Next() {
+		var i JournalEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.Description,
+			&i.TransferID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPostings = `-- name: ListPostings :many
+SELECT id, journal_entry_id, ledger_account_id, amount, currency, entry_id, created_at FROM postings
+WHERE journal_entry_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListPostings(ctx context.Context, journalEntryID int64) ([]Posting, error) {
+	rows, err := q.query(ctx, q.listPostingsStmt, listPostings, journalEntryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Posting{}
+	for rows.Next() {
+		var i Posting
+		if err := rows.Scan(
+			&i.ID,
+			&i.JournalEntryID,
+			&i.LedgerAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.EntryID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSystemLedgerAccountBalances = `-- name: ListSystemLedgerAccountBalances :many
+SELECT
+  l.id,
+  l.code,
+  l.name,
+  l.type,
+  l.currency,
+  COALESCE(SUM(p.amount), 0)::bigint AS balance
+FROM ledger_accounts l
+LEFT JOIN postings p ON p.ledger_account_id = l.id
+WHERE l.account_id IS NULL
+GROUP BY l.id
+ORDER BY l.code
+`
+
+type ListSystemLedgerAccountBalancesRow struct {
+	ID       int64  `json:"id"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Currency string `json:"currency"`
+	Balance  int64  `json:"balance"`
+}
+
+// customer accounts keep their balance in accounts.balance, system accounts only have postings
+func (q *Queries) ListSystemLedgerAccountBalances(ctx context.Context) ([]ListSystemLedgerAccountBalancesRow, error) {
+	rows, err := q.query(ctx, q.listSystemLedgerAccountBalancesStmt, listSystemLedgerAccountBalances)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSystemLedgerAccountBalancesRow{}
+	for rows.Next() {
+		var i ListSystemLedgerAccountBalancesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.Type,
+			&i.Currency,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnbalancedJournalEntries = `-- name: ListUnbalancedJournalEntries :many
+SELECT
+  journal_entry_id,
+  currency,
+  SUM(amount)::bigint AS total
+FROM postings
+GROUP BY journal_entry_id, currency
+HAVING SUM(amount) <> 0
+ORDER BY journal_entry_id
+`
+
+type ListUnbalancedJournalEntriesRow struct {
+	JournalEntryID int64  `json:"journal_entry_id"`
+	Currency       string `json:"currency"`
+	Total          int64  `json:"total"`
+}
+
+func (q *Queries) ListUnbalancedJournalEntries(ctx context.Context) ([]ListUnbalancedJournalEntriesRow, error) {
+	rows, err := q.query(ctx, q.listUnbalancedJournalEntriesStmt, listUnbalancedJournalEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUnbalancedJournalEntriesRow{}
+	for rows.Next() {
+		var i ListUnbalancedJournalEntriesRow
+		if err := rows.Scan(&i.JournalEntryID, &i.Currency, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
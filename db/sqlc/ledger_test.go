@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getAccountLedger(t *testing.T, account Account) LedgerAccount {
+	ledgerAccount, err := testQueries.GetLedgerAccountByAccountID(context.Background(), sql.NullInt64{Int64: account.ID, Valid: true})
+	require.NoError(t, err)
+	require.Equal(t, account.Currency, ledgerAccount.Currency)
+	return ledgerAccount
+}
+
+func getSystemLedger(t *testing.T, kind string, currency string) LedgerAccount {
+	ledgerAccount, err := testQueries.GetLedgerAccountByCode(context.Background(), SystemLedgerCode(kind, currency))
+	require.NoError(t, err)
+	require.False(t, ledgerAccount.AccountID.Valid)
+	return ledgerAccount
+}
+
+func requireBalancedJournal(t *testing.T, journalEntryID int64) []Posting {
+	postings, err := testQueries.ListPostings(context.Background(), journalEntryID)
+	require.NoError(t, err)
+
+	totals := map[string]int64{}
+	for _, posting := range postings {
+		totals[posting.Currency] += posting.Amount
+	}
+	for currency, total := range totals {
+		require.Zero(t, total, "postings in %s don't sum to zero", currency)
+	}
+	return postings
+}
+
+func TestPostJournalTxFee(t *testing.T) {
+	store := NewStore(testDb)
+
+	fee := int64(5)
+	account := createFundedAccount(t, fee)
+	feesLedger := getSystemLedger(t, LedgerFees, account.Currency)
+
+	result, err := store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Type:        JournalFee,
+		Description: "monthly fee",
+		Postings: []JournalPosting{
+			{LedgerAccountID: getAccountLedger(t, account).ID, Amount: -fee},
+			{LedgerAccountID: feesLedger.ID, Amount: fee},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, JournalFee, result.JournalEntry.Type)
+	require.Len(t, result.Postings, 2)
+
+	// only the customer posting writes an entry and moves a balance
+	require.Equal(t, -fee, result.Entries[0].Amount)
+	require.Equal(t, result.Entries[0].ID, result.Postings[0].EntryID.Int64)
+	require.Equal(t, account.Balance-fee, result.Accounts[0].Balance)
+	require.Zero(t, result.Entries[1].ID)
+	require.False(t, result.Postings[1].EntryID.Valid)
+
+	requireBalancedJournal(t, result.JournalEntry.ID)
+}
+
+func TestPostJournalTxUnbalanced(t *testing.T) {
+	store := NewStore(testDb)
+
+	account := createFundedAccount(t, 10)
+	feesLedger := getSystemLedger(t, LedgerFees, account.Currency)
+
+	_, err := store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Type: JournalFee,
+		Postings: []JournalPosting{
+			{LedgerAccountID: getAccountLedger(t, account).ID, Amount: -10},
+			{LedgerAccountID: feesLedger.ID, Amount: 9},
+		},
+	})
+	require.ErrorIs(t, err, ErrUnbalancedJournal)
+
+	// nothing was written
+	updatedAccount, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, updatedAccount.Balance)
+}
+
+func TestPostJournalTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDb)
+
+	account := createRandomAccount(t)
+	feesLedger := getSystemLedger(t, LedgerFees, account.Currency)
+	amount := account.Balance + account.OverdraftLimit + 1
+
+	_, err := store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Type: JournalFee,
+		Postings: []JournalPosting{
+			{LedgerAccountID: getAccountLedger(t, account).ID, Amount: -amount},
+			{LedgerAccountID: feesLedger.ID, Amount: amount},
+		},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestTransferTxJournal(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	amount := int64(100)
+	account1 := createFundedAccount(t, amount)
+	account2 := createRandomAccount(t)
+
+	result, err := store.TransferTx(ctx, TransferTxParams{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount:        amount,
+		ExchangeRate:  "0.925",
+	})
+	require.NoError(t, err)
+
+	journalEntries, err := testQueries.ListJournalEntriesByTransfer(ctx, sql.NullInt64{Int64: result.Transfer.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, journalEntries, 1)
+	require.Equal(t, JournalTransfer, journalEntries[0].Type)
+
+	postings := requireBalancedJournal(t, journalEntries[0].ID)
+
+	// both customer legs plus the two fx legs absorbing the conversion
+	require.Len(t, postings, 4)
+	require.Equal(t, result.FromEntry.ID, postings[0].EntryID.Int64)
+	require.Equal(t, result.ToEntry.ID, postings[1].EntryID.Int64)
+	require.Equal(t, getSystemLedger(t, LedgerFX, account1.Currency).ID, postings[2].LedgerAccountID)
+	require.Equal(t, getSystemLedger(t, LedgerFX, account2.Currency).ID, postings[3].LedgerAccountID)
+}
+
+func TestReconcileAccountTx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	// a random account starts with a balance but no entries, so it drifts by its whole balance
+	account := createRandomAccount(t)
+	require.NotZero(t, account.Balance)
+
+	result, err := store.ReconcileAccountTx(ctx, ReconcileAccountTxParams{AccountID: account.ID})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, result.Drift)
+	require.Equal(t, account.Balance, result.Account.Balance)
+	require.Equal(t, JournalAdjustment, result.JournalEntry.Type)
+	require.Equal(t, account.Balance, result.Entry.Amount)
+
+	postings := requireBalancedJournal(t, result.JournalEntry.ID)
+	require.Len(t, postings, 2)
+	require.Equal(t, getAccountLedger(t, account).ID, postings[0].LedgerAccountID)
+	require.Equal(t, getSystemLedger(t, LedgerSuspense, account.Currency).ID, postings[1].LedgerAccountID)
+
+	entriesTotal, err := testQueries.GetEntriesTotal(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, entriesTotal)
+
+	updatedAccount, err := testQueries.GetAccount(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, updatedAccount.Balance)
+
+	// once reconciled there is nothing left to correct
+	result, err = store.ReconcileAccountTx(ctx, ReconcileAccountTxParams{AccountID: account.ID})
+	require.NoError(t, err)
+	require.Zero(t, result.Drift)
+	require.Zero(t, result.JournalEntry.ID)
+}
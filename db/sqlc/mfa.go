@@ -0,0 +1,232 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Cell6969/go_bank/util"
+)
+
+// MaxMFAChallengeAttempts is how many wrong codes a login challenge accepts before it is burned
+const MaxMFAChallengeAttempts = 5
+
+var (
+	ErrMFANotEnabled       = errors.New("multi-factor authentication is not enabled")
+	ErrMFAAlreadyEnabled   = errors.New("multi-factor authentication is already enabled")
+	ErrInvalidMFACode      = errors.New("invalid multi-factor authentication code")
+	ErrMFAChallengeInvalid = errors.New("multi-factor authentication challenge is invalid or expired")
+)
+
+// verifyMFACode checks a TOTP code or an unused recovery code of the user, it must run inside a transaction.
+// The enrollment row stays locked until the transaction ends, so a code can't be accepted twice
+func verifyMFACode(ctx context.Context, q *Queries, username string, code string) (bool, error) {
+	enrollment, err := q.GetMFAEnrollmentForUpdate(ctx, username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, ErrMFANotEnabled
+		}
+		return false, err
+	}
+
+	if !enrollment.IsEnabled {
+		return false, ErrMFANotEnabled
+	}
+
+	if step, ok := util.ValidateTOTP(enrollment.TotpSecret, code, time.Now(), enrollment.LastUsedStep); ok {
+		err := q.UpdateMFALastUsedStep(ctx, UpdateMFALastUsedStepParams{
+			Username:     username,
+			LastUsedStep: step,
+		})
+		return err == nil, err
+	}
+
+	_, err = q.UseMFARecoveryCode(ctx, UseMFARecoveryCodeParams{
+		Username: username,
+		CodeHash: util.HashSecretToken(util.NormalizeRecoveryCode(code)),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// EnableMFATxParams contains input parameters of the enable MFA transaction
+// RecoveryCodeHashes replace the recovery codes of the user
+type EnableMFATxParams struct {
+	Username           string   `json:"username"`
+	Code               string   `json:"code"`
+	RecoveryCodeHashes []string `json:"recovery_code_hashes"`
+}
+
+// EnableMFATx confirms the pending enrollment of the user with a code of the authenticator app
+// and stores the new recovery codes
+func (store *SQLStore) EnableMFATx(ctx context.Context, arg EnableMFATxParams) (MfaEnrollment, error) {
+	var result MfaEnrollment
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		enrollment, err := q.GetMFAEnrollmentForUpdate(ctx, arg.Username)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrMFANotEnabled
+			}
+			return err
+		}
+
+		if enrollment.IsEnabled {
+			return ErrMFAAlreadyEnabled
+		}
+
+		step, ok := util.ValidateTOTP(enrollment.TotpSecret, arg.Code, time.Now(), enrollment.LastUsedStep)
+		if !ok {
+			return ErrInvalidMFACode
+		}
+
+		result, err = q.EnableMFAEnrollment(ctx, EnableMFAEnrollmentParams{
+			Username:     arg.Username,
+			LastUsedStep: step,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteMFARecoveryCodes(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		for _, codeHash := range arg.RecoveryCodeHashes {
+			_, err = q.CreateMFARecoveryCode(ctx, CreateMFARecoveryCodeParams{
+				Username: arg.Username,
+				CodeHash: codeHash,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// DisableMFATxParams contains input parameters of the disable MFA transaction
+type DisableMFATxParams struct {
+	Username string `json:"username"`
+	Code     string `json:"code"`
+}
+
+// DisableMFATx removes the enrollment and the recovery codes of the user once the code is verified.
+// A wrong code counts as a failure of the user, it is refused while the user is locked out
+func (store *SQLStore) DisableMFATx(ctx context.Context, arg DisableMFATxParams) error {
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := checkAuthLockout(ctx, q, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		ok, err := verifyMFACode(ctx, q, arg.Username, arg.Code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvalidMFACode
+		}
+
+		err = q.ResetAuthFailures(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteMFARecoveryCodes(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteMFAEnrollment(ctx, arg.Username)
+	})
+
+	if errors.Is(err, ErrInvalidMFACode) {
+		return store.recordAuthFailure(ctx, arg.Username, err)
+	}
+
+	return err
+}
+
+// VerifyMFATxParams contains input parameters of the verify MFA transaction
+type VerifyMFATxParams struct {
+	TokenHash string `json:"token_hash"`
+	Code      string `json:"code"`
+}
+
+// VerifyMFATxResult contains result of VerifyMFATx
+type VerifyMFATxResult struct {
+	User      User         `json:"user"`
+	Challenge MfaChallenge `json:"challenge"`
+}
+
+// VerifyMFATx completes the login challenge with a TOTP or recovery code, a challenge can only be completed once.
+// A wrong code counts as an attempt of the challenge and as a failure of the user, the challenge is refused
+// after MaxMFAChallengeAttempts and every challenge of the user while the user is locked out
+func (store *SQLStore) VerifyMFATx(ctx context.Context, arg VerifyMFATxParams) (VerifyMFATxResult, error) {
+	var result VerifyMFATxResult
+	var challenge MfaChallenge
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		challenge, err = q.GetMFAChallengeForUpdate(ctx, arg.TokenHash)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrMFAChallengeInvalid
+			}
+			return err
+		}
+
+		if challenge.IsUsed || challenge.Attempts >= MaxMFAChallengeAttempts || time.Now().After(challenge.ExpiredAt) {
+			return ErrMFAChallengeInvalid
+		}
+
+		err = checkAuthLockout(ctx, q, challenge.Username)
+		if err != nil {
+			return err
+		}
+
+		ok, err := verifyMFACode(ctx, q, challenge.Username, arg.Code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvalidMFACode
+		}
+
+		err = q.ResetAuthFailures(ctx, challenge.Username)
+		if err != nil {
+			return err
+		}
+
+		result.Challenge, err = q.UseMFAChallenge(ctx, challenge.ID)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.GetUser(ctx, challenge.Username)
+		return err
+	})
+
+	// the attempt is counted outside of the transaction, otherwise it would be rolled back with it
+	if errors.Is(err, ErrInvalidMFACode) {
+		if _, attemptErr := store.AddMFAChallengeAttempt(ctx, challenge.ID); attemptErr != nil {
+			return result, fmt.Errorf("%w, add attempt err: %v", err, attemptErr)
+		}
+		return result, store.recordAuthFailure(ctx, challenge.Username, err)
+	}
+
+	return result, err
+}
@@ -0,0 +1,316 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: mfa.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const addMFAChallengeAttempt = `-- name: AddMFAChallengeAttempt :one
+UPDATE mfa_challenges
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING id, username, token_hash, attempts, is_used, user_agent, client_ip, expired_at, created_at
+`
+
+func (q *Queries) AddMFAChallengeAttempt(ctx context.Context, id int64) (MfaChallenge, error) {
+	row := q.queryRow(ctx, q.addMFAChallengeAttemptStmt, addMFAChallengeAttempt, id)
+	var i MfaChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.TokenHash,
+		&i.Attempts,
+		&i.IsUsed,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createMFAChallenge = `-- name: CreateMFAChallenge :one
+INSERT INTO mfa_challenges (
+  username,
+  token_hash,
+  user_agent,
+  client_ip,
+  expired_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id, username, token_hash, attempts, is_used, user_agent, client_ip, expired_at, created_at
+`
+
+type CreateMFAChallengeParams struct {
+	Username  string    `json:"username"`
+	TokenHash string    `json:"token_hash"`
+	UserAgent string    `json:"user_agent"`
+	ClientIp  string    `json:"client_ip"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+func (q *Queries) CreateMFAChallenge(ctx context.Context, arg CreateMFAChallengeParams) (MfaChallenge, error) {
+	row := q.queryRow(ctx, q.createMFAChallengeStmt, createMFAChallenge,
+		arg.Username,
+		arg.TokenHash,
+		arg.UserAgent,
+		arg.ClientIp,
+		arg.ExpiredAt,
+	)
+	var i MfaChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.TokenHash,
+		&i.Attempts,
+		&i.IsUsed,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createMFAEnrollment = `-- name: CreateMFAEnrollment :one
+INSERT INTO mfa_enrollments (
+  username,
+  totp_secret
+) VALUES (
+  $1, $2
+) ON CONFLICT (username) DO UPDATE
+SET totp_secret = EXCLUDED.totp_secret,
+    last_used_step = 0,
+    created_at = now()
+WHERE mfa_enrollments.is_enabled = false
+RETURNING username, totp_secret, is_enabled, last_used_step, enabled_at, created_at
+`
+
+type CreateMFAEnrollmentParams struct {
+	Username   string `json:"username"`
+	TotpSecret string `json:"totp_secret"`
+}
+
+// starts or restarts the enrollment of the user, nothing is returned once it is enabled
+func (q *Queries) CreateMFAEnrollment(ctx context.Context, arg CreateMFAEnrollmentParams) (MfaEnrollment, error) {
+	row := q.queryRow(ctx, q.createMFAEnrollmentStmt, createMFAEnrollment, arg.Username, arg.TotpSecret)
+	var i MfaEnrollment
+	err := row.Scan(
+		&i.Username,
+		&i.TotpSecret,
+		&i.IsEnabled,
+		&i.LastUsedStep,
+		&i.EnabledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createMFARecoveryCode = `-- name: CreateMFARecoveryCode :one
+INSERT INTO mfa_recovery_codes (
+  username,
+  code_hash
+) VALUES (
+  $1, $2
+) RETURNING id, username, code_hash, used_at, created_at
+`
+
+type CreateMFARecoveryCodeParams struct {
+	Username string `json:"username"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (q *Queries) CreateMFARecoveryCode(ctx context.Context, arg CreateMFARecoveryCodeParams) (MfaRecoveryCode, error) {
+	row := q.queryRow(ctx, q.createMFARecoveryCodeStmt, createMFARecoveryCode, arg.Username, arg.CodeHash)
+	var i MfaRecoveryCode
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.CodeHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteMFAEnrollment = `-- name: DeleteMFAEnrollment :exec
+DELETE FROM mfa_enrollments
+WHERE username = $1
+`
+
+func (q *Queries) DeleteMFAEnrollment(ctx context.Context, username string) error {
+	_, err := q.exec(ctx, q.deleteMFAEnrollmentStmt, deleteMFAEnrollment, username)
+	return err
+}
+
+const deleteMFARecoveryCodes = `-- name: DeleteMFARecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE username = $1
+`
+
+func (q *Queries) DeleteMFARecoveryCodes(ctx context.Context, username string) error {
+	_, err := q.exec(ctx, q.deleteMFARecoveryCodesStmt, deleteMFARecoveryCodes, username)
+	return err
+}
+
+const enableMFAEnrollment = `-- name: EnableMFAEnrollment :one
+UPDATE mfa_enrollments
+SET is_enabled = true,
+    last_used_step = $1,
+    enabled_at = now()
+WHERE username = $2
+RETURNING username, totp_secret, is_enabled, last_used_step, enabled_at, created_at
+`
+
+type EnableMFAEnrollmentParams struct {
+	LastUsedStep int64  `json:"last_used_step"`
+	Username     string `json:"username"`
+}
+
+func (q *Queries) EnableMFAEnrollment(ctx context.Context, arg EnableMFAEnrollmentParams) (MfaEnrollment, error) {
+	row := q.queryRow(ctx, q.enableMFAEnrollmentStmt, enableMFAEnrollment, arg.LastUsedStep, arg.Username)
+	var i MfaEnrollment
+	err := row.Scan(
+		&i.Username,
+		&i.TotpSecret,
+		&i.IsEnabled,
+		&i.LastUsedStep,
+		&i.EnabledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMFAChallengeForUpdate = `-- name: GetMFAChallengeForUpdate :one
+SELECT id, username, token_hash, attempts, is_used, user_agent, client_ip, expired_at, created_at FROM mfa_challenges
+WHERE token_hash = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetMFAChallengeForUpdate(ctx context.Context, tokenHash string) (MfaChallenge, error) {
+	row := q.queryRow(ctx, q.getMFAChallengeForUpdateStmt, getMFAChallengeForUpdate, tokenHash)
+	var i MfaChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.TokenHash,
+		&i.Attempts,
+		&i.IsUsed,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMFAEnrollment = `-- name: GetMFAEnrollment :one
+SELECT username, totp_secret, is_enabled, last_used_step, enabled_at, created_at FROM mfa_enrollments
+WHERE username = $1 LIMIT 1
+`
+
+func (q *Queries) GetMFAEnrollment(ctx context.Context, username string) (MfaEnrollment, error) {
+	row := q.queryRow(ctx, q.getMFAEnrollmentStmt, getMFAEnrollment, username)
+	var i MfaEnrollment
+	err := row.Scan(
+		&i.Username,
+		&i.TotpSecret,
+		&i.IsEnabled,
+		&i.LastUsedStep,
+		&i.EnabledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMFAEnrollmentForUpdate = `-- name: GetMFAEnrollmentForUpdate :one
+SELECT username, totp_secret, is_enabled, last_used_step, enabled_at, created_at FROM mfa_enrollments
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetMFAEnrollmentForUpdate(ctx context.Context, username string) (MfaEnrollment, error) {
+	row := q.queryRow(ctx, q.getMFAEnrollmentForUpdateStmt, getMFAEnrollmentForUpdate, username)
+	var i MfaEnrollment
+	err := row.Scan(
+		&i.Username,
+		&i.TotpSecret,
+		&i.IsEnabled,
+		&i.LastUsedStep,
+		&i.EnabledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateMFALastUsedStep = `-- name: UpdateMFALastUsedStep :exec
+UPDATE mfa_enrollments
+SET last_used_step = $1
+WHERE username = $2
+`
+
+type UpdateMFALastUsedStepParams struct {
+	LastUsedStep int64  `json:"last_used_step"`
+	Username     string `json:"username"`
+}
+
+func (q *Queries) UpdateMFALastUsedStep(ctx context.Context, arg UpdateMFALastUsedStepParams) error {
+	_, err := q.exec(ctx, q.updateMFALastUsedStepStmt, updateMFALastUsedStep, arg.LastUsedStep, arg.Username)
+	return err
+}
+
+const useMFAChallenge = `-- name: UseMFAChallenge :one
+UPDATE mfa_challenges
+SET is_used = true
+WHERE id = $1
+RETURNING id, username, token_hash, attempts, is_used, user_agent, client_ip, expired_at, created_at
+`
+
+func (q *Queries) UseMFAChallenge(ctx context.Context, id int64) (MfaChallenge, error) {
+	row := q.queryRow(ctx, q.useMFAChallengeStmt, useMFAChallenge, id)
+	var i MfaChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.TokenHash,
+		&i.Attempts,
+		&i.IsUsed,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const useMFARecoveryCode = `-- name: UseMFARecoveryCode :one
+UPDATE mfa_recovery_codes
+SET used_at = now()
+WHERE username = $1
+  AND code_hash = $2
+  AND used_at IS NULL
+RETURNING id, username, code_hash, used_at, created_at
+`
+
+type UseMFARecoveryCodeParams struct {
+	Username string `json:"username"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (q *Queries) UseMFARecoveryCode(ctx context.Context, arg UseMFARecoveryCodeParams) (MfaRecoveryCode, error) {
+	row := q.queryRow(ctx, q.useMFARecoveryCodeStmt, useMFARecoveryCode, arg.Username, arg.CodeHash)
+	var i MfaRecoveryCode
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.CodeHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
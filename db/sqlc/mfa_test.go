@@ -0,0 +1,243 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Cell6969/go_bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createEnabledMFA enrolls the user and enables it with the code of the current period,
+// it returns the secret and one recovery code
+func createEnabledMFA(t *testing.T, store Store, username string) (string, string) {
+	ctx := context.Background()
+
+	secret, err := util.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	enrollment, err := store.CreateMFAEnrollment(ctx, CreateMFAEnrollmentParams{
+		Username:   username,
+		TotpSecret: secret,
+	})
+	require.NoError(t, err)
+	require.False(t, enrollment.IsEnabled)
+
+	code, err := util.TOTPCode(secret, util.TOTPStep(time.Now()))
+	require.NoError(t, err)
+
+	recoveryCodes, err := util.GenerateRecoveryCodes(2)
+	require.NoError(t, err)
+
+	enrollment, err = store.EnableMFATx(ctx, EnableMFATxParams{
+		Username:           username,
+		Code:               code,
+		RecoveryCodeHashes: []string{util.HashSecretToken(recoveryCodes[0]), util.HashSecretToken(recoveryCodes[1])},
+	})
+	require.NoError(t, err)
+	require.True(t, enrollment.IsEnabled)
+	require.True(t, enrollment.EnabledAt.Valid)
+
+	return secret, recoveryCodes[0]
+}
+
+func createRandomMFAChallenge(t *testing.T, username string) string {
+	challengeToken, err := util.GenerateSecretToken()
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateMFAChallenge(context.Background(), CreateMFAChallengeParams{
+		Username:  username,
+		TokenHash: util.HashSecretToken(challengeToken),
+		UserAgent: "test",
+		ClientIp:  "127.0.0.1",
+		ExpiredAt: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	return challengeToken
+}
+
+func TestEnableMFATx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+	user := createRandomUser(t)
+
+	_, err := store.EnableMFATx(ctx, EnableMFATxParams{Username: user.Username, Code: "123456"})
+	require.ErrorIs(t, err, ErrMFANotEnabled)
+
+	secret, _ := createEnabledMFA(t, store, user.Username)
+
+	// an enabled enrollment is not replaced
+	_, err = store.CreateMFAEnrollment(ctx, CreateMFAEnrollmentParams{
+		Username:   user.Username,
+		TotpSecret: "OTHERSECRET",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	enrollment, err := store.GetMFAEnrollment(ctx, user.Username)
+	require.NoError(t, err)
+	require.Equal(t, secret, enrollment.TotpSecret)
+}
+
+func TestVerifyMFATx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+	user := createRandomUser(t)
+
+	secret, recoveryCode := createEnabledMFA(t, store, user.Username)
+	challengeToken := createRandomMFAChallenge(t, user.Username)
+	tokenHash := util.HashSecretToken(challengeToken)
+
+	// the code that enabled the enrollment can't be used again
+	enrollment, err := store.GetMFAEnrollment(ctx, user.Username)
+	require.NoError(t, err)
+
+	usedCode, err := util.TOTPCode(secret, enrollment.LastUsedStep)
+	require.NoError(t, err)
+
+	_, err = store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: tokenHash, Code: usedCode})
+	require.ErrorIs(t, err, ErrInvalidMFACode)
+
+	challenge, err := store.GetMFAChallengeForUpdate(ctx, tokenHash)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), challenge.Attempts)
+
+	result, err := store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: tokenHash, Code: recoveryCode})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, result.User.Username)
+	require.True(t, result.Challenge.IsUsed)
+
+	// neither the challenge nor the recovery code can be used twice
+	_, err = store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: tokenHash, Code: recoveryCode})
+	require.ErrorIs(t, err, ErrMFAChallengeInvalid)
+
+	_, err = store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: util.HashSecretToken(createRandomMFAChallenge(t, user.Username)), Code: recoveryCode})
+	require.ErrorIs(t, err, ErrInvalidMFACode)
+}
+
+func TestVerifyMFATxTooManyAttempts(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+	user := createRandomUser(t)
+
+	_, recoveryCode := createEnabledMFA(t, store, user.Username)
+	tokenHash := util.HashSecretToken(createRandomMFAChallenge(t, user.Username))
+
+	for i := 0; i < MaxMFAChallengeAttempts; i++ {
+		_, err := store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: tokenHash, Code: "wrong-code"})
+		require.ErrorIs(t, err, ErrInvalidMFACode)
+	}
+
+	_, err := store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: tokenHash, Code: recoveryCode})
+	require.ErrorIs(t, err, ErrMFAChallengeInvalid)
+}
+
+func TestDisableMFATx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+	user := createRandomUser(t)
+
+	_, recoveryCode := createEnabledMFA(t, store, user.Username)
+
+	err := store.DisableMFATx(ctx, DisableMFATxParams{Username: user.Username, Code: "wrong-code"})
+	require.ErrorIs(t, err, ErrInvalidMFACode)
+
+	err = store.DisableMFATx(ctx, DisableMFATxParams{Username: user.Username, Code: recoveryCode})
+	require.NoError(t, err)
+
+	_, err = store.GetMFAEnrollment(ctx, user.Username)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = store.DisableMFATx(ctx, DisableMFATxParams{Username: user.Username, Code: recoveryCode})
+	require.ErrorIs(t, err, ErrMFANotEnabled)
+}
+
+func TestVerifyMFATxLockout(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+	user := createRandomUser(t)
+
+	_, recoveryCode := createEnabledMFA(t, store, user.Username)
+
+	// a success resets the failures of the user
+	tokenHash := util.HashSecretToken(createRandomMFAChallenge(t, user.Username))
+	_, err := store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: tokenHash, Code: "wrong-code"})
+	require.ErrorIs(t, err, ErrInvalidMFACode)
+
+	failure, err := store.GetAuthFailure(ctx, user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), failure.FailedAttempts)
+
+	_, err = store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: tokenHash, Code: recoveryCode})
+	require.NoError(t, err)
+
+	failure, err = store.GetAuthFailure(ctx, user.Username)
+	require.NoError(t, err)
+	require.Zero(t, failure.FailedAttempts)
+
+	// failures are counted across challenges, a new challenge doesn't give new attempts
+	for i := 0; i < MaxAuthFailures; i++ {
+		tokenHash := util.HashSecretToken(createRandomMFAChallenge(t, user.Username))
+		_, err := store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: tokenHash, Code: "wrong-code"})
+		require.ErrorIs(t, err, ErrInvalidMFACode)
+	}
+
+	failure, err = store.GetAuthFailure(ctx, user.Username)
+	require.NoError(t, err)
+	require.True(t, failure.LockedUntil.Valid)
+	require.WithinDuration(t, time.Now().Add(AuthLockoutDuration), failure.LockedUntil.Time, time.Minute)
+
+	err = store.CheckAuthLockout(ctx, user.Username)
+	require.ErrorIs(t, err, ErrAuthLocked)
+
+	recoveryCodes, err := util.GenerateRecoveryCodes(1)
+	require.NoError(t, err)
+	secondRecoveryCode := recoveryCodes[0]
+
+	_, err = store.CreateMFARecoveryCode(ctx, CreateMFARecoveryCodeParams{
+		Username: user.Username,
+		CodeHash: util.HashSecretToken(secondRecoveryCode),
+	})
+	require.NoError(t, err)
+
+	tokenHash = util.HashSecretToken(createRandomMFAChallenge(t, user.Username))
+	_, err = store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: tokenHash, Code: secondRecoveryCode})
+	require.ErrorIs(t, err, ErrAuthLocked)
+
+	// the challenge wasn't used, it works again once the lockout is over
+	for i := 0; i < MaxAuthFailures; i++ {
+		_, err := store.AddAuthFailure(ctx, AddAuthFailureParams{
+			Username:    user.Username,
+			MaxAttempts: MaxAuthFailures,
+			LockedUntil: time.Now().Add(-time.Second),
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, store.CheckAuthLockout(ctx, user.Username))
+
+	_, err = store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: tokenHash, Code: secondRecoveryCode})
+	require.NoError(t, err)
+}
+
+func TestDisableMFATxLockout(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+	user := createRandomUser(t)
+
+	_, recoveryCode := createEnabledMFA(t, store, user.Username)
+
+	for i := 0; i < MaxAuthFailures; i++ {
+		err := store.DisableMFATx(ctx, DisableMFATxParams{Username: user.Username, Code: "wrong-code"})
+		require.ErrorIs(t, err, ErrInvalidMFACode)
+	}
+
+	err := store.DisableMFATx(ctx, DisableMFATxParams{Username: user.Username, Code: recoveryCode})
+	require.ErrorIs(t, err, ErrAuthLocked)
+
+	enrollment, err := store.GetMFAEnrollment(ctx, user.Username)
+	require.NoError(t, err)
+	require.True(t, enrollment.IsEnabled)
+}
@@ -5,36 +5,225 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
-	Balance   int64     `json:"balance"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"created_at"`
+	ID             int64     `json:"id"`
+	Owner          string    `json:"owner"`
+	Balance        int64     `json:"balance"`
+	Currency       string    `json:"currency"`
+	CreatedAt      time.Time `json:"created_at"`
+	OverdraftLimit int64     `json:"overdraft_limit"`
+	Status         string    `json:"status"`
+}
+
+type AccountStatusChange struct {
+	ID         int64     `json:"id"`
+	AccountID  int64     `json:"account_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedBy  string    `json:"changed_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// a null limit falls back to the default of the account currency
+type AccountTransferLimit struct {
+	AccountID      int64         `json:"account_id"`
+	MaxPerTransfer sql.NullInt64 `json:"max_per_transfer"`
+	DailyAmount    sql.NullInt64 `json:"daily_amount"`
+	DailyCount     sql.NullInt32 `json:"daily_count"`
+	UpdatedBy      string        `json:"updated_by"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+type AuthFailure struct {
+	Username string `json:"username"`
+	// wrong codes or passwords since the last success or lockout, shared by every MFA and step-up challenge of the user
+	FailedAttempts int32        `json:"failed_attempts"`
+	LockedUntil    sql.NullTime `json:"locked_until"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+type CashTransaction struct {
+	ID             int64     `json:"id"`
+	AccountID      int64     `json:"account_id"`
+	Type           string    `json:"type"`
+	Amount         int64     `json:"amount"`
+	Channel        string    `json:"channel"`
+	Reference      string    `json:"reference"`
+	JournalEntryID int64     `json:"journal_entry_id"`
+	EntryID        int64     `json:"entry_id"`
+	CreatedBy      string    `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
 }
 
 type Entry struct {
 	ID        int64 `json:"id"`
 	AccountID int64 `json:"account_id"`
 	// can be negative
-	Amount    int64     `json:"amount"`
+	Amount            int64         `json:"amount"`
+	CreatedAt         time.Time     `json:"created_at"`
+	ExchangeRate      string        `json:"exchange_rate"`
+	SourceAmount      int64         `json:"source_amount"`
+	DestinationAmount int64         `json:"destination_amount"`
+	TransferID        sql.NullInt64 `json:"transfer_id"`
+}
+
+type IdempotencyKey struct {
+	Owner      string          `json:"owner"`
+	Key        string          `json:"key"`
+	TransferID int64           `json:"transfer_id"`
+	Result     json.RawMessage `json:"result"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type JournalEntry struct {
+	ID          int64         `json:"id"`
+	Type        string        `json:"type"`
+	Description string        `json:"description"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type LedgerAccount struct {
+	ID        int64         `json:"id"`
+	Code      string        `json:"code"`
+	Name      string        `json:"name"`
+	Type      string        `json:"type"`
+	Currency  string        `json:"currency"`
+	AccountID sql.NullInt64 `json:"account_id"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type MfaChallenge struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// SHA-256 of the challenge token returned by the first login step
+	TokenHash string    `json:"token_hash"`
+	Attempts  int32     `json:"attempts"`
+	IsUsed    bool      `json:"is_used"`
+	UserAgent string    `json:"user_agent"`
+	ClientIp  string    `json:"client_ip"`
+	ExpiredAt time.Time `json:"expired_at"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type MfaEnrollment struct {
+	Username   string `json:"username"`
+	TotpSecret string `json:"totp_secret"`
+	IsEnabled  bool   `json:"is_enabled"`
+	// TOTP period of the last accepted code, older codes are refused
+	LastUsedStep int64        `json:"last_used_step"`
+	EnabledAt    sql.NullTime `json:"enabled_at"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+type MfaRecoveryCode struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// SHA-256 of the recovery code, the code itself is only shown once
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type OutboxEvent struct {
+	ID            uuid.UUID       `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+	PublishedAt   sql.NullTime    `json:"published_at"`
+	Attempts      int32           `json:"attempts"`
+	// the relay skips the event until then after a failed publish
+	NextAttemptAt time.Time      `json:"next_attempt_at"`
+	LastError     sql.NullString `json:"last_error"`
+}
+
+type PasswordReset struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// SHA-256 of the token sent by email, the token itself is never stored
+	TokenHash string    `json:"token_hash"`
+	IsUsed    bool      `json:"is_used"`
+	ExpiredAt time.Time `json:"expired_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Posting struct {
+	ID              int64         `json:"id"`
+	JournalEntryID  int64         `json:"journal_entry_id"`
+	LedgerAccountID int64         `json:"ledger_account_id"`
+	Amount          int64         `json:"amount"`
+	Currency        string        `json:"currency"`
+	EntryID         sql.NullInt64 `json:"entry_id"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	// RRULE subset like FREQ=MONTHLY;BYMONTHDAY=1, empty for a one-off transfer
+	Recurrence  string       `json:"recurrence"`
+	StartAt     time.Time    `json:"start_at"`
+	EndAt       sql.NullTime `json:"end_at"`
+	NextRunAt   time.Time    `json:"next_run_at"`
+	Status      string       `json:"status"`
+	LockedUntil sql.NullTime `json:"locked_until"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+type ScheduledTransferRun struct {
+	ID                  int64         `json:"id"`
+	ScheduledTransferID int64         `json:"scheduled_transfer_id"`
+	OccurrenceAt        time.Time     `json:"occurrence_at"`
+	Status              string        `json:"status"`
+	TransferID          sql.NullInt64 `json:"transfer_id"`
+	Error               string        `json:"error"`
+	CreatedAt           time.Time     `json:"created_at"`
+}
+
 type Session struct {
-	ID           uuid.UUID `json:"id"`
-	Username     string    `json:"username"`
-	RefreshToken string    `json:"refresh_token"`
-	UserAgent    string    `json:"user_agent"`
-	ClientIp     string    `json:"client_ip"`
-	IsBlocked    bool      `json:"is_blocked"`
-	ExpiredAt    time.Time `json:"expired_at"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID              uuid.UUID     `json:"id"`
+	Username        string        `json:"username"`
+	RefreshToken    string        `json:"refresh_token"`
+	UserAgent       string        `json:"user_agent"`
+	ClientIp        string        `json:"client_ip"`
+	IsBlocked       bool          `json:"is_blocked"`
+	ExpiredAt       time.Time     `json:"expired_at"`
+	CreatedAt       time.Time     `json:"created_at"`
+	ParentID        uuid.NullUUID `json:"parent_id"`
+	FamilyID        uuid.UUID     `json:"family_id"`
+	RotatedAt       sql.NullTime  `json:"rotated_at"`
+	FamilyExpiredAt time.Time     `json:"family_expired_at"`
+}
+
+type Task struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Queue       string          `json:"queue"`
+	Priority    int32           `json:"priority"`
+	Status      string          `json:"status"`
+	Attempts    int32           `json:"attempts"`
+	MaxAttempts int32           `json:"max_attempts"`
+	LastError   sql.NullString  `json:"last_error"`
+	RunAt       time.Time       `json:"run_at"`
+	LockedUntil sql.NullTime    `json:"locked_until"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
 }
 
 type Transfer struct {
@@ -44,6 +233,50 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// to_amount = amount * exchange_rate
+	ExchangeRate string `json:"exchange_rate"`
+	// amount credited in the destination account currency
+	ToAmount int64 `json:"to_amount"`
+}
+
+type TransferChallenge struct {
+	ID int64 `json:"id"`
+	// id of the access token payload that asked for the transfer, only it can confirm the transfer
+	TokenID        uuid.UUID     `json:"token_id"`
+	Username       string        `json:"username"`
+	FromAccountID  int64         `json:"from_account_id"`
+	ToAccountID    int64         `json:"to_account_id"`
+	Amount         int64         `json:"amount"`
+	ExchangeRate   string        `json:"exchange_rate"`
+	IdempotencyKey string        `json:"idempotency_key"`
+	Method         string        `json:"method"`
+	Status         string        `json:"status"`
+	Attempts       int32         `json:"attempts"`
+	TransferID     sql.NullInt64 `json:"transfer_id"`
+	ExpiredAt      time.Time     `json:"expired_at"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type TransferLimitDefault struct {
+	Currency       string `json:"currency"`
+	MaxPerTransfer int64  `json:"max_per_transfer"`
+	DailyAmount    int64  `json:"daily_amount"`
+	DailyCount     int32  `json:"daily_count"`
+	// transfers above it wait for the user to prove their identity again
+	StepUpAmount int64 `json:"step_up_amount"`
+}
+
+type TransferReversal struct {
+	ID         int64 `json:"id"`
+	TransferID int64 `json:"transfer_id"`
+	// amount given back to the sender, in the source account currency
+	Amount int64 `json:"amount"`
+	// amount taken back from the recipient, in the destination account currency
+	ToAmount       int64     `json:"to_amount"`
+	Reason         string    `json:"reason"`
+	JournalEntryID int64     `json:"journal_entry_id"`
+	CreatedBy      string    `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
 }
 
 type User struct {
@@ -53,4 +286,40 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	Role              string    `json:"role"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
+}
+
+type VerifyEmail struct {
+	ID         int64     `json:"id"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	SecretCode string    `json:"secret_code"`
+	IsUsed     bool      `json:"is_used"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiredAt  time.Time `json:"expired_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	EndpointID     int64           `json:"endpoint_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	ResponseStatus sql.NullInt32   `json:"response_status"`
+	LastError      sql.NullString  `json:"last_error"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    sql.NullTime    `json:"delivered_at"`
+}
+
+type WebhookEndpoint struct {
+	ID         int64     `json:"id"`
+	Owner      string    `json:"owner"`
+	Url        string    `json:"url"`
+	Secret     string    `json:"secret"`
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
 }
@@ -0,0 +1,214 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// constant for all outbox aggregates and event types
+const (
+	AggregateTransfer        = "transfer"
+	AggregateCashTransaction = "cash_transaction"
+	EventTransferCompleted   = "transfer.completed"
+	EventTransferReversed    = "transfer.reversed"
+	EventCashDeposited       = "cash.deposited"
+	EventCashWithdrawn       = "cash.withdrawn"
+)
+
+// TransferCompletedPayload is the payload of the transfer.completed event
+type TransferCompletedPayload struct {
+	TransferID    int64     `json:"transfer_id"`
+	FromAccountID int64     `json:"from_account_id"`
+	ToAccountID   int64     `json:"to_account_id"`
+	Amount        int64     `json:"amount"`
+	ExchangeRate  string    `json:"exchange_rate"`
+	ToAmount      int64     `json:"to_amount"`
+	FromEntryID   int64     `json:"from_entry_id"`
+	ToEntryID     int64     `json:"to_entry_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// createTransferCompletedEvent writes the transfer.completed event into the outbox,
+// it must run in the transaction of the transfer so the event exists only if the transfer does
+func createTransferCompletedEvent(ctx context.Context, q *Queries, result TransferTxResult) error {
+	payload, err := json.Marshal(TransferCompletedPayload{
+		TransferID:    result.Transfer.ID,
+		FromAccountID: result.Transfer.FromAccountID,
+		ToAccountID:   result.Transfer.ToAccountID,
+		Amount:        result.Transfer.Amount,
+		ExchangeRate:  result.Transfer.ExchangeRate,
+		ToAmount:      result.Transfer.ToAmount,
+		FromEntryID:   result.FromEntry.ID,
+		ToEntryID:     result.ToEntry.ID,
+		CreatedAt:     result.Transfer.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateOutboxEvent(ctx, CreateOutboxEventParams{
+		ID:            uuid.New(),
+		AggregateType: AggregateTransfer,
+		AggregateID:   strconv.FormatInt(result.Transfer.ID, 10),
+		EventType:     EventTransferCompleted,
+		Payload:       payload,
+	})
+	return err
+}
+
+// TransferReversedPayload is the payload of the transfer.reversed event.
+// FromEntryID credits the sender of the transfer back, ToEntryID debits the recipient
+type TransferReversedPayload struct {
+	ReversalID  int64     `json:"reversal_id"`
+	TransferID  int64     `json:"transfer_id"`
+	Amount      int64     `json:"amount"`
+	ToAmount    int64     `json:"to_amount"`
+	FromEntryID int64     `json:"from_entry_id"`
+	ToEntryID   int64     `json:"to_entry_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// createTransferReversedEvent writes the transfer.reversed event into the outbox,
+// it must run in the transaction of the reversal so the event exists only if the reversal does
+func createTransferReversedEvent(ctx context.Context, q *Queries, result ReverseTransferTxResult) error {
+	payload, err := json.Marshal(TransferReversedPayload{
+		ReversalID:  result.Reversal.ID,
+		TransferID:  result.Reversal.TransferID,
+		Amount:      result.Reversal.Amount,
+		ToAmount:    result.Reversal.ToAmount,
+		FromEntryID: result.FromEntry.ID,
+		ToEntryID:   result.ToEntry.ID,
+		CreatedAt:   result.Reversal.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateOutboxEvent(ctx, CreateOutboxEventParams{
+		ID:            uuid.New(),
+		AggregateType: AggregateTransfer,
+		AggregateID:   strconv.FormatInt(result.Reversal.TransferID, 10),
+		EventType:     EventTransferReversed,
+		Payload:       payload,
+	})
+	return err
+}
+
+// CashPayload is the payload of the cash.deposited and cash.withdrawn events
+type CashPayload struct {
+	CashTransactionID int64     `json:"cash_transaction_id"`
+	AccountID         int64     `json:"account_id"`
+	Amount            int64     `json:"amount"`
+	Channel           string    `json:"channel"`
+	Reference         string    `json:"reference"`
+	EntryID           int64     `json:"entry_id"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// createCashEvent writes the cash.deposited or cash.withdrawn event into the outbox,
+// it must run in the transaction of the deposit or withdrawal so the event exists only if the entry does
+func createCashEvent(ctx context.Context, q *Queries, result CashTxResult) error {
+	payload, err := json.Marshal(CashPayload{
+		CashTransactionID: result.CashTransaction.ID,
+		AccountID:         result.CashTransaction.AccountID,
+		Amount:            result.CashTransaction.Amount,
+		Channel:           result.CashTransaction.Channel,
+		Reference:         result.CashTransaction.Reference,
+		EntryID:           result.Entry.ID,
+		CreatedAt:         result.CashTransaction.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	eventType := EventCashDeposited
+	if result.CashTransaction.Type == CashWithdrawal {
+		eventType = EventCashWithdrawn
+	}
+
+	_, err = q.CreateOutboxEvent(ctx, CreateOutboxEventParams{
+		ID:            uuid.New(),
+		AggregateType: AggregateCashTransaction,
+		AggregateID:   strconv.FormatInt(result.CashTransaction.ID, 10),
+		EventType:     eventType,
+		Payload:       payload,
+	})
+	return err
+}
+
+// maxOutboxRetryDelay caps the backoff of an event that keeps failing to publish
+const maxOutboxRetryDelay = time.Hour
+
+// outboxRetryDelay doubles the delay with every failed attempt, starting at one second
+func outboxRetryDelay(attempts int32) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 12 {
+		return maxOutboxRetryDelay
+	}
+
+	delay := time.Second << (attempts - 1)
+	if delay > maxOutboxRetryDelay {
+		return maxOutboxRetryDelay
+	}
+	return delay
+}
+
+// PublishOutboxEventsTx hands up to limit due unpublished events to publish, oldest first, and marks them as published.
+// The events stay locked until the transaction ends so concurrent relays skip them. A failed event records the error
+// and is skipped until its next attempt with exponential backoff, the rest of the batch is still published and the
+// first publish error is returned with the count. An event may be published again when the commit fails,
+// so delivery is at-least-once, and a retried event comes after the events created since it.
+func (store *SQLStore) PublishOutboxEventsTx(ctx context.Context, limit int32, publish func(event OutboxEvent) error) (int, error) {
+	published := 0
+	failed := 0
+	var firstErr error
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		events, err := q.ClaimUnpublishedOutboxEvents(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if publishErr := publish(event); publishErr != nil {
+				err := q.RecordOutboxEventFailure(ctx, RecordOutboxEventFailureParams{
+					ID:            event.ID,
+					NextAttemptAt: time.Now().Add(outboxRetryDelay(event.Attempts + 1)),
+					LastError:     sql.NullString{String: publishErr.Error(), Valid: true},
+				})
+				if err != nil {
+					return err
+				}
+
+				if firstErr == nil {
+					firstErr = publishErr
+				}
+				failed++
+				continue
+			}
+
+			if err := q.MarkOutboxEventPublished(ctx, event.ID); err != nil {
+				return err
+			}
+			published++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if firstErr != nil {
+		return published, fmt.Errorf("failed to publish %d outbox events: %w", failed, firstErr)
+	}
+	return published, nil
+}
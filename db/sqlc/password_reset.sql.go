@@ -0,0 +1,93 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: password_reset.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const createPasswordReset = `-- name: CreatePasswordReset :one
+INSERT INTO password_resets (
+  username,
+  token_hash,
+  expired_at
+)
+SELECT $1::varchar, $2::varchar, $3::timestamp
+WHERE NOT EXISTS (
+  SELECT 1 FROM password_resets
+  WHERE username = $1::varchar
+    AND is_used = false
+    AND expired_at > now()
+)
+RETURNING id, username, token_hash, is_used, expired_at, created_at
+`
+
+type CreatePasswordResetParams struct {
+	Username  string    `json:"username"`
+	TokenHash string    `json:"token_hash"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// nothing is created while the user has an unused and unexpired reset, so the requests can't flood the inbox of the user
+func (q *Queries) CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error) {
+	row := q.queryRow(ctx, q.createPasswordResetStmt, createPasswordReset, arg.Username, arg.TokenHash, arg.ExpiredAt)
+	var i PasswordReset
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.TokenHash,
+		&i.IsUsed,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deletePasswordReset = `-- name: DeletePasswordReset :exec
+DELETE FROM password_resets
+WHERE id = $1
+`
+
+func (q *Queries) DeletePasswordReset(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deletePasswordResetStmt, deletePasswordReset, id)
+	return err
+}
+
+const usePasswordReset = `-- name: UsePasswordReset :one
+UPDATE password_resets
+SET is_used = true
+WHERE token_hash = $1
+  AND is_used = false
+  AND expired_at > now()
+RETURNING id, username, token_hash, is_used, expired_at, created_at
+`
+
+func (q *Queries) UsePasswordReset(ctx context.Context, tokenHash string) (PasswordReset, error) {
+	row := q.queryRow(ctx, q.usePasswordResetStmt, usePasswordReset, tokenHash)
+	var i PasswordReset
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.TokenHash,
+		&i.IsUsed,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const useUserPasswordResets = `-- name: UseUserPasswordResets :exec
+UPDATE password_resets
+SET is_used = true
+WHERE username = $1 AND is_used = false
+`
+
+// the other outstanding tokens of the user can no longer be used once the password is reset
+func (q *Queries) UseUserPasswordResets(ctx context.Context, username string) error {
+	_, err := q.exec(ctx, q.useUserPasswordResetsStmt, useUserPasswordResets, username)
+	return err
+}
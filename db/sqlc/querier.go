@@ -6,33 +6,152 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	// the counter starts over once it reaches max_attempts and the user is locked out until locked_until
+	AddAuthFailure(ctx context.Context, arg AddAuthFailureParams) (AuthFailure, error)
+	AddMFAChallengeAttempt(ctx context.Context, id int64) (MfaChallenge, error)
+	AddTransferChallengeAttempt(ctx context.Context, id int64) (TransferChallenge, error)
+	// moves the schedule past the occurrence that just ran, it does nothing when another scheduler already did
+	AdvanceScheduledTransfer(ctx context.Context, arg AdvanceScheduledTransferParams) (ScheduledTransfer, error)
+	BlockSession(ctx context.Context, arg BlockSessionParams) (Session, error)
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) (int64, error)
+	BlockUserSessions(ctx context.Context, username string) (int64, error)
+	CancelScheduledTransfer(ctx context.Context, arg CancelScheduledTransferParams) (ScheduledTransfer, error)
+	// picks the next due scheduled transfer, a lock that expired belongs to a crashed scheduler
+	ClaimDueScheduledTransfer(ctx context.Context, lockedUntil time.Time) (ScheduledTransfer, error)
+	// picks the next due task of the highest priority, a running task whose lock expired
+	// belongs to a crashed worker and is picked up again
+	ClaimTask(ctx context.Context, lockedUntil time.Time) (Task, error)
+	ClaimUnpublishedOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error)
+	CompleteTask(ctx context.Context, id int64) (Task, error)
+	CompleteTransferChallenge(ctx context.Context, arg CompleteTransferChallengeParams) (TransferChallenge, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountStatusChange(ctx context.Context, arg CreateAccountStatusChangeParams) (AccountStatusChange, error)
+	CreateCashTransaction(ctx context.Context, arg CreateCashTransactionParams) (CashTransaction, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (JournalEntry, error)
+	CreateMFAChallenge(ctx context.Context, arg CreateMFAChallengeParams) (MfaChallenge, error)
+	// starts or restarts the enrollment of the user, nothing is returned once it is enabled
+	CreateMFAEnrollment(ctx context.Context, arg CreateMFAEnrollmentParams) (MfaEnrollment, error)
+	CreateMFARecoveryCode(ctx context.Context, arg CreateMFARecoveryCodeParams) (MfaRecoveryCode, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (OutboxEvent, error)
+	// nothing is created while the user has an unused and unexpired reset, so the requests can't flood the inbox of the user
+	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error)
+	CreatePosting(ctx context.Context, arg CreatePostingParams) (Posting, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferChallenge(ctx context.Context, arg CreateTransferChallengeParams) (TransferChallenge, error)
+	CreateTransferReversal(ctx context.Context, arg CreateTransferReversalParams) (TransferReversal, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
+	// a delivery exists once per endpoint and event, fanning out the same event again returns the existing row
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error)
+	DeactivateWebhookEndpoint(ctx context.Context, arg DeactivateWebhookEndpointParams) (WebhookEndpoint, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteMFAEnrollment(ctx context.Context, username string) error
+	DeleteMFARecoveryCodes(ctx context.Context, username string) error
+	DeletePasswordReset(ctx context.Context, id int64) error
+	EnableMFAEnrollment(ctx context.Context, arg EnableMFAEnrollmentParams) (MfaEnrollment, error)
+	FailWebhookDelivery(ctx context.Context, arg FailWebhookDeliveryParams) (WebhookDelivery, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	// the limits of the account, each one falls back to the default of the account currency
+	GetAccountTransferLimit(ctx context.Context, id int64) (GetAccountTransferLimitRow, error)
+	GetAuthFailure(ctx context.Context, username string) (AuthFailure, error)
+	GetCashTransaction(ctx context.Context, id int64) (CashTransaction, error)
+	// the outgoing transfers of the account since the start of the day
+	GetDailyTransferTotal(ctx context.Context, fromAccountID int64) (GetDailyTransferTotalRow, error)
+	GetEntriesSumSince(ctx context.Context, arg GetEntriesSumSinceParams) (int64, error)
+	GetEntriesTotal(ctx context.Context, accountID int64) (int64, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetJournalEntry(ctx context.Context, id int64) (JournalEntry, error)
+	GetLedgerAccount(ctx context.Context, id int64) (LedgerAccount, error)
+	GetLedgerAccountByAccountID(ctx context.Context, accountID sql.NullInt64) (LedgerAccount, error)
+	GetLedgerAccountByCode(ctx context.Context, code string) (LedgerAccount, error)
+	GetMFAChallengeForUpdate(ctx context.Context, tokenHash string) (MfaChallenge, error)
+	GetMFAEnrollment(ctx context.Context, username string) (MfaEnrollment, error)
+	GetMFAEnrollmentForUpdate(ctx context.Context, username string) (MfaEnrollment, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetSessionForUpdate(ctx context.Context, id uuid.UUID) (Session, error)
+	GetStatementEntriesSumAfter(ctx context.Context, arg GetStatementEntriesSumAfterParams) (int64, error)
+	GetTask(ctx context.Context, id int64) (Task, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferChallengeForUpdate(ctx context.Context, id int64) (TransferChallenge, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
+	GetTransferLimitDefault(ctx context.Context, currency string) (TransferLimitDefault, error)
+	GetTransferReversedTotal(ctx context.Context, transferID int64) (GetTransferReversedTotalRow, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetVerifyEmail(ctx context.Context, id int64) (VerifyEmail, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	GetWebhookEndpoint(ctx context.Context, id int64) (WebhookEndpoint, error)
+	KillTask(ctx context.Context, arg KillTaskParams) (Task, error)
 	ListAccount(ctx context.Context, arg ListAccountParams) ([]Account, error)
+	ListAccountStatusChanges(ctx context.Context, arg ListAccountStatusChangesParams) ([]AccountStatusChange, error)
+	ListActiveSessions(ctx context.Context, username string) ([]Session, error)
+	// accounts whose balance is not the sum of their entries
+	ListBalanceDrifts(ctx context.Context) ([]ListBalanceDriftsRow, error)
+	ListDeadTasks(ctx context.Context, limit int32) ([]Task, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListEntriesAfter(ctx context.Context, arg ListEntriesAfterParams) ([]Entry, error)
+	ListJournalEntriesByTransfer(ctx context.Context, transferID sql.NullInt64) ([]JournalEntry, error)
+	ListOutboxEventsByAggregate(ctx context.Context, arg ListOutboxEventsByAggregateParams) ([]OutboxEvent, error)
+	ListPostings(ctx context.Context, journalEntryID int64) ([]Posting, error)
+	ListScheduledTransferRuns(ctx context.Context, arg ListScheduledTransferRunsParams) ([]ScheduledTransferRun, error)
+	ListScheduledTransfers(ctx context.Context, owner string) ([]ScheduledTransfer, error)
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
+	ListSubscribedWebhookEndpoints(ctx context.Context, arg ListSubscribedWebhookEndpointsParams) ([]WebhookEndpoint, error)
+	// customer accounts keep their balance in accounts.balance, system accounts only have postings
+	ListSystemLedgerAccountBalances(ctx context.Context) ([]ListSystemLedgerAccountBalancesRow, error)
+	ListTransferReversals(ctx context.Context, transferID int64) ([]TransferReversal, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListUnbalancedJournalEntries(ctx context.Context) ([]ListUnbalancedJournalEntriesRow, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookEndpoints(ctx context.Context, owner string) ([]WebhookEndpoint, error)
+	// serializes the limit checks of the transfers out of an account without locking its row,
+	// so the balances can still be locked in the order of the account ids.
+	// The first key is the namespace of the lock, 1 for transfer limits and 2 for idempotency keys
+	LockAccountTransferLimit(ctx context.Context, accountID int64) error
+	// the first key is the namespace of the lock, see LockAccountTransferLimit
+	LockIdempotencyKey(ctx context.Context, arg LockIdempotencyKeyParams) error
+	MarkOutboxEventPublished(ctx context.Context, id uuid.UUID) error
+	RecordOutboxEventFailure(ctx context.Context, arg RecordOutboxEventFailureParams) error
+	RequeueDeadTask(ctx context.Context, id int64) (Task, error)
 	ResetAccountTable(ctx context.Context) error
+	ResetAuthFailures(ctx context.Context, username string) error
 	ResetEntryTable(ctx context.Context) error
 	ResetTransferTable(ctx context.Context) error
 	ResetUserTable(ctx context.Context) error
+	ResetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	RetryTask(ctx context.Context, arg RetryTaskParams) (Task, error)
+	RotateSession(ctx context.Context, id uuid.UUID) (Session, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateMFALastUsedStep(ctx context.Context, arg UpdateMFALastUsedStepParams) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) (WebhookDelivery, error)
+	UpsertAccountTransferLimit(ctx context.Context, arg UpsertAccountTransferLimitParams) (AccountTransferLimit, error)
+	UseMFAChallenge(ctx context.Context, id int64) (MfaChallenge, error)
+	UseMFARecoveryCode(ctx context.Context, arg UseMFARecoveryCodeParams) (MfaRecoveryCode, error)
+	UsePasswordReset(ctx context.Context, tokenHash string) (PasswordReset, error)
+	// the other outstanding tokens of the user can no longer be used once the password is reset
+	UseUserPasswordResets(ctx context.Context, username string) error
 }
 
 var _ Querier = (*Queries)(nil)
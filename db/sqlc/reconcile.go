@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ReconcileAccountTxParams contains input parameters of the reconcile account transaction
+type ReconcileAccountTxParams struct {
+	AccountID int64 `json:"account_id"`
+}
+
+// ReconcileAccountTxResult contains result of ReconcileAccountTx.
+// Drift is the balance minus the sum of the entries before the adjustment, the journal entry is empty
+// when there was nothing to correct
+type ReconcileAccountTxResult struct {
+	Account      Account      `json:"account"`
+	Drift        int64        `json:"drift"`
+	JournalEntry JournalEntry `json:"journal_entry"`
+	Entry        Entry        `json:"entry"`
+}
+
+// ReconcileAccountTx writes an adjustment journal entry so the entries of the account sum to its balance again.
+// The balance is kept as it is, the difference is booked against the suspense account of the currency
+// so it can be investigated later. The account row is locked while the drift is measured,
+// so concurrent transfers can't change it in between
+func (store *SQLStore) ReconcileAccountTx(ctx context.Context, arg ReconcileAccountTxParams) (ReconcileAccountTxResult, error) {
+	var result ReconcileAccountTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Account, err = q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		entriesTotal, err := q.GetEntriesTotal(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		result.Drift = result.Account.Balance - entriesTotal
+		if result.Drift == 0 {
+			return nil
+		}
+
+		accountLedger, err := q.GetLedgerAccountByAccountID(ctx, sql.NullInt64{Int64: arg.AccountID, Valid: true})
+		if err != nil {
+			return fmt.Errorf("failed to get ledger account of account %d: %w", arg.AccountID, err)
+		}
+
+		suspenseLedger, err := q.GetLedgerAccountByCode(ctx, SystemLedgerCode(LedgerSuspense, accountLedger.Currency))
+		if err != nil {
+			return fmt.Errorf("failed to get suspense ledger account: %w", err)
+		}
+
+		journal, err := postJournal(ctx, q, PostJournalTxParams{
+			Type:        JournalAdjustment,
+			Description: fmt.Sprintf("balance reconciliation of account %d", arg.AccountID),
+			Postings: []JournalPosting{
+				{LedgerAccountID: accountLedger.ID, Amount: result.Drift, SkipBalanceUpdate: true},
+				{LedgerAccountID: suspenseLedger.ID, Amount: -result.Drift},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.JournalEntry = journal.JournalEntry
+		result.Entry = journal.Entries[0]
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Cell6969/go_bank/util"
+)
+
+var (
+	// ErrTransferAlreadyReversed is returned when the whole amount of a transfer was already given back
+	ErrTransferAlreadyReversed = errors.New("transfer already reversed")
+	// ErrReversalAmountTooLarge is returned when a reversal would give back more than what is left of the transfer
+	ErrReversalAmountTooLarge = errors.New("reversal amount exceeds the amount left to reverse")
+)
+
+// ReverseTransferTxParams contains input parameters of the reverse transfer transaction.
+// Amount is in the source account currency, 0 reverses everything that was not reversed yet
+type ReverseTransferTxParams struct {
+	TransferID int64  `json:"transfer_id"`
+	Amount     int64  `json:"amount"`
+	Reason     string `json:"reason"`
+	CreatedBy  string `json:"created_by"`
+}
+
+// ReverseTransferTxResult contains result of ReverseTransferTx.
+// FromAccount and FromEntry belong to the sender of the original transfer, who gets the money back
+type ReverseTransferTxResult struct {
+	Reversal    TransferReversal `json:"reversal"`
+	Transfer    Transfer         `json:"transfer"`
+	FromAccount Account          `json:"from_account"`
+	ToAccount   Account          `json:"to_account"`
+	FromEntry   Entry            `json:"from_entry"`
+	ToEntry     Entry            `json:"to_entry"`
+}
+
+// ReverseTransferTx gives back all or part of a transfer by posting the compensating journal entry.
+// A transfer can be reversed in several parts until its whole amount is given back, the transfer row is locked
+// while the amount left is computed so concurrent reversals can't give back more than was sent.
+// The recipient is debited at the exchange rate of the transfer and must have the funds,
+// the transfer.reversed outbox event is written in the same transaction
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Transfer, err = q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		reversed, err := q.GetTransferReversedTotal(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		amountLeft := result.Transfer.Amount - reversed.Amount
+		if amountLeft <= 0 {
+			return ErrTransferAlreadyReversed
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = amountLeft
+		}
+		if amount > amountLeft {
+			return ErrReversalAmountTooLarge
+		}
+
+		// partial reversals are capped at what is left of the credited amount and the last one takes
+		// exactly the remainder, so the rounding never adds up to more or less than the recipient was credited
+		toAmountLeft := result.Transfer.ToAmount - reversed.ToAmount
+		toAmount := toAmountLeft
+		if amount < amountLeft {
+			converted, err := util.ConvertAmount(amount, result.Transfer.ExchangeRate)
+			if err != nil {
+				return err
+			}
+			toAmount = min(converted, toAmountLeft)
+		}
+
+		postings, err := transferPostings(ctx, q, TransferTxParams{
+			FromAccountId: result.Transfer.FromAccountID,
+			ToAccountId:   result.Transfer.ToAccountID,
+			Amount:        amount,
+		}, result.Transfer.ExchangeRate, toAmount)
+		if err != nil {
+			return err
+		}
+
+		// the compensating postings are the ones of the transfer turned around
+		for i := range postings {
+			postings[i].Amount = -postings[i].Amount
+		}
+
+		journal, err := postJournal(ctx, q, PostJournalTxParams{
+			Type:        JournalReversal,
+			Description: arg.Reason,
+			TransferID:  sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+			Postings:    postings,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.FromEntry, result.ToEntry = journal.Entries[0], journal.Entries[1]
+		result.FromAccount, result.ToAccount = journal.Accounts[0], journal.Accounts[1]
+
+		result.Reversal, err = q.CreateTransferReversal(ctx, CreateTransferReversalParams{
+			TransferID:     result.Transfer.ID,
+			Amount:         amount,
+			ToAmount:       toAmount,
+			Reason:         arg.Reason,
+			JournalEntryID: journal.JournalEntry.ID,
+			CreatedBy:      arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		return createTransferReversedEvent(ctx, q, result)
+	})
+
+	return result, err
+}
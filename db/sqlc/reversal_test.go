@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTransferTx(t *testing.T, amount int64, exchangeRate string) (TransferTxResult, Account) {
+	account1 := createFundedAccount(t, amount)
+	account2 := createRandomAccount(t)
+
+	result, err := NewStore(testDb).TransferTx(context.Background(), TransferTxParams{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount:        amount,
+		ExchangeRate:  exchangeRate,
+	})
+	require.NoError(t, err)
+	return result, account1
+}
+
+func TestReverseTransferTx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	transfer, _ := createRandomTransferTx(t, 100, "")
+
+	result, err := store.ReverseTransferTx(ctx, ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Reason:     "refund",
+		CreatedBy:  transfer.ToAccount.Owner,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, transfer.Transfer.ID, result.Reversal.TransferID)
+	require.Equal(t, int64(100), result.Reversal.Amount)
+	require.Equal(t, int64(100), result.Reversal.ToAmount)
+	require.Equal(t, "refund", result.Reversal.Reason)
+
+	require.Equal(t, transfer.FromAccount.Balance+100, result.FromAccount.Balance)
+	require.Equal(t, transfer.ToAccount.Balance-100, result.ToAccount.Balance)
+	require.Equal(t, int64(100), result.FromEntry.Amount)
+	require.Equal(t, int64(-100), result.ToEntry.Amount)
+	require.Equal(t, transfer.Transfer.ID, result.FromEntry.TransferID.Int64)
+
+	journalEntries, err := testQueries.ListJournalEntriesByTransfer(ctx, sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, journalEntries, 2)
+	require.Equal(t, JournalReversal, journalEntries[1].Type)
+	require.Equal(t, result.Reversal.JournalEntryID, journalEntries[1].ID)
+	requireBalancedJournal(t, journalEntries[1].ID)
+
+	events, err := testQueries.ListOutboxEventsByAggregate(ctx, ListOutboxEventsByAggregateParams{
+		AggregateType: AggregateTransfer,
+		AggregateID:   strconv.FormatInt(transfer.Transfer.ID, 10),
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, EventTransferCompleted, events[0].EventType)
+	require.Equal(t, EventTransferReversed, events[1].EventType)
+
+	var payload TransferReversedPayload
+	err = json.Unmarshal(events[1].Payload, &payload)
+	require.NoError(t, err)
+	require.Equal(t, result.Reversal.ID, payload.ReversalID)
+	require.Equal(t, result.FromEntry.ID, payload.FromEntryID)
+	require.Equal(t, result.ToEntry.ID, payload.ToEntryID)
+
+	// a transfer can't be reversed twice
+	_, err = store.ReverseTransferTx(ctx, ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		CreatedBy:  transfer.ToAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+}
+
+func TestReverseTransferTxPartial(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	transfer, _ := createRandomTransferTx(t, 100, "0.925")
+	require.Equal(t, int64(93), transfer.Transfer.ToAmount)
+
+	arg := ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     30,
+		CreatedBy:  transfer.ToAccount.Owner,
+	}
+
+	result1, err := store.ReverseTransferTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(30), result1.Reversal.Amount)
+	require.Equal(t, int64(28), result1.Reversal.ToAmount)
+	requireBalancedJournal(t, result1.Reversal.JournalEntryID)
+
+	// more than what is left is refused
+	arg.Amount = 71
+	_, err = store.ReverseTransferTx(ctx, arg)
+	require.ErrorIs(t, err, ErrReversalAmountTooLarge)
+
+	// the last part takes back exactly what is left of the credited amount
+	arg.Amount = 70
+	result2, err := store.ReverseTransferTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(70), result2.Reversal.Amount)
+	require.Equal(t, int64(93-28), result2.Reversal.ToAmount)
+	requireBalancedJournal(t, result2.Reversal.JournalEntryID)
+
+	require.Equal(t, transfer.FromAccount.Balance+100, result2.FromAccount.Balance)
+	require.Equal(t, transfer.ToAccount.Balance-93, result2.ToAccount.Balance)
+
+	reversals, err := testQueries.ListTransferReversals(ctx, transfer.Transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, reversals, 2)
+}
+
+func TestReverseTransferTxRoundedPieces(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	// 2.5 rounds up, the recipient is credited 3
+	transfer, _ := createRandomTransferTx(t, 5, "0.5")
+	require.Equal(t, int64(3), transfer.Transfer.ToAmount)
+
+	arg := ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     1,
+		CreatedBy:  transfer.ToAccount.Owner,
+	}
+
+	// every piece of 1 also rounds up, the pieces never take back more than was credited
+	var toAmount int64
+	for i := 0; i < 5; i++ {
+		result, err := store.ReverseTransferTx(ctx, arg)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, result.Reversal.ToAmount, int64(0))
+		requireBalancedJournal(t, result.Reversal.JournalEntryID)
+		toAmount += result.Reversal.ToAmount
+	}
+	require.Equal(t, transfer.Transfer.ToAmount, toAmount)
+
+	_, err := store.ReverseTransferTx(ctx, arg)
+	require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+
+	reversed, err := testQueries.GetTransferReversedTotal(ctx, transfer.Transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, transfer.Transfer.Amount, reversed.Amount)
+	require.Equal(t, transfer.Transfer.ToAmount, reversed.ToAmount)
+
+	toAccount, err := testQueries.GetAccount(ctx, transfer.ToAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, transfer.ToAccount.Balance-transfer.Transfer.ToAmount, toAccount.Balance)
+}
+
+func TestReverseTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	transfer, _ := createRandomTransferTx(t, 100, "")
+
+	// the recipient spends the money before the reversal
+	_, err := testQueries.AddAccountBalance(ctx, AddAccountBalanceParams{
+		ID:     transfer.ToAccount.ID,
+		Amount: -(transfer.ToAccount.Balance + transfer.ToAccount.OverdraftLimit),
+	})
+	require.NoError(t, err)
+
+	_, err = store.ReverseTransferTx(ctx, ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		CreatedBy:  transfer.ToAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	reversed, err := testQueries.GetTransferReversedTotal(ctx, transfer.Transfer.ID)
+	require.NoError(t, err)
+	require.Zero(t, reversed.Amount)
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// constant for all scheduled transfer statuses
+const (
+	ScheduledTransferActive    = "active"
+	ScheduledTransferCompleted = "completed"
+	ScheduledTransferCancelled = "cancelled"
+)
+
+// constant for all scheduled transfer run statuses
+const (
+	ScheduledRunSucceeded = "succeeded"
+	ScheduledRunFailed    = "failed"
+)
+
+// RecordScheduledTransferRunTxParams contains input parameters of the record scheduled transfer run transaction.
+// The run is the occurrence at the NextRunAt of the scheduled transfer, an empty Error means it succeeded.
+// A zero NextRunAt completes the scheduled transfer
+type RecordScheduledTransferRunTxParams struct {
+	ScheduledTransfer ScheduledTransfer `json:"scheduled_transfer"`
+	TransferID        sql.NullInt64     `json:"transfer_id"`
+	Error             string            `json:"error"`
+	NextRunAt         time.Time         `json:"next_run_at"`
+}
+
+// RecordScheduledTransferRunTxResult contains result of RecordScheduledTransferRunTx.
+// Recorded is false when the occurrence had already been recorded by another scheduler
+type RecordScheduledTransferRunTxResult struct {
+	ScheduledTransfer ScheduledTransfer    `json:"scheduled_transfer"`
+	Run               ScheduledTransferRun `json:"run"`
+	Recorded          bool                 `json:"recorded"`
+}
+
+// RecordScheduledTransferRunTx records the outcome of an occurrence and moves the schedule to the next one
+func (store *SQLStore) RecordScheduledTransferRunTx(ctx context.Context, arg RecordScheduledTransferRunTxParams) (RecordScheduledTransferRunTxResult, error) {
+	var result RecordScheduledTransferRunTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		runStatus := ScheduledRunSucceeded
+		if arg.Error != "" {
+			runStatus = ScheduledRunFailed
+		}
+
+		result.ScheduledTransfer = arg.ScheduledTransfer
+		result.Run, err = q.CreateScheduledTransferRun(ctx, CreateScheduledTransferRunParams{
+			ScheduledTransferID: arg.ScheduledTransfer.ID,
+			OccurrenceAt:        arg.ScheduledTransfer.NextRunAt,
+			Status:              runStatus,
+			TransferID:          arg.TransferID,
+			Error:               arg.Error,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+		result.Recorded = true
+
+		status, nextRunAt := ScheduledTransferActive, arg.NextRunAt
+		if nextRunAt.IsZero() {
+			status, nextRunAt = ScheduledTransferCompleted, arg.ScheduledTransfer.NextRunAt
+		}
+
+		scheduledTransfer, err := q.AdvanceScheduledTransfer(ctx, AdvanceScheduledTransferParams{
+			ID:           arg.ScheduledTransfer.ID,
+			OccurrenceAt: arg.ScheduledTransfer.NextRunAt,
+			NextRunAt:    nextRunAt,
+			Status:       status,
+		})
+		if err != nil {
+			// cancelled while the occurrence was running, the run is still recorded
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+
+		result.ScheduledTransfer = scheduledTransfer
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,262 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: scheduled_transfer.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const advanceScheduledTransfer = `-- name: AdvanceScheduledTransfer :one
+UPDATE scheduled_transfers
+SET next_run_at = $1,
+    status = $2,
+    locked_until = NULL,
+    updated_at = now()
+WHERE id = $3 AND next_run_at = $4 AND status = 'active'
+RETURNING id, owner, from_account_id, to_account_id, amount, currency, recurrence, start_at, end_at, next_run_at, status, locked_until, created_at, updated_at
+`
+
+type AdvanceScheduledTransferParams struct {
+	NextRunAt    time.Time `json:"next_run_at"`
+	Status       string    `json:"status"`
+	ID           int64     `json:"id"`
+	OccurrenceAt time.Time `json:"occurrence_at"`
+}
+
+// moves the schedule past the occurrence that just ran, it does nothing when another scheduler already did
+func (q *Queries) AdvanceScheduledTransfer(ctx context.Context, arg AdvanceScheduledTransferParams) (ScheduledTransfer, error) {
+	row := q.queryRow(ctx, q.advanceScheduledTransferStmt, advanceScheduledTransfer,
+		arg.NextRunAt,
+		arg.Status,
+		arg.ID,
+		arg.OccurrenceAt,
+	)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Recurrence,
+		&i.StartAt,
+		&i.EndAt,
+		&i.NextRunAt,
+		&i.Status,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const cancelScheduledTransfer = `-- name: CancelScheduledTransfer :one
+UPDATE scheduled_transfers
+SET status = 'cancelled',
+    updated_at = now()
+WHERE id = $1 AND owner = $2 AND status = 'active'
+RETURNING id, owner, from_account_id, to_account_id, amount, currency, recurrence, start_at, end_at, next_run_at, status, locked_until, created_at, updated_at
+`
+
+type CancelScheduledTransferParams struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+}
+
+func (q *Queries) CancelScheduledTransfer(ctx context.Context, arg CancelScheduledTransferParams) (ScheduledTransfer, error) {
+	row := q.queryRow(ctx, q.cancelScheduledTransferStmt, cancelScheduledTransfer, arg.ID, arg.Owner)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Recurrence,
+		&i.StartAt,
+		&i.EndAt,
+		&i.NextRunAt,
+		&i.Status,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const claimDueScheduledTransfer = `-- name: ClaimDueScheduledTransfer :one
+UPDATE scheduled_transfers
+SET locked_until = $1::timestamp,
+    updated_at = now()
+WHERE id = (
+  SELECT s.id FROM scheduled_transfers s
+  WHERE s.status = 'active'
+    AND s.next_run_at <= now()
+    AND (s.locked_until IS NULL OR s.locked_until < now())
+  ORDER BY s.next_run_at, s.id
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, owner, from_account_id, to_account_id, amount, currency, recurrence, start_at, end_at, next_run_at, status, locked_until, created_at, updated_at
+`
+
+// picks the next due scheduled transfer, a lock that expired belongs to a crashed scheduler
+func (q *Queries) ClaimDueScheduledTransfer(ctx context.Context, lockedUntil time.Time) (ScheduledTransfer, error) {
+	row := q.queryRow(ctx, q.claimDueScheduledTransferStmt, claimDueScheduledTransfer, lockedUntil)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Recurrence,
+		&i.StartAt,
+		&i.EndAt,
+		&i.NextRunAt,
+		&i.Status,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createScheduledTransfer = `-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  recurrence,
+  start_at,
+  end_at,
+  next_run_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, owner, from_account_id, to_account_id, amount, currency, recurrence, start_at, end_at, next_run_at, status, locked_until, created_at, updated_at
+`
+
+type CreateScheduledTransferParams struct {
+	Owner         string       `json:"owner"`
+	FromAccountID int64        `json:"from_account_id"`
+	ToAccountID   int64        `json:"to_account_id"`
+	Amount        int64        `json:"amount"`
+	Currency      string       `json:"currency"`
+	Recurrence    string       `json:"recurrence"`
+	StartAt       time.Time    `json:"start_at"`
+	EndAt         sql.NullTime `json:"end_at"`
+	NextRunAt     time.Time    `json:"next_run_at"`
+}
+
+func (q *Queries) CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error) {
+	row := q.queryRow(ctx, q.createScheduledTransferStmt, createScheduledTransfer,
+		arg.Owner,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.Recurrence,
+		arg.StartAt,
+		arg.EndAt,
+		arg.NextRunAt,
+	)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Recurrence,
+		&i.StartAt,
+		&i.EndAt,
+		&i.NextRunAt,
+		&i.Status,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getScheduledTransfer = `-- name: GetScheduledTransfer :one
+SELECT id, owner, from_account_id, to_account_id, amount, currency, recurrence, start_at, end_at, next_run_at, status, locked_until, created_at, updated_at FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	row := q.queryRow(ctx, q.getScheduledTransferStmt, getScheduledTransfer, id)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Recurrence,
+		&i.StartAt,
+		&i.EndAt,
+		&i.NextRunAt,
+		&i.Status,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listScheduledTransfers = `-- name: ListScheduledTransfers :many
+SELECT id, owner, from_account_id, to_account_id, amount, currency, recurrence, start_at, end_at, next_run_at, status, locked_until, created_at, updated_at FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id
+`
+
+func (q *Queries) ListScheduledTransfers(ctx context.Context, owner string) ([]ScheduledTransfer, error) {
+	rows, err := q.query(ctx, q.listScheduledTransfersStmt, listScheduledTransfers, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransfer{}
+	for rows.Next() {
+		var i ScheduledTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Recurrence,
+			&i.StartAt,
+			&i.EndAt,
+			&i.NextRunAt,
+			&i.Status,
+			&i.LockedUntil,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,105 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: scheduled_transfer_run.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createScheduledTransferRun = `-- name: CreateScheduledTransferRun :one
+INSERT INTO scheduled_transfer_runs (
+  scheduled_transfer_id,
+  occurrence_at,
+  status,
+  transfer_id,
+  error
+) VALUES (
+  $1, $2, $3, $4, $5
+) ON CONFLICT (scheduled_transfer_id, occurrence_at) DO NOTHING
+RETURNING id, scheduled_transfer_id, occurrence_at, status, transfer_id, error, created_at
+`
+
+type CreateScheduledTransferRunParams struct {
+	ScheduledTransferID int64         `json:"scheduled_transfer_id"`
+	OccurrenceAt        time.Time     `json:"occurrence_at"`
+	Status              string        `json:"status"`
+	TransferID          sql.NullInt64 `json:"transfer_id"`
+	Error               string        `json:"error"`
+}
+
+func (q *Queries) CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error) {
+	row := q.queryRow(ctx, q.createScheduledTransferRunStmt, createScheduledTransferRun,
+		arg.ScheduledTransferID,
+		arg.OccurrenceAt,
+		arg.Status,
+		arg.TransferID,
+		arg.Error,
+	)
+	var i ScheduledTransferRun
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduledTransferID,
+		&i.OccurrenceAt,
+		&i.Status,
+		&i.TransferID,
+		&i.Error,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listScheduledTransferRuns = `-- name: ListScheduledTransferRuns :many
+SELECT id, scheduled_transfer_id, occurrence_at, status, transfer_id, error, created_at FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = $1
+  AND (created_at, id) > ($2::timestamp, $3::bigint)
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListScheduledTransferRunsParams struct {
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	CursorCreatedAt     time.Time `json:"cursor_created_at"`
+	CursorID            int64     `json:"cursor_id"`
+	PageSize            int32     `json:"page_size"`
+}
+
+func (q *Queries) ListScheduledTransferRuns(ctx context.Context, arg ListScheduledTransferRunsParams) ([]ScheduledTransferRun, error) {
+	rows, err := q.query(ctx, q.listScheduledTransferRunsStmt, listScheduledTransferRuns,
+		arg.ScheduledTransferID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransferRun{}
+	for rows.Next() {
+		var i ScheduledTransferRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduledTransferID,
+			&i.OccurrenceAt,
+			&i.Status,
+			&i.TransferID,
+			&i.Error,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Cell6969/go_bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomScheduledTransfer(t *testing.T, recurrence string) ScheduledTransfer {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	startAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	arg := CreateScheduledTransferParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        util.GenerateRandomMoney(),
+		Currency:      account1.Currency,
+		Recurrence:    recurrence,
+		StartAt:       startAt,
+		NextRunAt:     startAt,
+	}
+
+	scheduledTransfer, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Owner, scheduledTransfer.Owner)
+	require.Equal(t, arg.Amount, scheduledTransfer.Amount)
+	require.Equal(t, arg.Recurrence, scheduledTransfer.Recurrence)
+	require.WithinDuration(t, arg.NextRunAt, scheduledTransfer.NextRunAt, time.Second)
+	require.Equal(t, ScheduledTransferActive, scheduledTransfer.Status)
+	require.False(t, scheduledTransfer.EndAt.Valid)
+
+	return scheduledTransfer
+}
+
+func TestRecordScheduledTransferRunTx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	scheduledTransfer := createRandomScheduledTransfer(t, "FREQ=DAILY")
+	arg := RecordScheduledTransferRunTxParams{
+		ScheduledTransfer: scheduledTransfer,
+		Error:             "account has insufficient funds",
+		NextRunAt:         scheduledTransfer.NextRunAt.AddDate(0, 0, 1),
+	}
+
+	result, err := store.RecordScheduledTransferRunTx(ctx, arg)
+	require.NoError(t, err)
+	require.True(t, result.Recorded)
+	require.Equal(t, ScheduledRunFailed, result.Run.Status)
+	require.Equal(t, arg.Error, result.Run.Error)
+	require.False(t, result.Run.TransferID.Valid)
+	require.True(t, scheduledTransfer.NextRunAt.Equal(result.Run.OccurrenceAt))
+	require.True(t, arg.NextRunAt.Equal(result.ScheduledTransfer.NextRunAt))
+	require.Equal(t, ScheduledTransferActive, result.ScheduledTransfer.Status)
+
+	// another scheduler finishing the same occurrence doesn't record it twice
+	result, err = store.RecordScheduledTransferRunTx(ctx, arg)
+	require.NoError(t, err)
+	require.False(t, result.Recorded)
+
+	runs, err := testQueries.ListScheduledTransferRuns(ctx, ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		PageSize:            10,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+
+	updated, err := testQueries.GetScheduledTransfer(ctx, scheduledTransfer.ID)
+	require.NoError(t, err)
+	require.True(t, arg.NextRunAt.Equal(updated.NextRunAt))
+}
+
+func TestRecordScheduledTransferRunTxCompleted(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	scheduledTransfer := createRandomScheduledTransfer(t, "")
+	transfer := createRandomTransfer(t, createRandomAccount(t), createRandomAccount(t))
+
+	result, err := store.RecordScheduledTransferRunTx(ctx, RecordScheduledTransferRunTxParams{
+		ScheduledTransfer: scheduledTransfer,
+		TransferID:        sql.NullInt64{Int64: transfer.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Recorded)
+	require.Equal(t, ScheduledRunSucceeded, result.Run.Status)
+	require.Equal(t, transfer.ID, result.Run.TransferID.Int64)
+	require.Equal(t, ScheduledTransferCompleted, result.ScheduledTransfer.Status)
+
+	// a completed scheduled transfer can't be cancelled anymore
+	_, err = testQueries.CancelScheduledTransfer(ctx, CancelScheduledTransferParams{
+		ID:    scheduledTransfer.ID,
+		Owner: scheduledTransfer.Owner,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCancelScheduledTransfer(t *testing.T) {
+	ctx := context.Background()
+	scheduledTransfer := createRandomScheduledTransfer(t, "FREQ=MONTHLY;BYMONTHDAY=1")
+
+	// only the owner can cancel it
+	_, err := testQueries.CancelScheduledTransfer(ctx, CancelScheduledTransferParams{
+		ID:    scheduledTransfer.ID,
+		Owner: util.GenerateRandomName(),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	cancelled, err := testQueries.CancelScheduledTransfer(ctx, CancelScheduledTransferParams{
+		ID:    scheduledTransfer.ID,
+		Owner: scheduledTransfer.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferCancelled, cancelled.Status)
+}
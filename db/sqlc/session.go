@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrRefreshTokenReused is returned when a refresh token that was already rotated is presented again
+	ErrRefreshTokenReused = errors.New("refresh token already used")
+	// ErrSessionBlocked is returned when the parent session has been blocked
+	ErrSessionBlocked = errors.New("session blocked")
+	// ErrSessionExpired is returned when the parent session has expired
+	ErrSessionExpired = errors.New("session expired")
+)
+
+// RotateSessionTxParams contains input parameters of session rotation
+// Session describes the new session, its ParentID, FamilyID and FamilyExpiredAt are taken from the parent session
+// and its ExpiredAt is capped at the expiry of the family
+type RotateSessionTxParams struct {
+	ParentID uuid.UUID           `json:"parent_id"`
+	Session  CreateSessionParams `json:"session"`
+}
+
+// RotateSessionTxResult contains result of RotateSessionTx
+type RotateSessionTxResult struct {
+	ParentSession Session `json:"parent_session"`
+	Session       Session `json:"session"`
+}
+
+// RotateSessionTx exchanges the refresh token of the parent session for a new session in the same family.
+// A refresh token can only be rotated once, presenting it again blocks the whole family
+// since it means the token has most likely been stolen.
+// The parent is checked again once locked, a concurrent reuse may have blocked the family in the meantime.
+func (store *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error) {
+	var result RotateSessionTxResult
+	var familyID uuid.UUID
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		parent, err := q.GetSessionForUpdate(ctx, arg.ParentID)
+		if err != nil {
+			return err
+		}
+
+		if parent.RotatedAt.Valid {
+			familyID = parent.FamilyID
+			return ErrRefreshTokenReused
+		}
+
+		if parent.IsBlocked {
+			return ErrSessionBlocked
+		}
+
+		if !time.Now().Before(parent.ExpiredAt) {
+			return ErrSessionExpired
+		}
+
+		result.ParentSession, err = q.RotateSession(ctx, parent.ID)
+		if err != nil {
+			return err
+		}
+
+		sessionArg := arg.Session
+		sessionArg.ParentID = uuid.NullUUID{UUID: parent.ID, Valid: true}
+		sessionArg.FamilyID = parent.FamilyID
+		sessionArg.FamilyExpiredAt = parent.FamilyExpiredAt
+		if sessionArg.ExpiredAt.After(parent.FamilyExpiredAt) {
+			sessionArg.ExpiredAt = parent.FamilyExpiredAt
+		}
+
+		result.Session, err = q.CreateSession(ctx, sessionArg)
+		return err
+	})
+
+	// the family is blocked outside of the transaction, otherwise it would be rolled back with it
+	if errors.Is(err, ErrRefreshTokenReused) {
+		if _, blockErr := store.BlockSessionFamily(ctx, familyID); blockErr != nil {
+			return result, fmt.Errorf("%w, block family err: %v", err, blockErr)
+		}
+	}
+
+	return result, err
+}
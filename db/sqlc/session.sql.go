@@ -12,6 +12,66 @@ import (
 	"github.com/google/uuid"
 )
 
+const blockSession = `-- name: BlockSession :one
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1 AND username = $2
+RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at, parent_id, family_id, rotated_at, family_expired_at
+`
+
+type BlockSessionParams struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+}
+
+func (q *Queries) BlockSession(ctx context.Context, arg BlockSessionParams) (Session, error) {
+	row := q.queryRow(ctx, q.blockSessionStmt, blockSession, arg.ID, arg.Username)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+		&i.ParentID,
+		&i.FamilyID,
+		&i.RotatedAt,
+		&i.FamilyExpiredAt,
+	)
+	return i, err
+}
+
+const blockSessionFamily = `-- name: BlockSessionFamily :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1 AND is_blocked = false
+`
+
+func (q *Queries) BlockSessionFamily(ctx context.Context, familyID uuid.UUID) (int64, error) {
+	result, err := q.exec(ctx, q.blockSessionFamilyStmt, blockSessionFamily, familyID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const blockUserSessions = `-- name: BlockUserSessions :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND is_blocked = false
+`
+
+func (q *Queries) BlockUserSessions(ctx context.Context, username string) (int64, error) {
+	result, err := q.exec(ctx, q.blockUserSessionsStmt, blockUserSessions, username)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO sessions (
     id,
@@ -20,20 +80,26 @@ INSERT INTO sessions (
     user_agent,
     client_ip,
     is_blocked,
+    parent_id,
+    family_id,
+    family_expired_at,
     expired_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at, parent_id, family_id, rotated_at, family_expired_at
 `
 
 type CreateSessionParams struct {
-	ID           uuid.UUID `json:"id"`
-	Username     string    `json:"username"`
-	RefreshToken string    `json:"refresh_token"`
-	UserAgent    string    `json:"user_agent"`
-	ClientIp     string    `json:"client_ip"`
-	IsBlocked    bool      `json:"is_blocked"`
-	ExpiredAt    time.Time `json:"expired_at"`
+	ID              uuid.UUID     `json:"id"`
+	Username        string        `json:"username"`
+	RefreshToken    string        `json:"refresh_token"`
+	UserAgent       string        `json:"user_agent"`
+	ClientIp        string        `json:"client_ip"`
+	IsBlocked       bool          `json:"is_blocked"`
+	ParentID        uuid.NullUUID `json:"parent_id"`
+	FamilyID        uuid.UUID     `json:"family_id"`
+	FamilyExpiredAt time.Time     `json:"family_expired_at"`
+	ExpiredAt       time.Time     `json:"expired_at"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
@@ -44,6 +110,9 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		arg.UserAgent,
 		arg.ClientIp,
 		arg.IsBlocked,
+		arg.ParentID,
+		arg.FamilyID,
+		arg.FamilyExpiredAt,
 		arg.ExpiredAt,
 	)
 	var i Session
@@ -56,12 +125,16 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		&i.IsBlocked,
 		&i.ExpiredAt,
 		&i.CreatedAt,
+		&i.ParentID,
+		&i.FamilyID,
+		&i.RotatedAt,
+		&i.FamilyExpiredAt,
 	)
 	return i, err
 }
 
 const getSession = `-- name: GetSession :one
-SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at FROM sessions
+SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at, parent_id, family_id, rotated_at, family_expired_at FROM sessions
 WHERE id = $1 LIMIT 1
 `
 
@@ -77,6 +150,108 @@ func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 		&i.IsBlocked,
 		&i.ExpiredAt,
 		&i.CreatedAt,
+		&i.ParentID,
+		&i.FamilyID,
+		&i.RotatedAt,
+		&i.FamilyExpiredAt,
+	)
+	return i, err
+}
+
+const getSessionForUpdate = `-- name: GetSessionForUpdate :one
+SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at, parent_id, family_id, rotated_at, family_expired_at FROM sessions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetSessionForUpdate(ctx context.Context, id uuid.UUID) (Session, error) {
+	row := q.queryRow(ctx, q.getSessionForUpdateStmt, getSessionForUpdate, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+		&i.ParentID,
+		&i.FamilyID,
+		&i.RotatedAt,
+		&i.FamilyExpiredAt,
+	)
+	return i, err
+}
+
+const listActiveSessions = `-- name: ListActiveSessions :many
+SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at, parent_id, family_id, rotated_at, family_expired_at FROM sessions
+WHERE username = $1
+  AND is_blocked = false
+  AND rotated_at IS NULL
+  AND expired_at > now()
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListActiveSessions(ctx context.Context, username string) ([]Session, error) {
+	rows, err := q.query(ctx, q.listActiveSessionsStmt, listActiveSessions, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.RefreshToken,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.ExpiredAt,
+			&i.CreatedAt,
+			&i.ParentID,
+			&i.FamilyID,
+			&i.RotatedAt,
+			&i.FamilyExpiredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rotateSession = `-- name: RotateSession :one
+UPDATE sessions
+SET rotated_at = now()
+WHERE id = $1
+RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at, parent_id, family_id, rotated_at, family_expired_at
+`
+
+func (q *Queries) RotateSession(ctx context.Context, id uuid.UUID) (Session, error) {
+	row := q.queryRow(ctx, q.rotateSessionStmt, rotateSession, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+		&i.ParentID,
+		&i.FamilyID,
+		&i.RotatedAt,
+		&i.FamilyExpiredAt,
 	)
 	return i, err
 }
@@ -0,0 +1,186 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Cell6969/go_bank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSession(t *testing.T, user User) Session {
+	ctx := context.Background()
+	id := uuid.New()
+	arg := CreateSessionParams{
+		ID:              id,
+		Username:        user.Username,
+		RefreshToken:    util.RandomString(32),
+		UserAgent:       util.RandomString(10),
+		ClientIp:        "127.0.0.1",
+		IsBlocked:       false,
+		FamilyID:        id,
+		FamilyExpiredAt: time.Now().Add(time.Hour),
+		ExpiredAt:       time.Now().Add(time.Hour),
+	}
+
+	session, err := testQueries.CreateSession(ctx, arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, session)
+
+	require.Equal(t, arg.ID, session.ID)
+	require.Equal(t, arg.Username, session.Username)
+	require.False(t, session.IsBlocked)
+	return session
+}
+
+func TestListActiveSessions(t *testing.T) {
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	session1 := createRandomSession(t, user)
+	session2 := createRandomSession(t, user)
+
+	_, err := testQueries.BlockSession(ctx, BlockSessionParams{ID: session2.ID, Username: user.Username})
+	require.NoError(t, err)
+
+	sessions, err := testQueries.ListActiveSessions(ctx, user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, session1.ID, sessions[0].ID)
+}
+
+func TestBlockSession(t *testing.T) {
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	otherUser := createRandomUser(t)
+	session := createRandomSession(t, user)
+
+	// a session can only be revoked by its owner
+	_, err := testQueries.BlockSession(ctx, BlockSessionParams{ID: session.ID, Username: otherUser.Username})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	blocked, err := testQueries.BlockSession(ctx, BlockSessionParams{ID: session.ID, Username: user.Username})
+	require.NoError(t, err)
+	require.Equal(t, session.ID, blocked.ID)
+	require.True(t, blocked.IsBlocked)
+}
+
+func TestBlockUserSessions(t *testing.T) {
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	for i := 0; i < 3; i++ {
+		createRandomSession(t, user)
+	}
+
+	revoked, err := testQueries.BlockUserSessions(ctx, user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), revoked)
+
+	sessions, err := testQueries.ListActiveSessions(ctx, user.Username)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	parent := createRandomSession(t, user)
+
+	arg := RotateSessionTxParams{
+		ParentID: parent.ID,
+		Session: CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     user.Username,
+			RefreshToken: util.RandomString(32),
+			UserAgent:    parent.UserAgent,
+			ClientIp:     parent.ClientIp,
+			ExpiredAt:    time.Now().Add(time.Hour),
+		},
+	}
+
+	result, err := store.RotateSessionTx(ctx, arg)
+	require.NoError(t, err)
+	require.True(t, result.ParentSession.RotatedAt.Valid)
+	require.Equal(t, arg.Session.ID, result.Session.ID)
+	require.Equal(t, parent.ID, result.Session.ParentID.UUID)
+	require.Equal(t, parent.FamilyID, result.Session.FamilyID)
+	require.False(t, result.Session.RotatedAt.Valid)
+
+	// only the newest session of the family is active
+	sessions, err := testQueries.ListActiveSessions(ctx, user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, result.Session.ID, sessions[0].ID)
+
+	// presenting the rotated refresh token again blocks the whole family
+	arg.Session.ID = uuid.New()
+	_, err = store.RotateSessionTx(ctx, arg)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	child, err := testQueries.GetSession(ctx, result.Session.ID)
+	require.NoError(t, err)
+	require.True(t, child.IsBlocked)
+
+	_, err = testQueries.GetSession(ctx, arg.Session.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestRotateSessionTxFamilyExpiry(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	parent := createRandomSession(t, user)
+
+	// a renewal asking for more than the family has left is capped at the family expiry
+	arg := RotateSessionTxParams{
+		ParentID: parent.ID,
+		Session: CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     user.Username,
+			RefreshToken: util.RandomString(32),
+			ExpiredAt:    parent.FamilyExpiredAt.Add(time.Hour),
+		},
+	}
+
+	result, err := store.RotateSessionTx(ctx, arg)
+	require.NoError(t, err)
+	require.WithinDuration(t, parent.FamilyExpiredAt, result.Session.FamilyExpiredAt, time.Second)
+	require.WithinDuration(t, parent.FamilyExpiredAt, result.Session.ExpiredAt, time.Second)
+}
+
+func TestRotateSessionTxBlockedParent(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	parent := createRandomSession(t, user)
+
+	// the family was blocked after the caller read the session
+	_, err := testQueries.BlockSessionFamily(ctx, parent.FamilyID)
+	require.NoError(t, err)
+
+	_, err = store.RotateSessionTx(ctx, RotateSessionTxParams{
+		ParentID: parent.ID,
+		Session: CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     user.Username,
+			RefreshToken: util.RandomString(32),
+			ExpiredAt:    time.Now().Add(time.Hour),
+		},
+	})
+	require.ErrorIs(t, err, ErrSessionBlocked)
+
+	sessions, err := testQueries.ListActiveSessions(ctx, user.Username)
+	require.NoError(t, err)
+	for _, session := range sessions {
+		require.NotEqual(t, parent.FamilyID, session.FamilyID)
+	}
+}
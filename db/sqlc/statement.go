@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// AccountStatementTxParams contains input parameters of account statement, the range is [FromTime, ToTime)
+// Lines are paged like ListEntries, at most PageSize lines after the (CursorCreatedAt, CursorID) cursor are returned
+type AccountStatementTxParams struct {
+	AccountID       int64     `json:"account_id"`
+	FromTime        time.Time `json:"from_time"`
+	ToTime          time.Time `json:"to_time"`
+	CursorCreatedAt time.Time `json:"cursor_created_at"`
+	CursorID        int64     `json:"cursor_id"`
+	PageSize        int32     `json:"page_size"`
+}
+
+// StatementLine is a single entry of an account statement
+type StatementLine struct {
+	EntryID               int64     `json:"entry_id"`
+	TransferID            int64     `json:"transfer_id"`
+	CounterpartyAccountID int64     `json:"counterparty_account_id"`
+	Amount                int64     `json:"amount"`
+	RunningBalance        int64     `json:"running_balance"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+// AccountStatementTxResult contains result of AccountStatementTx
+type AccountStatementTxResult struct {
+	Account        Account         `json:"account"`
+	OpeningBalance int64           `json:"opening_balance"`
+	ClosingBalance int64           `json:"closing_balance"`
+	Lines          []StatementLine `json:"lines"`
+}
+
+// AccountStatementTx builds a page of the statement of an account within a date range.
+// The opening and closing balances are derived from the current balance minus every entry posted since FromTime and ToTime,
+// the running balance of the page starts from the entries posted after the cursor.
+// All reads share one snapshot so concurrent transfers cannot make the balances disagree.
+func (store *SQLStore) AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error) {
+	var result AccountStatementTxResult
+
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := store.execTxWithOptions(ctx, opts, func(q *Queries) error {
+		var err error
+
+		result.Account, err = q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		postedSince, err := q.GetEntriesSumSince(ctx, GetEntriesSumSinceParams{
+			AccountID: arg.AccountID,
+			CreatedAt: arg.FromTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		postedAfterEnd, err := q.GetEntriesSumSince(ctx, GetEntriesSumSinceParams{
+			AccountID: arg.AccountID,
+			CreatedAt: arg.ToTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		postedAfterCursor, err := q.GetStatementEntriesSumAfter(ctx, GetStatementEntriesSumAfterParams{
+			AccountID:       arg.AccountID,
+			FromTime:        arg.FromTime,
+			CursorCreatedAt: arg.CursorCreatedAt,
+			CursorID:        arg.CursorID,
+		})
+		if err != nil {
+			return err
+		}
+
+		entries, err := q.ListStatementEntries(ctx, ListStatementEntriesParams{
+			AccountID:       arg.AccountID,
+			FromTime:        arg.FromTime,
+			ToTime:          arg.ToTime,
+			CursorCreatedAt: arg.CursorCreatedAt,
+			CursorID:        arg.CursorID,
+			PageSize:        arg.PageSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.OpeningBalance = result.Account.Balance - postedSince
+		result.ClosingBalance = result.Account.Balance - postedAfterEnd
+		result.Lines = make([]StatementLine, 0, len(entries))
+
+		balance := result.Account.Balance - postedAfterCursor
+		for _, entry := range entries {
+			balance += entry.Amount
+			result.Lines = append(result.Lines, StatementLine{
+				EntryID:               entry.ID,
+				TransferID:            entry.TransferID.Int64,
+				CounterpartyAccountID: entry.CounterpartyAccountID,
+				Amount:                entry.Amount,
+				RunningBalance:        balance,
+				CreatedAt:             entry.CreatedAt,
+			})
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStatementTx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	amount := int64(10)
+	account1 := createFundedAccount(t, 3*amount)
+	account2 := createRandomAccount(t)
+
+	fromTime := time.Now().UTC().Add(-time.Minute)
+
+	transfers := make([]TransferTxResult, 3)
+	for i := range transfers {
+		arg := TransferTxParams{
+			FromAccountId: account1.ID,
+			ToAccountId:   account2.ID,
+			Amount:        amount,
+		}
+
+		result, err := store.TransferTx(ctx, arg)
+		require.NoError(t, err)
+		transfers[i] = result
+	}
+
+	toTime := time.Now().UTC().Add(time.Minute)
+
+	statement, err := store.AccountStatementTx(ctx, AccountStatementTxParams{
+		AccountID: account1.ID,
+		FromTime:  fromTime,
+		ToTime:    toTime,
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account1.ID, statement.Account.ID)
+	require.Equal(t, account1.Balance, statement.OpeningBalance)
+	require.Equal(t, account1.Balance-3*amount, statement.ClosingBalance)
+	require.Len(t, statement.Lines, len(transfers))
+
+	balance := statement.OpeningBalance
+	for i, line := range statement.Lines {
+		balance -= amount
+		require.Equal(t, transfers[i].FromEntry.ID, line.EntryID)
+		require.Equal(t, transfers[i].Transfer.ID, line.TransferID)
+		require.Equal(t, account2.ID, line.CounterpartyAccountID)
+		require.Equal(t, -amount, line.Amount)
+		require.Equal(t, balance, line.RunningBalance)
+	}
+
+	// the next page carries on the running balance from the last line of the previous one
+	arg := AccountStatementTxParams{
+		AccountID: account1.ID,
+		FromTime:  fromTime,
+		ToTime:    toTime,
+		PageSize:  2,
+	}
+	page1, err := store.AccountStatementTx(ctx, arg)
+	require.NoError(t, err)
+	require.Len(t, page1.Lines, 2)
+	require.Equal(t, statement.Lines[:2], page1.Lines)
+
+	last := page1.Lines[1]
+	arg.CursorCreatedAt, arg.CursorID = last.CreatedAt, last.EntryID
+	page2, err := store.AccountStatementTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, statement.Lines[2:], page2.Lines)
+	require.Equal(t, statement.OpeningBalance, page2.OpeningBalance)
+	require.Equal(t, statement.ClosingBalance, page2.ClosingBalance)
+
+	// a period before the transfers has no lines and ends where the next one starts
+	statement, err = store.AccountStatementTx(ctx, AccountStatementTxParams{
+		AccountID: account1.ID,
+		FromTime:  fromTime.Add(-time.Hour),
+		ToTime:    fromTime,
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, statement.Lines)
+	require.Equal(t, account1.Balance, statement.OpeningBalance)
+	require.Equal(t, account1.Balance, statement.ClosingBalance)
+}
@@ -3,13 +3,45 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+
+	"github.com/Cell6969/go_bank/util"
+)
+
+var (
+	// ErrInsufficientFunds is returned when a debit would take an account below its overdraft limit
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrIdempotencyKeyConflict is returned when an idempotency key is reused for a different transfer
+	ErrIdempotencyKeyConflict = errors.New("idempotency key already used for a different transfer")
+	// ErrConvertedAmountTooSmall is returned when the amount rounds down to nothing in the destination currency
+	ErrConvertedAmountTooSmall = errors.New("converted amount must be greater than 0")
 )
 
 // Store provides all function to execute db queries and transaction
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	ChangeAccountStatusTx(ctx context.Context, arg ChangeAccountStatusTxParams) (ChangeAccountStatusTxResult, error)
+	RecordScheduledTransferRunTx(ctx context.Context, arg RecordScheduledTransferRunTxParams) (RecordScheduledTransferRunTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	PostJournalTx(ctx context.Context, arg PostJournalTxParams) (PostJournalTxResult, error)
+	DepositTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	WithdrawTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	ReconcileAccountTx(ctx context.Context, arg ReconcileAccountTxParams) (ReconcileAccountTxResult, error)
+	AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error)
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error)
+	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error)
+	EnableMFATx(ctx context.Context, arg EnableMFATxParams) (MfaEnrollment, error)
+	DisableMFATx(ctx context.Context, arg DisableMFATxParams) error
+	VerifyMFATx(ctx context.Context, arg VerifyMFATxParams) (VerifyMFATxResult, error)
+	CheckAuthLockout(ctx context.Context, username string) error
+	ConfirmTransferTx(ctx context.Context, arg ConfirmTransferTxParams) (ConfirmTransferTxResult, error)
+	ReplayTransfer(ctx context.Context, arg TransferTxParams) (TransferTxResult, bool, error)
+	PublishOutboxEventsTx(ctx context.Context, limit int32, publish func(event OutboxEvent) error) (int, error)
 }
 
 // SQLStore provides all function to execute db queries and transaction
@@ -28,7 +60,12 @@ func NewStore(db *sql.DB) Store {
 
 // execTx executes a function within a database transaction
 func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.db.BeginTx(ctx, nil)
+	return store.execTxWithOptions(ctx, nil, fn)
+}
+
+// execTxWithOptions executes a function within a database transaction started with the given options
+func (store *SQLStore) execTxWithOptions(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, opts)
 	if err != nil {
 		return err
 	}
@@ -48,99 +85,238 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 }
 
 // TransferTxParams contains input parameters of transfer transaction
+// Amount is in the source account currency and ExchangeRate converts it into the destination currency, empty means 1
+// IdempotencyKey is optional, a retried transfer with the same key and owner returns the original result
 type TransferTxParams struct {
-	FromAccountId int64 `json:"from_account_id"`
-	ToAccountId   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountId  int64  `json:"from_account_id"`
+	ToAccountId    int64  `json:"to_account_id"`
+	Amount         int64  `json:"amount"`
+	ExchangeRate   string `json:"exchange_rate"`
+	Owner          string `json:"owner"`
+	IdempotencyKey string `json:"idempotency_key"`
 }
 
 // TransferTxResult contains result of TransferTx
+// Allowance is what the source account may still send today once the transfer is made
 type TransferTxResult struct {
-	Transfer    Transfer `json:"transfer"`
-	FromAccount Account  `json:"from_account"`
-	ToAccount   Account  `json:"to_account"`
-	FromEntry   Entry    `json:"from_entry"`
-	ToEntry     Entry    `json:"to_entry"`
+	Transfer    Transfer          `json:"transfer"`
+	FromAccount Account           `json:"from_account"`
+	ToAccount   Account           `json:"to_account"`
+	FromEntry   Entry             `json:"from_entry"`
+	ToEntry     Entry             `json:"to_entry"`
+	Allowance   TransferAllowance `json:"allowance"`
 }
 
 var txKey = struct{}{}
 
 // TransfersTx performs a money transfer from one account into another account
-// it creates a transfer record, add account entries, and update accounts balance within a single database transaction
+// it checks the transfer limits of the source account, creates a transfer record, posts the transfer journal entry which adds the account entries and updates
+// the accounts balance, and writes the transfer.completed event within a single database transaction
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = transfer(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
 
-		// create transfer
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountId,
-			ToAccountID:   arg.ToAccountId,
-			Amount:        arg.Amount,
-		})
+// transfer makes the transfer of TransferTx, it must run inside a transaction
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
 
-		if err != nil {
-			return err
+	if arg.IdempotencyKey != "" {
+		replayed, err := replayTransfer(ctx, q, arg, &result)
+		if err != nil || replayed {
+			return result, err
 		}
+	}
 
-		// create FromAccount entry
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountId,
-			Amount:    -arg.Amount,
-		})
+	var err error
+	result.Allowance, err = checkTransferLimit(ctx, q, arg.FromAccountId, arg.Amount)
+	if err != nil {
+		return result, err
+	}
 
-		if err != nil {
-			return err
-		}
+	// convert the amount into the destination currency
+	exchangeRate := arg.ExchangeRate
+	if exchangeRate == "" {
+		exchangeRate = "1"
+	}
+
+	toAmount, err := util.ConvertAmount(arg.Amount, exchangeRate)
+	if err != nil {
+		return result, err
+	}
+	if toAmount <= 0 {
+		return result, ErrConvertedAmountTooSmall
+	}
 
-		// create ToAccount entry
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountId,
-			Amount:    arg.Amount,
-		})
+	// create transfer
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountId,
+		ToAccountID:   arg.ToAccountId,
+		Amount:        arg.Amount,
+		ExchangeRate:  exchangeRate,
+		ToAmount:      toAmount,
+	})
 
-		if err != nil {
-			return err
-		}
+	if err != nil {
+		return result, err
+	}
 
-		if arg.FromAccountId < arg.ToAccountId {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountId, -arg.Amount, arg.ToAccountId, arg.Amount)
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountId, arg.Amount, arg.FromAccountId, -arg.Amount)
-		}
+	postings, err := transferPostings(ctx, q, arg, exchangeRate, toAmount)
+	if err != nil {
+		return result, err
+	}
 
-		return nil
+	journal, err := postJournal(ctx, q, PostJournalTxParams{
+		Type:       JournalTransfer,
+		TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		Postings:   postings,
 	})
+	if err != nil {
+		return result, err
+	}
 
-	return result, err
+	// the first two postings are the legs of the customer accounts
+	result.FromEntry, result.ToEntry = journal.Entries[0], journal.Entries[1]
+	result.FromAccount, result.ToAccount = journal.Accounts[0], journal.Accounts[1]
+
+	err = createTransferCompletedEvent(ctx, q, result)
+	if err != nil {
+		return result, err
+	}
+
+	if arg.IdempotencyKey != "" {
+		return result, saveIdempotencyKey(ctx, q, arg, result)
+	}
+
+	return result, nil
 }
 
-func addMoney(
-	ctx context.Context,
-	q *Queries,
-	accountID1 int64,
-	amount1 int64,
-	accountID2 int64,
-	amount2 int64,
-) (account1 Account, account2 Account, err error) {
-	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID1,
-		Amount: amount1,
+// replayTransfer loads the result of an earlier transfer made with the same idempotency key.
+// The key is locked until the transaction ends, so concurrent retries wait for the first one to finish.
+func replayTransfer(ctx context.Context, q *Queries, arg TransferTxParams, result *TransferTxResult) (bool, error) {
+	err := q.LockIdempotencyKey(ctx, LockIdempotencyKeyParams{
+		Owner: arg.Owner,
+		Key:   arg.IdempotencyKey,
 	})
+	if err != nil {
+		return false, err
+	}
+
+	return loadIdempotentTransfer(ctx, q, arg, result)
+}
+
+// ReplayTransfer returns the result of an earlier transfer made with the idempotency key of arg, if there is one.
+// Callers that would not make the transfer right away, like a step-up challenge, look for the result first
+func (store *SQLStore) ReplayTransfer(ctx context.Context, arg TransferTxParams) (TransferTxResult, bool, error) {
+	var result TransferTxResult
+	replayed, err := loadIdempotentTransfer(ctx, store.Queries, arg, &result)
+	return result, replayed, err
+}
+
+// loadIdempotentTransfer reads the stored result of the idempotency key, a key used for a different transfer
+// returns ErrIdempotencyKeyConflict
+func loadIdempotentTransfer(ctx context.Context, q *Queries, arg TransferTxParams, result *TransferTxResult) (bool, error) {
+	idempotencyKey, err := q.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Owner: arg.Owner,
+		Key:   arg.IdempotencyKey,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if err := json.Unmarshal(idempotencyKey.Result, result); err != nil {
+		return false, err
+	}
+
+	transfer := result.Transfer
+	if transfer.FromAccountID != arg.FromAccountId || transfer.ToAccountID != arg.ToAccountId || transfer.Amount != arg.Amount {
+		return false, ErrIdempotencyKeyConflict
+	}
+
+	return true, nil
+}
 
+// saveIdempotencyKey stores the transfer result so it can be returned to retried requests
+func saveIdempotencyKey(ctx context.Context, q *Queries, arg TransferTxParams, result TransferTxResult) error {
+	data, err := json.Marshal(result)
 	if err != nil {
-		return
+		return err
 	}
 
-	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID2,
-		Amount: amount2,
+	_, err = q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+		Owner:      arg.Owner,
+		Key:        arg.IdempotencyKey,
+		TransferID: result.Transfer.ID,
+		Result:     data,
 	})
+	return err
+}
+
+// transferPostings returns the postings of a transfer, the legs of both customer accounts come first.
+// When the destination amount differs from the debited amount the difference goes through the FX accounts,
+// so the postings of each currency still sum to zero
+func transferPostings(ctx context.Context, q *Queries, arg TransferTxParams, exchangeRate string, toAmount int64) ([]JournalPosting, error) {
+	fromLedger, err := q.GetLedgerAccountByAccountID(ctx, sql.NullInt64{Int64: arg.FromAccountId, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger account of account %d: %w", arg.FromAccountId, err)
+	}
+
+	toLedger, err := q.GetLedgerAccountByAccountID(ctx, sql.NullInt64{Int64: arg.ToAccountId, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger account of account %d: %w", arg.ToAccountId, err)
+	}
+
+	postings := []JournalPosting{
+		{
+			LedgerAccountID:   fromLedger.ID,
+			Amount:            -arg.Amount,
+			ExchangeRate:      exchangeRate,
+			SourceAmount:      arg.Amount,
+			DestinationAmount: toAmount,
+		},
+		{
+			LedgerAccountID:   toLedger.ID,
+			Amount:            toAmount,
+			ExchangeRate:      exchangeRate,
+			SourceAmount:      arg.Amount,
+			DestinationAmount: toAmount,
+		},
+	}
+
+	if fromLedger.Currency == toLedger.Currency && arg.Amount == toAmount {
+		return postings, nil
+	}
 
+	fromFX, err := q.GetLedgerAccountByCode(ctx, SystemLedgerCode(LedgerFX, fromLedger.Currency))
 	if err != nil {
-		return
+		return nil, fmt.Errorf("failed to get fx ledger account: %w", err)
 	}
 
-	return
+	toFX, err := q.GetLedgerAccountByCode(ctx, SystemLedgerCode(LedgerFX, toLedger.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fx ledger account: %w", err)
+	}
+
+	return append(postings,
+		JournalPosting{LedgerAccountID: fromFX.ID, Amount: arg.Amount},
+		JournalPosting{LedgerAccountID: toFX.ID, Amount: -toAmount},
+	), nil
+}
+
+// checkSufficientFunds makes sure the balance has not gone past the account overdraft limit
+func checkSufficientFunds(account Account) error {
+	if account.Balance < -account.OverdraftLimit {
+		return ErrInsufficientFunds
+	}
+	return nil
 }
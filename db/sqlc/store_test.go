@@ -2,9 +2,15 @@ package db
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"testing"
 
+	"github.com/Cell6969/go_bank/util"
+	"github.com/google/uuid"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,14 +21,14 @@ func TestTransferTx(t *testing.T) {
 	testQueries.ResetEntryTable(context.Background())
 	testQueries.ResetAccountTable(context.Background())
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
-	fmt.Println(">>> before:", account1.Balance, account2.Balance)
-
 	// run n concurrency
 	n := 5
 	amount := int64(10)
 
+	account1 := createFundedAccount(t, int64(n)*amount)
+	account2 := createRandomAccount(t)
+	fmt.Println(">>> before:", account1.Balance, account2.Balance)
+
 	errs := make(chan error)
 	results := make(chan TransferTxResult)
 
@@ -128,14 +134,14 @@ func TestTransferTxDeadlock(t *testing.T) {
 	store := NewStore(testDb)
 	testQueries.ResetAccountTable(context.Background())
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
-	fmt.Println(">>> before:", account1.Balance, account2.Balance)
-
 	// run n concurrency
 	n := 10
 	amount := int64(10)
 
+	account1 := createFundedAccount(t, int64(n)*amount)
+	account2 := createFundedAccount(t, int64(n)*amount)
+	fmt.Println(">>> before:", account1.Balance, account2.Balance)
+
 	errs := make(chan error)
 
 	for i := 0; i < n; i++ {
@@ -178,3 +184,285 @@ func TestTransferTxDeadlock(t *testing.T) {
 
 	fmt.Println(">>> after:", updateAccount1.Balance, updateAccount2.Balance)
 }
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account1 := createRandomAccount(t)
+	account1, err := testQueries.UpdateAccount(ctx, UpdateAccountParams{ID: account1.ID, Balance: 0})
+	require.NoError(t, err)
+	account2 := createRandomAccount(t)
+
+	arg := TransferTxParams{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount:        10,
+	}
+
+	// no overdraft allowed by default
+	_, err = store.TransferTx(ctx, arg)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// the failed transfer must be rolled back
+	updateAccount1, err := testQueries.GetAccount(ctx, account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), updateAccount1.Balance)
+
+	updateAccount2, err := testQueries.GetAccount(ctx, account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updateAccount2.Balance)
+
+	// allow the account to go below zero up to the amount of a single transfer
+	_, err = testQueries.UpdateAccountOverdraftLimit(ctx, UpdateAccountOverdraftLimitParams{
+		ID:             account1.ID,
+		OverdraftLimit: arg.Amount,
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, -arg.Amount, result.FromAccount.Balance)
+
+	_, err = store.TransferTx(ctx, arg)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+// createFundedAccount creates a random account holding at least the given balance
+func createFundedAccount(t *testing.T, balance int64) Account {
+	account := createRandomAccount(t)
+
+	account, err := testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account.ID,
+		Amount: balance + util.GenerateRandomMoney(),
+	})
+	require.NoError(t, err)
+
+	return account
+}
+
+func TestTransferTxIdempotency(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	amount := int64(10)
+	account1 := createFundedAccount(t, 2*amount)
+	account2 := createRandomAccount(t)
+
+	arg := TransferTxParams{
+		FromAccountId:  account1.ID,
+		ToAccountId:    account2.ID,
+		Amount:         amount,
+		Owner:          account1.Owner,
+		IdempotencyKey: util.RandomString(16),
+	}
+
+	result1, err := store.TransferTx(ctx, arg)
+	require.NoError(t, err)
+
+	// a retry returns the original transfer without moving money again
+	result2, err := store.TransferTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, result1.Transfer.ID, result2.Transfer.ID)
+	require.Equal(t, result1.FromEntry.ID, result2.FromEntry.ID)
+	require.Equal(t, result1.ToEntry.ID, result2.ToEntry.ID)
+	require.Equal(t, result1.FromAccount.Balance, result2.FromAccount.Balance)
+
+	updateAccount1, err := testQueries.GetAccount(ctx, account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-amount, updateAccount1.Balance)
+
+	// the same key with a different payload is rejected
+	arg.Amount = 2 * amount
+	_, err = store.TransferTx(ctx, arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyConflict)
+}
+
+func TestTransferTxExchangeRate(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	amount := int64(100)
+	account1 := createFundedAccount(t, amount)
+	account2 := createRandomAccount(t)
+
+	arg := TransferTxParams{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount:        amount,
+		ExchangeRate:  "0.925",
+	}
+
+	result, err := store.TransferTx(ctx, arg)
+	require.NoError(t, err)
+
+	toAmount := int64(93)
+	require.Equal(t, arg.ExchangeRate, result.Transfer.ExchangeRate)
+	require.Equal(t, amount, result.Transfer.Amount)
+	require.Equal(t, toAmount, result.Transfer.ToAmount)
+
+	require.Equal(t, -amount, result.FromEntry.Amount)
+	require.Equal(t, toAmount, result.ToEntry.Amount)
+	for _, entry := range []Entry{result.FromEntry, result.ToEntry} {
+		require.Equal(t, arg.ExchangeRate, entry.ExchangeRate)
+		require.Equal(t, amount, entry.SourceAmount)
+		require.Equal(t, toAmount, entry.DestinationAmount)
+	}
+
+	require.Equal(t, account1.Balance-amount, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+toAmount, result.ToAccount.Balance)
+
+	// an amount that rounds down to nothing would only debit the sender
+	arg.Amount = 1
+	arg.ExchangeRate = "0.4"
+	_, err = store.TransferTx(ctx, arg)
+	require.ErrorIs(t, err, ErrConvertedAmountTooSmall)
+
+	updateAccount1, err := testQueries.GetAccount(ctx, account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.FromAccount.Balance, updateAccount1.Balance)
+}
+
+func TestTransferTxOutboxEvent(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	amount := int64(10)
+	account1 := createFundedAccount(t, amount)
+	account2 := createRandomAccount(t)
+
+	arg := TransferTxParams{
+		FromAccountId:  account1.ID,
+		ToAccountId:    account2.ID,
+		Amount:         amount,
+		Owner:          account1.Owner,
+		IdempotencyKey: util.RandomString(16),
+	}
+
+	result, err := store.TransferTx(ctx, arg)
+	require.NoError(t, err)
+
+	// a replayed transfer doesn't write the event again
+	_, err = store.TransferTx(ctx, arg)
+	require.NoError(t, err)
+
+	events, err := testQueries.ListOutboxEventsByAggregate(ctx, ListOutboxEventsByAggregateParams{
+		AggregateType: AggregateTransfer,
+		AggregateID:   strconv.FormatInt(result.Transfer.ID, 10),
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, EventTransferCompleted, events[0].EventType)
+	require.False(t, events[0].PublishedAt.Valid)
+
+	var payload TransferCompletedPayload
+	err = json.Unmarshal(events[0].Payload, &payload)
+	require.NoError(t, err)
+	require.Equal(t, result.Transfer.ID, payload.TransferID)
+	require.Equal(t, amount, payload.Amount)
+	require.Equal(t, result.FromEntry.ID, payload.FromEntryID)
+	require.Equal(t, result.ToEntry.ID, payload.ToEntryID)
+}
+
+func TestPublishOutboxEventsTx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account1 := createFundedAccount(t, 10)
+	account2 := createRandomAccount(t)
+
+	result, err := store.TransferTx(ctx, TransferTxParams{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	aggregateID := strconv.FormatInt(result.Transfer.ID, 10)
+
+	// drain the outbox until the event of the transfer has been published
+	var publishedIDs []uuid.UUID
+	for {
+		n, err := store.PublishOutboxEventsTx(ctx, 100, func(event OutboxEvent) error {
+			if event.AggregateID == aggregateID {
+				publishedIDs = append(publishedIDs, event.ID)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+	}
+	require.Len(t, publishedIDs, 1)
+
+	events, err := testQueries.ListOutboxEventsByAggregate(ctx, ListOutboxEventsByAggregateParams{
+		AggregateType: AggregateTransfer,
+		AggregateID:   aggregateID,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, publishedIDs[0], events[0].ID)
+	require.True(t, events[0].PublishedAt.Valid)
+}
+
+func TestPublishOutboxEventsTxFailure(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account1 := createFundedAccount(t, 20)
+	account2 := createRandomAccount(t)
+
+	var aggregateIDs []string
+	for i := 0; i < 2; i++ {
+		result, err := store.TransferTx(ctx, TransferTxParams{
+			FromAccountId: account1.ID,
+			ToAccountId:   account2.ID,
+			Amount:        10,
+		})
+		require.NoError(t, err)
+		aggregateIDs = append(aggregateIDs, strconv.FormatInt(result.Transfer.ID, 10))
+	}
+
+	// the event of the first transfer always fails, the one of the second must still be published
+	var publishedIDs []uuid.UUID
+	for {
+		n, err := store.PublishOutboxEventsTx(ctx, 100, func(event OutboxEvent) error {
+			switch event.AggregateID {
+			case aggregateIDs[0]:
+				return errors.New("broker unavailable")
+			case aggregateIDs[1]:
+				publishedIDs = append(publishedIDs, event.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			require.ErrorContains(t, err, "broker unavailable")
+		}
+		if n == 0 {
+			break
+		}
+	}
+	require.Len(t, publishedIDs, 1)
+
+	failed, err := testQueries.ListOutboxEventsByAggregate(ctx, ListOutboxEventsByAggregateParams{
+		AggregateType: AggregateTransfer,
+		AggregateID:   aggregateIDs[0],
+	})
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	require.False(t, failed[0].PublishedAt.Valid)
+	require.GreaterOrEqual(t, failed[0].Attempts, int32(1))
+	require.True(t, failed[0].NextAttemptAt.After(failed[0].CreatedAt))
+	require.Equal(t, "broker unavailable", failed[0].LastError.String)
+
+	published, err := testQueries.ListOutboxEventsByAggregate(ctx, ListOutboxEventsByAggregateParams{
+		AggregateType: AggregateTransfer,
+		AggregateID:   aggregateIDs[1],
+	})
+	require.NoError(t, err)
+	require.Len(t, published, 1)
+	require.Equal(t, publishedIDs[0], published[0].ID)
+	require.True(t, published[0].PublishedAt.Valid)
+	require.Zero(t, published[0].Attempts)
+}
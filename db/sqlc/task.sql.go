@@ -0,0 +1,308 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: task.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const claimTask = `-- name: ClaimTask :one
+UPDATE tasks
+SET status = 'running',
+    attempts = attempts + 1,
+    locked_until = $1::timestamp,
+    updated_at = now()
+WHERE id = (
+    SELECT t.id FROM tasks t
+    WHERE (t.status = 'pending' AND t.run_at <= now())
+       OR (t.status = 'running' AND t.locked_until < now())
+    ORDER BY t.priority DESC, t.run_at, t.id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, type, payload, queue, priority, status, attempts, max_attempts, last_error, run_at, locked_until, created_at, updated_at
+`
+
+// picks the next due task of the highest priority, a running task whose lock expired
+// belongs to a crashed worker and is picked up again
+func (q *Queries) ClaimTask(ctx context.Context, lockedUntil time.Time) (Task, error) {
+	row := q.queryRow(ctx, q.claimTaskStmt, claimTask, lockedUntil)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Queue,
+		&i.Priority,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.LastError,
+		&i.RunAt,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const completeTask = `-- name: CompleteTask :one
+UPDATE tasks
+SET status = 'completed',
+    locked_until = NULL,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, type, payload, queue, priority, status, attempts, max_attempts, last_error, run_at, locked_until, created_at, updated_at
+`
+
+func (q *Queries) CompleteTask(ctx context.Context, id int64) (Task, error) {
+	row := q.queryRow(ctx, q.completeTaskStmt, completeTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Queue,
+		&i.Priority,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.LastError,
+		&i.RunAt,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createTask = `-- name: CreateTask :one
+INSERT INTO tasks (
+    type,
+    payload,
+    queue,
+    priority,
+    max_attempts,
+    run_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, type, payload, queue, priority, status, attempts, max_attempts, last_error, run_at, locked_until, created_at, updated_at
+`
+
+type CreateTaskParams struct {
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Queue       string          `json:"queue"`
+	Priority    int32           `json:"priority"`
+	MaxAttempts int32           `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+}
+
+func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error) {
+	row := q.queryRow(ctx, q.createTaskStmt, createTask,
+		arg.Type,
+		arg.Payload,
+		arg.Queue,
+		arg.Priority,
+		arg.MaxAttempts,
+		arg.RunAt,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Queue,
+		&i.Priority,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.LastError,
+		&i.RunAt,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTask = `-- name: GetTask :one
+SELECT id, type, payload, queue, priority, status, attempts, max_attempts, last_error, run_at, locked_until, created_at, updated_at FROM tasks
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetTask(ctx context.Context, id int64) (Task, error) {
+	row := q.queryRow(ctx, q.getTaskStmt, getTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Queue,
+		&i.Priority,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.LastError,
+		&i.RunAt,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const killTask = `-- name: KillTask :one
+UPDATE tasks
+SET status = 'dead',
+    last_error = $1,
+    locked_until = NULL,
+    updated_at = now()
+WHERE id = $2
+RETURNING id, type, payload, queue, priority, status, attempts, max_attempts, last_error, run_at, locked_until, created_at, updated_at
+`
+
+type KillTaskParams struct {
+	LastError sql.NullString `json:"last_error"`
+	ID        int64          `json:"id"`
+}
+
+func (q *Queries) KillTask(ctx context.Context, arg KillTaskParams) (Task, error) {
+	row := q.queryRow(ctx, q.killTaskStmt, killTask, arg.LastError, arg.ID)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Queue,
+		&i.Priority,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.LastError,
+		&i.RunAt,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listDeadTasks = `-- name: ListDeadTasks :many
+SELECT id, type, payload, queue, priority, status, attempts, max_attempts, last_error, run_at, locked_until, created_at, updated_at FROM tasks
+WHERE status = 'dead'
+ORDER BY updated_at DESC
+LIMIT $1
+`
+
+func (q *Queries) ListDeadTasks(ctx context.Context, limit int32) ([]Task, error) {
+	rows, err := q.query(ctx, q.listDeadTasksStmt, listDeadTasks, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.Payload,
+			&i.Queue,
+			&i.Priority,
+			&i.Status,
+			&i.Attempts,
+			&i.MaxAttempts,
+			&i.LastError,
+			&i.RunAt,
+			&i.LockedUntil,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const requeueDeadTask = `-- name: RequeueDeadTask :one
+UPDATE tasks
+SET status = 'pending',
+    attempts = 0,
+    run_at = now(),
+    updated_at = now()
+WHERE id = $1 AND status = 'dead'
+RETURNING id, type, payload, queue, priority, status, attempts, max_attempts, last_error, run_at, locked_until, created_at, updated_at
+`
+
+func (q *Queries) RequeueDeadTask(ctx context.Context, id int64) (Task, error) {
+	row := q.queryRow(ctx, q.requeueDeadTaskStmt, requeueDeadTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Queue,
+		&i.Priority,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.LastError,
+		&i.RunAt,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const retryTask = `-- name: RetryTask :one
+UPDATE tasks
+SET status = 'pending',
+    run_at = $1,
+    last_error = $2,
+    locked_until = NULL,
+    updated_at = now()
+WHERE id = $3
+RETURNING id, type, payload, queue, priority, status, attempts, max_attempts, last_error, run_at, locked_until, created_at, updated_at
+`
+
+type RetryTaskParams struct {
+	RunAt     time.Time      `json:"run_at"`
+	LastError sql.NullString `json:"last_error"`
+	ID        int64          `json:"id"`
+}
+
+func (q *Queries) RetryTask(ctx context.Context, arg RetryTaskParams) (Task, error) {
+	row := q.queryRow(ctx, q.retryTaskStmt, retryTask, arg.RunAt, arg.LastError, arg.ID)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Queue,
+		&i.Priority,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.LastError,
+		&i.RunAt,
+		&i.LockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Cell6969/go_bank/util"
+	"github.com/google/uuid"
+)
+
+// constant for all step-up methods, users with multi-factor authentication confirm with a code, others with their password
+const (
+	StepUpPassword = "password"
+	StepUpMFA      = "mfa"
+)
+
+// constant for all transfer challenge statuses
+const (
+	TransferChallengePending   = "pending"
+	TransferChallengeCompleted = "completed"
+)
+
+// MaxTransferChallengeAttempts is how many wrong proofs a transfer challenge accepts before it is refused
+const MaxTransferChallengeAttempts = 3
+
+var (
+	ErrTransferChallengeInvalid = errors.New("transfer challenge is invalid or expired")
+	ErrStepUpFailed             = errors.New("step-up verification failed")
+)
+
+// ConfirmTransferTxParams contains input parameters of the confirm transfer transaction
+// TokenID is the id of the access token payload of the request, Password or Code depend on the method of the challenge
+type ConfirmTransferTxParams struct {
+	ChallengeID int64     `json:"challenge_id"`
+	TokenID     uuid.UUID `json:"token_id"`
+	Username    string    `json:"username"`
+	Password    string    `json:"-"`
+	Code        string    `json:"-"`
+}
+
+// ConfirmTransferTxResult contains result of ConfirmTransferTx
+type ConfirmTransferTxResult struct {
+	TransferTxResult
+	Challenge TransferChallenge `json:"challenge"`
+}
+
+// ConfirmTransferTx verifies the proof of the user and makes the transfer of the challenge in the same database transaction.
+// Only the access token that asked for the transfer can confirm it. A wrong proof counts as an attempt of the challenge
+// and as a failure of the user, the challenge is refused after MaxTransferChallengeAttempts or while the user is locked out
+func (store *SQLStore) ConfirmTransferTx(ctx context.Context, arg ConfirmTransferTxParams) (ConfirmTransferTxResult, error) {
+	var result ConfirmTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		challenge, err := q.GetTransferChallengeForUpdate(ctx, arg.ChallengeID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrTransferChallengeInvalid
+			}
+			return err
+		}
+
+		if challenge.TokenID != arg.TokenID || challenge.Username != arg.Username ||
+			challenge.Status != TransferChallengePending || challenge.Attempts >= MaxTransferChallengeAttempts ||
+			time.Now().After(challenge.ExpiredAt) {
+			return ErrTransferChallengeInvalid
+		}
+		result.Challenge = challenge
+
+		err = checkAuthLockout(ctx, q, challenge.Username)
+		if err != nil {
+			return err
+		}
+
+		ok, err := verifyStepUp(ctx, q, challenge, arg)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrStepUpFailed
+		}
+
+		err = q.ResetAuthFailures(ctx, challenge.Username)
+		if err != nil {
+			return err
+		}
+
+		result.TransferTxResult, err = transfer(ctx, q, TransferTxParams{
+			FromAccountId:  challenge.FromAccountID,
+			ToAccountId:    challenge.ToAccountID,
+			Amount:         challenge.Amount,
+			ExchangeRate:   challenge.ExchangeRate,
+			Owner:          challenge.Username,
+			IdempotencyKey: challenge.IdempotencyKey,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Challenge, err = q.CompleteTransferChallenge(ctx, CompleteTransferChallengeParams{
+			ID:         challenge.ID,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+
+	// the attempt is counted outside of the transaction, otherwise it would be rolled back with it
+	if errors.Is(err, ErrStepUpFailed) {
+		if _, attemptErr := store.AddTransferChallengeAttempt(ctx, result.Challenge.ID); attemptErr != nil {
+			return result, fmt.Errorf("%w, add attempt err: %v", err, attemptErr)
+		}
+		return result, store.recordAuthFailure(ctx, result.Challenge.Username, err)
+	}
+
+	return result, err
+}
+
+// verifyStepUp checks the proof the method of the challenge asks for
+func verifyStepUp(ctx context.Context, q *Queries, challenge TransferChallenge, arg ConfirmTransferTxParams) (bool, error) {
+	if challenge.Method == StepUpMFA {
+		return verifyMFACode(ctx, q, challenge.Username, arg.Code)
+	}
+
+	user, err := q.GetUser(ctx, challenge.Username)
+	if err != nil {
+		return false, err
+	}
+
+	return util.ValidatePassword(arg.Password, user.Password) == nil, nil
+}
@@ -0,0 +1,166 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: transfer_challenge.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const addTransferChallengeAttempt = `-- name: AddTransferChallengeAttempt :one
+UPDATE transfer_challenges
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING id, token_id, username, from_account_id, to_account_id, amount, exchange_rate, idempotency_key, method, status, attempts, transfer_id, expired_at, created_at
+`
+
+func (q *Queries) AddTransferChallengeAttempt(ctx context.Context, id int64) (TransferChallenge, error) {
+	row := q.queryRow(ctx, q.addTransferChallengeAttemptStmt, addTransferChallengeAttempt, id)
+	var i TransferChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.TokenID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.Method,
+		&i.Status,
+		&i.Attempts,
+		&i.TransferID,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const completeTransferChallenge = `-- name: CompleteTransferChallenge :one
+UPDATE transfer_challenges
+SET status = 'completed',
+    transfer_id = $1
+WHERE id = $2
+RETURNING id, token_id, username, from_account_id, to_account_id, amount, exchange_rate, idempotency_key, method, status, attempts, transfer_id, expired_at, created_at
+`
+
+type CompleteTransferChallengeParams struct {
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ID         int64         `json:"id"`
+}
+
+func (q *Queries) CompleteTransferChallenge(ctx context.Context, arg CompleteTransferChallengeParams) (TransferChallenge, error) {
+	row := q.queryRow(ctx, q.completeTransferChallengeStmt, completeTransferChallenge, arg.TransferID, arg.ID)
+	var i TransferChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.TokenID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.Method,
+		&i.Status,
+		&i.Attempts,
+		&i.TransferID,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createTransferChallenge = `-- name: CreateTransferChallenge :one
+INSERT INTO transfer_challenges (
+  token_id,
+  username,
+  from_account_id,
+  to_account_id,
+  amount,
+  exchange_rate,
+  idempotency_key,
+  method,
+  expired_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, token_id, username, from_account_id, to_account_id, amount, exchange_rate, idempotency_key, method, status, attempts, transfer_id, expired_at, created_at
+`
+
+type CreateTransferChallengeParams struct {
+	TokenID        uuid.UUID `json:"token_id"`
+	Username       string    `json:"username"`
+	FromAccountID  int64     `json:"from_account_id"`
+	ToAccountID    int64     `json:"to_account_id"`
+	Amount         int64     `json:"amount"`
+	ExchangeRate   string    `json:"exchange_rate"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	Method         string    `json:"method"`
+	ExpiredAt      time.Time `json:"expired_at"`
+}
+
+func (q *Queries) CreateTransferChallenge(ctx context.Context, arg CreateTransferChallengeParams) (TransferChallenge, error) {
+	row := q.queryRow(ctx, q.createTransferChallengeStmt, createTransferChallenge,
+		arg.TokenID,
+		arg.Username,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ExchangeRate,
+		arg.IdempotencyKey,
+		arg.Method,
+		arg.ExpiredAt,
+	)
+	var i TransferChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.TokenID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.Method,
+		&i.Status,
+		&i.Attempts,
+		&i.TransferID,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferChallengeForUpdate = `-- name: GetTransferChallengeForUpdate :one
+SELECT id, token_id, username, from_account_id, to_account_id, amount, exchange_rate, idempotency_key, method, status, attempts, transfer_id, expired_at, created_at FROM transfer_challenges
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferChallengeForUpdate(ctx context.Context, id int64) (TransferChallenge, error) {
+	row := q.queryRow(ctx, q.getTransferChallengeForUpdateStmt, getTransferChallengeForUpdate, id)
+	var i TransferChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.TokenID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.Method,
+		&i.Status,
+		&i.Attempts,
+		&i.TransferID,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,240 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Cell6969/go_bank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTransferChallenge(t *testing.T, from Account, to Account, method string) TransferChallenge {
+	challenge, err := testQueries.CreateTransferChallenge(context.Background(), CreateTransferChallengeParams{
+		TokenID:       uuid.New(),
+		Username:      from.Owner,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        10,
+		ExchangeRate:  "1",
+		Method:        method,
+		ExpiredAt:     time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferChallengePending, challenge.Status)
+
+	return challenge
+}
+
+func TestConfirmTransferTxPassword(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account1 := createFundedAccount(t, 10)
+	account2 := createRandomAccount(t)
+
+	password := util.RandomString(8)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateUser(ctx, UpdateUserParams{
+		Username: account1.Owner,
+		Password: sql.NullString{String: hashedPassword, Valid: true},
+	})
+	require.NoError(t, err)
+
+	challenge := createRandomTransferChallenge(t, account1, account2, StepUpPassword)
+	arg := ConfirmTransferTxParams{
+		ChallengeID: challenge.ID,
+		TokenID:     challenge.TokenID,
+		Username:    account1.Owner,
+		Password:    password,
+	}
+
+	// only the access token that asked for the transfer can confirm it
+	otherToken := arg
+	otherToken.TokenID = uuid.New()
+	_, err = store.ConfirmTransferTx(ctx, otherToken)
+	require.ErrorIs(t, err, ErrTransferChallengeInvalid)
+
+	wrongPassword := arg
+	wrongPassword.Password = util.RandomString(8)
+	_, err = store.ConfirmTransferTx(ctx, wrongPassword)
+	require.ErrorIs(t, err, ErrStepUpFailed)
+
+	result, err := store.ConfirmTransferTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, TransferChallengeCompleted, result.Challenge.Status)
+	require.Equal(t, int32(1), result.Challenge.Attempts)
+	require.Equal(t, sql.NullInt64{Int64: result.Transfer.ID, Valid: true}, result.Challenge.TransferID)
+	require.Equal(t, account1.ID, result.Transfer.FromAccountID)
+	require.Equal(t, int64(10), result.Transfer.Amount)
+	require.Equal(t, account1.Balance-10, result.FromAccount.Balance)
+
+	// a challenge makes a single transfer
+	_, err = store.ConfirmTransferTx(ctx, arg)
+	require.ErrorIs(t, err, ErrTransferChallengeInvalid)
+}
+
+func TestConfirmTransferTxMFA(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account1 := createFundedAccount(t, 10)
+	account2 := createRandomAccount(t)
+	_, recoveryCode := createEnabledMFA(t, store, account1.Owner)
+
+	challenge := createRandomTransferChallenge(t, account1, account2, StepUpMFA)
+	arg := ConfirmTransferTxParams{
+		ChallengeID: challenge.ID,
+		TokenID:     challenge.TokenID,
+		Username:    account1.Owner,
+		Code:        "wrong-code",
+	}
+
+	for i := 0; i < MaxTransferChallengeAttempts; i++ {
+		_, err := store.ConfirmTransferTx(ctx, arg)
+		require.ErrorIs(t, err, ErrStepUpFailed)
+	}
+
+	// too many wrong codes burn the challenge
+	arg.Code = recoveryCode
+	_, err := store.ConfirmTransferTx(ctx, arg)
+	require.ErrorIs(t, err, ErrTransferChallengeInvalid)
+
+	challenge = createRandomTransferChallenge(t, account1, account2, StepUpMFA)
+	result, err := store.ConfirmTransferTx(ctx, ConfirmTransferTxParams{
+		ChallengeID: challenge.ID,
+		TokenID:     challenge.TokenID,
+		Username:    account1.Owner,
+		Code:        recoveryCode,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferChallengeCompleted, result.Challenge.Status)
+}
+
+func TestConfirmTransferTxLockout(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account1 := createFundedAccount(t, 10)
+	account2 := createRandomAccount(t)
+
+	password := util.RandomString(8)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateUser(ctx, UpdateUserParams{
+		Username: account1.Owner,
+		Password: sql.NullString{String: hashedPassword, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// failures are counted for the user, a new challenge doesn't give new attempts
+	for i := 0; i < MaxAuthFailures; i++ {
+		challenge := createRandomTransferChallenge(t, account1, account2, StepUpPassword)
+		_, err := store.ConfirmTransferTx(ctx, ConfirmTransferTxParams{
+			ChallengeID: challenge.ID,
+			TokenID:     challenge.TokenID,
+			Username:    account1.Owner,
+			Password:    util.RandomString(8),
+		})
+		require.ErrorIs(t, err, ErrStepUpFailed)
+	}
+
+	err = store.CheckAuthLockout(ctx, account1.Owner)
+	require.ErrorIs(t, err, ErrAuthLocked)
+
+	challenge := createRandomTransferChallenge(t, account1, account2, StepUpPassword)
+	arg := ConfirmTransferTxParams{
+		ChallengeID: challenge.ID,
+		TokenID:     challenge.TokenID,
+		Username:    account1.Owner,
+		Password:    password,
+	}
+	_, err = store.ConfirmTransferTx(ctx, arg)
+	require.ErrorIs(t, err, ErrAuthLocked)
+
+	// the counter is shared with the multi-factor authentication challenges
+	_, recoveryCode := createEnabledMFA(t, store, account1.Owner)
+	tokenHash := util.HashSecretToken(createRandomMFAChallenge(t, account1.Owner))
+	_, err = store.VerifyMFATx(ctx, VerifyMFATxParams{TokenHash: tokenHash, Code: recoveryCode})
+	require.ErrorIs(t, err, ErrAuthLocked)
+
+	for i := 0; i < MaxAuthFailures; i++ {
+		_, err := store.AddAuthFailure(ctx, AddAuthFailureParams{
+			Username:    account1.Owner,
+			MaxAttempts: MaxAuthFailures,
+			LockedUntil: time.Now().Add(-time.Second),
+		})
+		require.NoError(t, err)
+	}
+
+	// once the lockout is over, a success resets the failures of the user
+	result, err := store.ConfirmTransferTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, TransferChallengeCompleted, result.Challenge.Status)
+	require.Zero(t, result.Challenge.Attempts)
+
+	failure, err := store.GetAuthFailure(ctx, account1.Owner)
+	require.NoError(t, err)
+	require.Zero(t, failure.FailedAttempts)
+	require.False(t, failure.LockedUntil.Valid)
+}
+
+func TestReplayTransfer(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account1 := createFundedAccount(t, 10)
+	account2 := createRandomAccount(t)
+	_, recoveryCode := createEnabledMFA(t, store, account1.Owner)
+
+	arg := TransferTxParams{
+		FromAccountId:  account1.ID,
+		ToAccountId:    account2.ID,
+		Amount:         10,
+		ExchangeRate:   "1",
+		Owner:          account1.Owner,
+		IdempotencyKey: util.RandomString(16),
+	}
+
+	// nothing is stored until the challenge is confirmed
+	_, replayed, err := store.ReplayTransfer(ctx, arg)
+	require.NoError(t, err)
+	require.False(t, replayed)
+
+	challenge, err := testQueries.CreateTransferChallenge(ctx, CreateTransferChallengeParams{
+		TokenID:        uuid.New(),
+		Username:       account1.Owner,
+		FromAccountID:  arg.FromAccountId,
+		ToAccountID:    arg.ToAccountId,
+		Amount:         arg.Amount,
+		ExchangeRate:   arg.ExchangeRate,
+		IdempotencyKey: arg.IdempotencyKey,
+		Method:         StepUpMFA,
+		ExpiredAt:      time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	confirmed, err := store.ConfirmTransferTx(ctx, ConfirmTransferTxParams{
+		ChallengeID: challenge.ID,
+		TokenID:     challenge.TokenID,
+		Username:    account1.Owner,
+		Code:        recoveryCode,
+	})
+	require.NoError(t, err)
+
+	// a retry of the confirmed transfer gets its result
+	result, replayed, err := store.ReplayTransfer(ctx, arg)
+	require.NoError(t, err)
+	require.True(t, replayed)
+	require.Equal(t, confirmed.Transfer.ID, result.Transfer.ID)
+	require.Equal(t, confirmed.FromEntry.ID, result.FromEntry.ID)
+
+	arg.Amount = 5
+	_, _, err = store.ReplayTransfer(ctx, arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyConflict)
+}
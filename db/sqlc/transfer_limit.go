@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrTransferLimitExceeded is returned when a transfer goes over one of the limits of its source account
+var ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+
+// TransferAllowance is what the source account may still send, MaxPerTransfer is the limit of a single transfer
+type TransferAllowance struct {
+	MaxPerTransfer       int64 `json:"max_per_transfer"`
+	DailyAmountRemaining int64 `json:"daily_amount_remaining"`
+	DailyCountRemaining  int32 `json:"daily_count_remaining"`
+}
+
+// NewTransferAllowance returns the allowance left by the transfers of the day
+func NewTransferAllowance(limit GetAccountTransferLimitRow, total GetDailyTransferTotalRow) TransferAllowance {
+	allowance := TransferAllowance{
+		MaxPerTransfer:       limit.MaxPerTransfer,
+		DailyAmountRemaining: limit.DailyAmount - total.Amount,
+		DailyCountRemaining:  limit.DailyCount - total.Count,
+	}
+
+	// a lowered limit can leave less than nothing
+	if allowance.DailyAmountRemaining < 0 {
+		allowance.DailyAmountRemaining = 0
+	}
+	if allowance.DailyCountRemaining < 0 {
+		allowance.DailyCountRemaining = 0
+	}
+
+	return allowance
+}
+
+// checkTransferLimit makes sure a transfer of amount stays within the limits of the account
+// and returns the allowance left once it is made, it must run inside a transaction.
+// The limit lock is held until the transaction ends, so concurrent transfers out of the account are counted one by one
+func checkTransferLimit(ctx context.Context, q *Queries, accountID int64, amount int64) (TransferAllowance, error) {
+	var allowance TransferAllowance
+
+	err := q.LockAccountTransferLimit(ctx, accountID)
+	if err != nil {
+		return allowance, err
+	}
+
+	limit, err := q.GetAccountTransferLimit(ctx, accountID)
+	if err != nil {
+		return allowance, fmt.Errorf("failed to get transfer limit of account %d: %w", accountID, err)
+	}
+
+	total, err := q.GetDailyTransferTotal(ctx, accountID)
+	if err != nil {
+		return allowance, err
+	}
+
+	allowance = NewTransferAllowance(limit, total)
+
+	if amount > allowance.MaxPerTransfer {
+		return allowance, fmt.Errorf("%w: amount is over the maximum of %d per transfer", ErrTransferLimitExceeded, allowance.MaxPerTransfer)
+	}
+
+	if amount > allowance.DailyAmountRemaining {
+		return allowance, fmt.Errorf("%w: only %d of the daily amount is left", ErrTransferLimitExceeded, allowance.DailyAmountRemaining)
+	}
+
+	if allowance.DailyCountRemaining < 1 {
+		return allowance, fmt.Errorf("%w: the daily count of %d transfers is reached", ErrTransferLimitExceeded, limit.DailyCount)
+	}
+
+	allowance.DailyAmountRemaining -= amount
+	allowance.DailyCountRemaining--
+	return allowance, nil
+}
@@ -0,0 +1,143 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: transfer_limit.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getAccountTransferLimit = `-- name: GetAccountTransferLimit :one
+SELECT
+  a.id AS account_id,
+  a.currency,
+  COALESCE(l.max_per_transfer, d.max_per_transfer)::bigint AS max_per_transfer,
+  COALESCE(l.daily_amount, d.daily_amount)::bigint AS daily_amount,
+  COALESCE(l.daily_count, d.daily_count)::int AS daily_count
+FROM accounts a
+JOIN transfer_limit_defaults d ON d.currency = a.currency
+LEFT JOIN account_transfer_limits l ON l.account_id = a.id
+WHERE a.id = $1 LIMIT 1
+`
+
+type GetAccountTransferLimitRow struct {
+	AccountID      int64  `json:"account_id"`
+	Currency       string `json:"currency"`
+	MaxPerTransfer int64  `json:"max_per_transfer"`
+	DailyAmount    int64  `json:"daily_amount"`
+	DailyCount     int32  `json:"daily_count"`
+}
+
+// the limits of the account, each one falls back to the default of the account currency
+func (q *Queries) GetAccountTransferLimit(ctx context.Context, id int64) (GetAccountTransferLimitRow, error) {
+	row := q.queryRow(ctx, q.getAccountTransferLimitStmt, getAccountTransferLimit, id)
+	var i GetAccountTransferLimitRow
+	err := row.Scan(
+		&i.AccountID,
+		&i.Currency,
+		&i.MaxPerTransfer,
+		&i.DailyAmount,
+		&i.DailyCount,
+	)
+	return i, err
+}
+
+const getDailyTransferTotal = `-- name: GetDailyTransferTotal :one
+SELECT
+  COUNT(*)::int AS count,
+  COALESCE(SUM(amount), 0)::bigint AS amount
+FROM transfers
+WHERE from_account_id = $1 AND created_at >= date_trunc('day', now())
+`
+
+type GetDailyTransferTotalRow struct {
+	Count  int32 `json:"count"`
+	Amount int64 `json:"amount"`
+}
+
+// the outgoing transfers of the account since the start of the day
+func (q *Queries) GetDailyTransferTotal(ctx context.Context, fromAccountID int64) (GetDailyTransferTotalRow, error) {
+	row := q.queryRow(ctx, q.getDailyTransferTotalStmt, getDailyTransferTotal, fromAccountID)
+	var i GetDailyTransferTotalRow
+	err := row.Scan(&i.Count, &i.Amount)
+	return i, err
+}
+
+const getTransferLimitDefault = `-- name: GetTransferLimitDefault :one
+SELECT currency, max_per_transfer, daily_amount, daily_count, step_up_amount FROM transfer_limit_defaults
+WHERE currency = $1 LIMIT 1
+`
+
+func (q *Queries) GetTransferLimitDefault(ctx context.Context, currency string) (TransferLimitDefault, error) {
+	row := q.queryRow(ctx, q.getTransferLimitDefaultStmt, getTransferLimitDefault, currency)
+	var i TransferLimitDefault
+	err := row.Scan(
+		&i.Currency,
+		&i.MaxPerTransfer,
+		&i.DailyAmount,
+		&i.DailyCount,
+		&i.StepUpAmount,
+	)
+	return i, err
+}
+
+const lockAccountTransferLimit = `-- name: LockAccountTransferLimit :exec
+SELECT pg_advisory_xact_lock(1, ($1::bigint % 2147483647)::int)
+`
+
+// serializes the limit checks of the transfers out of an account without locking its row,
+// so the balances can still be locked in the order of the account ids.
+// The first key is the namespace of the lock, 1 for transfer limits and 2 for idempotency keys
+func (q *Queries) LockAccountTransferLimit(ctx context.Context, accountID int64) error {
+	_, err := q.exec(ctx, q.lockAccountTransferLimitStmt, lockAccountTransferLimit, accountID)
+	return err
+}
+
+const upsertAccountTransferLimit = `-- name: UpsertAccountTransferLimit :one
+INSERT INTO account_transfer_limits (
+  account_id,
+  max_per_transfer,
+  daily_amount,
+  daily_count,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4, $5
+) ON CONFLICT (account_id) DO UPDATE
+SET max_per_transfer = EXCLUDED.max_per_transfer,
+    daily_amount = EXCLUDED.daily_amount,
+    daily_count = EXCLUDED.daily_count,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING account_id, max_per_transfer, daily_amount, daily_count, updated_by, updated_at
+`
+
+type UpsertAccountTransferLimitParams struct {
+	AccountID      int64         `json:"account_id"`
+	MaxPerTransfer sql.NullInt64 `json:"max_per_transfer"`
+	DailyAmount    sql.NullInt64 `json:"daily_amount"`
+	DailyCount     sql.NullInt32 `json:"daily_count"`
+	UpdatedBy      string        `json:"updated_by"`
+}
+
+func (q *Queries) UpsertAccountTransferLimit(ctx context.Context, arg UpsertAccountTransferLimitParams) (AccountTransferLimit, error) {
+	row := q.queryRow(ctx, q.upsertAccountTransferLimitStmt, upsertAccountTransferLimit,
+		arg.AccountID,
+		arg.MaxPerTransfer,
+		arg.DailyAmount,
+		arg.DailyCount,
+		arg.UpdatedBy,
+	)
+	var i AccountTransferLimit
+	err := row.Scan(
+		&i.AccountID,
+		&i.MaxPerTransfer,
+		&i.DailyAmount,
+		&i.DailyCount,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountTransferLimitDefault(t *testing.T) {
+	account := createRandomAccount(t)
+
+	defaults, err := testQueries.GetTransferLimitDefault(context.Background(), account.Currency)
+	require.NoError(t, err)
+
+	limit, err := testQueries.GetAccountTransferLimit(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, limit.AccountID)
+	require.Equal(t, account.Currency, limit.Currency)
+	require.Equal(t, defaults.MaxPerTransfer, limit.MaxPerTransfer)
+	require.Equal(t, defaults.DailyAmount, limit.DailyAmount)
+	require.Equal(t, defaults.DailyCount, limit.DailyCount)
+
+	// an unset limit keeps the default
+	_, err = testQueries.UpsertAccountTransferLimit(context.Background(), UpsertAccountTransferLimitParams{
+		AccountID:   account.ID,
+		DailyAmount: sql.NullInt64{Int64: 50, Valid: true},
+		UpdatedBy:   account.Owner,
+	})
+	require.NoError(t, err)
+
+	limit, err = testQueries.GetAccountTransferLimit(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, defaults.MaxPerTransfer, limit.MaxPerTransfer)
+	require.Equal(t, int64(50), limit.DailyAmount)
+	require.Equal(t, defaults.DailyCount, limit.DailyCount)
+}
+
+func TestTransferTxLimits(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createRandomAccount(t)
+
+	_, err := testQueries.UpsertAccountTransferLimit(ctx, UpsertAccountTransferLimitParams{
+		AccountID:      account1.ID,
+		MaxPerTransfer: sql.NullInt64{Int64: 30, Valid: true},
+		DailyAmount:    sql.NullInt64{Int64: 50, Valid: true},
+		DailyCount:     sql.NullInt32{Int32: 2, Valid: true},
+		UpdatedBy:      account1.Owner,
+	})
+	require.NoError(t, err)
+
+	transfer := func(amount int64) (TransferTxResult, error) {
+		return store.TransferTx(ctx, TransferTxParams{
+			FromAccountId: account1.ID,
+			ToAccountId:   account2.ID,
+			Amount:        amount,
+		})
+	}
+
+	_, err = transfer(40)
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	result, err := transfer(30)
+	require.NoError(t, err)
+	require.Equal(t, TransferAllowance{MaxPerTransfer: 30, DailyAmountRemaining: 20, DailyCountRemaining: 1}, result.Allowance)
+
+	_, err = transfer(30)
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	result, err = transfer(20)
+	require.NoError(t, err)
+	require.Equal(t, TransferAllowance{MaxPerTransfer: 30, DailyAmountRemaining: 0, DailyCountRemaining: 0}, result.Allowance)
+
+	// raising the daily amount still leaves the daily count reached
+	_, err = testQueries.UpsertAccountTransferLimit(ctx, UpsertAccountTransferLimitParams{
+		AccountID:      account1.ID,
+		MaxPerTransfer: sql.NullInt64{Int64: 30, Valid: true},
+		DailyAmount:    sql.NullInt64{Int64: 100, Valid: true},
+		DailyCount:     sql.NullInt32{Int32: 2, Valid: true},
+		UpdatedBy:      account1.Owner,
+	})
+	require.NoError(t, err)
+
+	_, err = transfer(10)
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	total, err := testQueries.GetDailyTransferTotal(ctx, account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), total.Count)
+	require.Equal(t, int64(50), total.Amount)
+}
+
+func TestNewTransferAllowanceLoweredLimit(t *testing.T) {
+	allowance := NewTransferAllowance(
+		GetAccountTransferLimitRow{MaxPerTransfer: 10, DailyAmount: 20, DailyCount: 1},
+		GetDailyTransferTotalRow{Count: 3, Amount: 50},
+	)
+	require.Equal(t, TransferAllowance{MaxPerTransfer: 10}, allowance)
+}
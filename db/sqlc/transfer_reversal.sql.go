@@ -0,0 +1,113 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: transfer_reversal.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createTransferReversal = `-- name: CreateTransferReversal :one
+INSERT INTO transfer_reversals (
+  transfer_id,
+  amount,
+  to_amount,
+  reason,
+  journal_entry_id,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING id, transfer_id, amount, to_amount, reason, journal_entry_id, created_by, created_at
+`
+
+type CreateTransferReversalParams struct {
+	TransferID     int64  `json:"transfer_id"`
+	Amount         int64  `json:"amount"`
+	ToAmount       int64  `json:"to_amount"`
+	Reason         string `json:"reason"`
+	JournalEntryID int64  `json:"journal_entry_id"`
+	CreatedBy      string `json:"created_by"`
+}
+
+func (q *Queries) CreateTransferReversal(ctx context.Context, arg CreateTransferReversalParams) (TransferReversal, error) {
+	row := q.queryRow(ctx, q.createTransferReversalStmt, createTransferReversal,
+		arg.TransferID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.Reason,
+		arg.JournalEntryID,
+		arg.CreatedBy,
+	)
+	var i TransferReversal
+	err := row.Scan(
+		&i.ID,
+		&i.TransferID,
+		&i.Amount,
+		&i.ToAmount,
+		&i.Reason,
+		&i.JournalEntryID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferReversedTotal = `-- name: GetTransferReversedTotal :one
+SELECT
+  COALESCE(SUM(amount), 0)::bigint AS amount,
+  COALESCE(SUM(to_amount), 0)::bigint AS to_amount
+FROM transfer_reversals
+WHERE transfer_id = $1
+`
+
+type GetTransferReversedTotalRow struct {
+	Amount   int64 `json:"amount"`
+	ToAmount int64 `json:"to_amount"`
+}
+
+func (q *Queries) GetTransferReversedTotal(ctx context.Context, transferID int64) (GetTransferReversedTotalRow, error) {
+	row := q.queryRow(ctx, q.getTransferReversedTotalStmt, getTransferReversedTotal, transferID)
+	var i GetTransferReversedTotalRow
+	err := row.Scan(&i.Amount, &i.ToAmount)
+	return i, err
+}
+
+const listTransferReversals = `-- name: ListTransferReversals :many
+SELECT id, transfer_id, amount, to_amount, reason, journal_entry_id, created_by, created_at FROM transfer_reversals
+WHERE transfer_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListTransferReversals(ctx context.Context, transferID int64) ([]TransferReversal, error) {
+	rows, err := q.query(ctx, q.listTransferReversalsStmt, listTransferReversals, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferReversal{}
+	for rows.Next() {
+		var i TransferReversal
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransferID,
+			&i.Amount,
+			&i.ToAmount,
+			&i.Reason,
+			&i.JournalEntryID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -12,10 +12,13 @@ import (
 func createRandomTransfer(t *testing.T, account1 Account, account2 Account) Transfer {
 	ctx := context.Background()
 
+	amount := util.GenerateRandomMoney()
 	arg := CreateTransferParams{
 		FromAccountID: account1.ID,
 		ToAccountID:   account2.ID,
-		Amount:        util.GenerateRandomMoney(),
+		Amount:        amount,
+		ExchangeRate:  "1",
+		ToAmount:      amount,
 	}
 
 	transfer, err := testQueries.CreateTransfer(ctx, arg)
@@ -26,6 +29,8 @@ func createRandomTransfer(t *testing.T, account1 Account, account2 Account) Tran
 	require.Equal(t, arg.FromAccountID, transfer.FromAccountID)
 	require.Equal(t, arg.ToAccountID, transfer.ToAccountID)
 	require.Equal(t, arg.Amount, transfer.Amount)
+	require.Equal(t, arg.ExchangeRate, transfer.ExchangeRate)
+	require.Equal(t, arg.ToAmount, transfer.ToAmount)
 
 	require.NotZero(t, transfer.ID)
 	require.NotZero(t, transfer.CreatedAt)
@@ -85,10 +90,8 @@ func TestListTransfer(t *testing.T) {
 	}
 
 	arg := ListTransfersParams{
-		FromAccountID: account1.ID,
-		ToAccountID:   account2.ID,
-		Limit:         5,
-		Offset:        0,
+		AccountID: account1.ID,
+		PageSize:  5,
 	}
 
 	transfers, err := testQueries.ListTransfers(ctx, arg)
@@ -99,4 +102,17 @@ func TestListTransfer(t *testing.T) {
 		require.NotEmpty(t, transfer)
 		require.True(t, transfer.FromAccountID == account1.ID || transfer.ToAccountID == account1.ID)
 	}
+
+	// the next page continues after the last transfer without overlapping
+	last := transfers[len(transfers)-1]
+	arg.CursorCreatedAt = last.CreatedAt
+	arg.CursorID = last.ID
+
+	nextTransfers, err := testQueries.ListTransfers(ctx, arg)
+	require.NoError(t, err)
+	require.Len(t, nextTransfers, 5)
+
+	for _, transfer := range nextTransfers {
+		require.NotContains(t, transfers, transfer)
+	}
 }
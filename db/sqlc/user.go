@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// CreateUserTxParams contains input parameters of create user transaction
+// VerifyEmailTask builds the task sending the verification email, the task is inserted within the transaction
+// so the user and the task are committed or rolled back together
+type CreateUserTxParams struct {
+	CreateUserParams
+	SecretCode      string
+	VerifyEmailTask func(user User, verifyEmail VerifyEmail) (CreateTaskParams, error)
+}
+
+// CreateUserTxResult contains result of CreateUserTx
+type CreateUserTxResult struct {
+	User            User        `json:"user"`
+	VerifyEmail     VerifyEmail `json:"verify_email"`
+	VerifyEmailTask Task        `json:"verify_email_task"`
+}
+
+// CreateUserTx creates a user together with the code that verifies the email of the user and the task sending it
+func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error) {
+	var result CreateUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.CreateUser(ctx, arg.CreateUserParams)
+		if err != nil {
+			return err
+		}
+
+		result.VerifyEmail, err = q.CreateVerifyEmail(ctx, CreateVerifyEmailParams{
+			Username:   result.User.Username,
+			Email:      result.User.Email,
+			SecretCode: arg.SecretCode,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.VerifyEmailTask == nil {
+			return nil
+		}
+
+		task, err := arg.VerifyEmailTask(result.User, result.VerifyEmail)
+		if err != nil {
+			return err
+		}
+
+		result.VerifyEmailTask, err = q.CreateTask(ctx, task)
+		return err
+	})
+
+	return result, err
+}
+
+// VerifyEmailTxParams contains input parameters of verify email transaction
+type VerifyEmailTxParams struct {
+	EmailID    int64  `json:"email_id"`
+	SecretCode string `json:"secret_code"`
+}
+
+// VerifyEmailTxResult contains result of VerifyEmailTx
+type VerifyEmailTxResult struct {
+	User        User        `json:"user"`
+	VerifyEmail VerifyEmail `json:"verify_email"`
+}
+
+// VerifyEmailTx uses the secret code and marks the email of the user as verified.
+// An unknown, used or expired code returns sql.ErrNoRows
+func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error) {
+	var result VerifyEmailTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.VerifyEmail, err = q.UpdateVerifyEmail(ctx, UpdateVerifyEmailParams{
+			ID:         arg.EmailID,
+			SecretCode: arg.SecretCode,
+		})
+		if err != nil {
+			return err
+		}
+
+		// a code sent to a previous email address must not verify the current one
+		user, err := q.GetUser(ctx, result.VerifyEmail.Username)
+		if err != nil {
+			return err
+		}
+		if user.Email != result.VerifyEmail.Email {
+			return sql.ErrNoRows
+		}
+
+		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+			Username:        result.VerifyEmail.Username,
+			IsEmailVerified: sql.NullBool{Bool: true, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ResetPasswordTxParams contains input parameters of reset password transaction
+// HashedPassword is the hash of the new password
+type ResetPasswordTxParams struct {
+	TokenHash      string `json:"token_hash"`
+	HashedPassword string `json:"-"`
+}
+
+// ResetPasswordTxResult contains result of ResetPasswordTx
+type ResetPasswordTxResult struct {
+	User            User          `json:"user"`
+	PasswordReset   PasswordReset `json:"password_reset"`
+	BlockedSessions int64         `json:"blocked_sessions"`
+}
+
+// ResetPasswordTx uses the reset token, sets the new password and blocks every session of the user,
+// so whoever knew the old password is logged out. An unknown, used or expired token returns sql.ErrNoRows.
+// Access tokens can't be revoked here: the gRPC server refuses the ones issued before password_changed_at,
+// the Gin server doesn't look up the user and accepts them until they expire
+func (store *SQLStore) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error) {
+	var result ResetPasswordTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.PasswordReset, err = q.UsePasswordReset(ctx, arg.TokenHash)
+		if err != nil {
+			return err
+		}
+
+		err = q.UseUserPasswordResets(ctx, result.PasswordReset.Username)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+			Username:          result.PasswordReset.Username,
+			Password:          sql.NullString{String: arg.HashedPassword, Valid: true},
+			PasswordChangedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.BlockedSessions, err = q.BlockUserSessions(ctx, result.PasswordReset.Username)
+		return err
+	})
+
+	return result, err
+}
@@ -18,7 +18,7 @@ INSERT INTO users (
     email
 ) VALUES (
     $1, $2, $3, $4
-) RETURNING username, password, full_name, email, password_changed_at, created_at
+) RETURNING username, password, full_name, email, password_changed_at, created_at, role, is_email_verified
 `
 
 type CreateUserParams struct {
@@ -43,12 +43,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
+		&i.IsEmailVerified,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, password, full_name, email, password_changed_at, created_at FROM users
+SELECT username, password, full_name, email, password_changed_at, created_at, role, is_email_verified FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -62,6 +64,8 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
+		&i.IsEmailVerified,
 	)
 	return i, err
 }
@@ -81,10 +85,11 @@ SET
     password = COALESCE($1,password),
     password_changed_at = COALESCE($2,password_changed_at),
     full_name = COALESCE($3, full_name), 
-    email = COALESCE($4, email)
+    email = COALESCE($4, email),
+    is_email_verified = COALESCE($5, is_email_verified)
 WHERE 
-    username = $5 
-RETURNING username, password, full_name, email, password_changed_at, created_at
+    username = $6 
+RETURNING username, password, full_name, email, password_changed_at, created_at, role, is_email_verified
 `
 
 type UpdateUserParams struct {
@@ -92,6 +97,7 @@ type UpdateUserParams struct {
 	PasswordChangedAt sql.NullTime   `json:"password_changed_at"`
 	FullName          sql.NullString `json:"full_name"`
 	Email             sql.NullString `json:"email"`
+	IsEmailVerified   sql.NullBool   `json:"is_email_verified"`
 	Username          string         `json:"username"`
 }
 
@@ -101,6 +107,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.PasswordChangedAt,
 		arg.FullName,
 		arg.Email,
+		arg.IsEmailVerified,
 		arg.Username,
 	)
 	var i User
@@ -111,6 +118,36 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
+		&i.IsEmailVerified,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2
+WHERE username = $1
+RETURNING username, password, full_name, email, password_changed_at, created_at, role, is_email_verified
+`
+
+type UpdateUserRoleParams struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.queryRow(ctx, q.updateUserRoleStmt, updateUserRole, arg.Username, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.Password,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.Role,
+		&i.IsEmailVerified,
 	)
 	return i, err
 }
@@ -3,6 +3,8 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -102,6 +104,179 @@ func TestUpdateUserOnlyEmail(t *testing.T) {
 	require.Equal(t, oldUser.Password, updatedUser.Password)
 }
 
+func TestUpdateUserRole(t *testing.T) {
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	require.Equal(t, util.DepositorRole, user.Role)
+
+	updatedUser, err := testQueries.UpdateUserRole(ctx, UpdateUserRoleParams{
+		Username: user.Username,
+		Role:     util.BankerRole,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.BankerRole, updatedUser.Role)
+
+	// unknown roles are rejected by the database
+	_, err = testQueries.UpdateUserRole(ctx, UpdateUserRoleParams{
+		Username: user.Username,
+		Role:     "admin",
+	})
+	require.Error(t, err)
+}
+
+func TestCreateUserTx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	hashedPassword, err := util.HashPassword(util.RandomString(8))
+	require.NoError(t, err)
+
+	arg := CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username: util.GenerateRandomName(),
+			Password: hashedPassword,
+			FullName: util.GenerateRandomName(),
+			Email:    util.GenerateRandomEmail(),
+		},
+		SecretCode: util.RandomString(32),
+	}
+
+	// a failing task rolls back the user
+	arg.VerifyEmailTask = func(user User, verifyEmail VerifyEmail) (CreateTaskParams, error) {
+		return CreateTaskParams{}, errors.New("cannot build task")
+	}
+	_, err = store.CreateUserTx(ctx, arg)
+	require.Error(t, err)
+
+	_, err = testQueries.GetUser(ctx, arg.Username)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.VerifyEmailTask = func(user User, verifyEmail VerifyEmail) (CreateTaskParams, error) {
+		require.Equal(t, user.Username, verifyEmail.Username)
+		require.Equal(t, user.Email, verifyEmail.Email)
+
+		payload := fmt.Sprintf(`{"username":%q,"verify_email_id":%d}`, user.Username, verifyEmail.ID)
+		return CreateTaskParams{
+			Type:        "task:send_verify_email",
+			Payload:     []byte(payload),
+			Queue:       "critical",
+			Priority:    10,
+			MaxAttempts: 10,
+			RunAt:       time.Now(),
+		}, nil
+	}
+	result, err := store.CreateUserTx(ctx, arg)
+	require.NoError(t, err)
+	require.False(t, result.User.IsEmailVerified)
+	require.Equal(t, arg.SecretCode, result.VerifyEmail.SecretCode)
+	require.False(t, result.VerifyEmail.IsUsed)
+	require.True(t, result.VerifyEmail.ExpiredAt.After(result.VerifyEmail.CreatedAt))
+
+	// the task is committed with the user
+	task, err := testQueries.GetTask(ctx, result.VerifyEmailTask.ID)
+	require.NoError(t, err)
+	require.Equal(t, "task:send_verify_email", task.Type)
+}
+
+func TestVerifyEmailTx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	verifyEmail, err := testQueries.CreateVerifyEmail(ctx, CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: util.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	_, err = store.VerifyEmailTx(ctx, VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: util.RandomString(32),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg := VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	}
+
+	result, err := store.VerifyEmailTx(ctx, arg)
+	require.NoError(t, err)
+	require.True(t, result.User.IsEmailVerified)
+	require.True(t, result.VerifyEmail.IsUsed)
+
+	// a code can only be used once
+	_, err = store.VerifyEmailTx(ctx, arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestResetPasswordTx(t *testing.T) {
+	store := NewStore(testDb)
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	session := createRandomSession(t, user)
+
+	createPasswordReset := func(expiredAt time.Time) string {
+		token, err := util.GenerateSecretToken()
+		require.NoError(t, err)
+
+		_, err = testQueries.CreatePasswordReset(ctx, CreatePasswordResetParams{
+			Username:  user.Username,
+			TokenHash: util.HashSecretToken(token),
+			ExpiredAt: expiredAt,
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	expiredToken := createPasswordReset(time.Now().Add(-time.Minute))
+	_, err := store.ResetPasswordTx(ctx, ResetPasswordTxParams{
+		TokenHash:      util.HashSecretToken(expiredToken),
+		HashedPassword: "new_hash",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	token := createPasswordReset(time.Now().Add(time.Minute))
+
+	// no other reset is created while one is pending
+	_, err = testQueries.CreatePasswordReset(ctx, CreatePasswordResetParams{
+		Username:  user.Username,
+		TokenHash: util.HashSecretToken(util.RandomString(32)),
+		ExpiredAt: time.Now().Add(time.Minute),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg := ResetPasswordTxParams{
+		TokenHash:      util.HashSecretToken(token),
+		HashedPassword: "new_hash",
+	}
+
+	result, err := store.ResetPasswordTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, "new_hash", result.User.Password)
+	require.True(t, result.User.PasswordChangedAt.After(user.PasswordChangedAt))
+	require.True(t, result.PasswordReset.IsUsed)
+	require.Equal(t, int64(1), result.BlockedSessions)
+
+	blockedSession, err := testQueries.GetSession(ctx, session.ID)
+	require.NoError(t, err)
+	require.True(t, blockedSession.IsBlocked)
+
+	// access tokens are not stored, the servers compare their issue time with password_changed_at
+	updatedUser, err := testQueries.GetUser(ctx, user.Username)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), updatedUser.PasswordChangedAt, time.Minute)
+
+	// the token can only be used once, a new reset can be requested after it
+	_, err = store.ResetPasswordTx(ctx, arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	createPasswordReset(time.Now().Add(time.Minute))
+}
+
 func resetUsers(ctx context.Context) {
 	testQueries.ResetEntryTable(ctx)
 	testQueries.ResetTransferTable(ctx)
@@ -0,0 +1,8 @@
+package db
+
+// constant for all statuses of a webhook delivery
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
@@ -0,0 +1,241 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: webhook_delivery.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createWebhookDelivery = `-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+    endpoint_id,
+    event_id,
+    event_type,
+    payload
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (endpoint_id, event_id) DO UPDATE SET event_type = EXCLUDED.event_type
+RETURNING id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at
+`
+
+type CreateWebhookDeliveryParams struct {
+	EndpointID int64           `json:"endpoint_id"`
+	EventID    uuid.UUID       `json:"event_id"`
+	EventType  string          `json:"event_type"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// a delivery exists once per endpoint and event, fanning out the same event again returns the existing row
+func (q *Queries) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.queryRow(ctx, q.createWebhookDeliveryStmt, createWebhookDelivery,
+		arg.EndpointID,
+		arg.EventID,
+		arg.EventType,
+		arg.Payload,
+	)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.EndpointID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
+
+const failWebhookDelivery = `-- name: FailWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'failed',
+    last_error = $2
+WHERE id = $1
+RETURNING id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at
+`
+
+type FailWebhookDeliveryParams struct {
+	ID        int64          `json:"id"`
+	LastError sql.NullString `json:"last_error"`
+}
+
+func (q *Queries) FailWebhookDelivery(ctx context.Context, arg FailWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.queryRow(ctx, q.failWebhookDeliveryStmt, failWebhookDelivery, arg.ID, arg.LastError)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.EndpointID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
+
+const getWebhookDelivery = `-- name: GetWebhookDelivery :one
+SELECT id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at FROM webhook_deliveries
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error) {
+	row := q.queryRow(ctx, q.getWebhookDeliveryStmt, getWebhookDelivery, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.EndpointID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at FROM webhook_deliveries
+WHERE endpoint_id = $1
+  AND (created_at, id) > ($2::timestamp, $3::bigint)
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListWebhookDeliveriesParams struct {
+	EndpointID      int64     `json:"endpoint_id"`
+	CursorCreatedAt time.Time `json:"cursor_created_at"`
+	CursorID        int64     `json:"cursor_id"`
+	PageSize        int32     `json:"page_size"`
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.query(ctx, q.listWebhookDeliveriesStmt, listWebhookDeliveries,
+		arg.EndpointID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookDelivery{}
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.EndpointID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const resetWebhookDelivery = `-- name: ResetWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pending'
+WHERE id = $1
+RETURNING id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at
+`
+
+func (q *Queries) ResetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error) {
+	row := q.queryRow(ctx, q.resetWebhookDeliveryStmt, resetWebhookDelivery, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.EndpointID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
+
+const updateWebhookDeliveryAttempt = `-- name: UpdateWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries
+SET status = $1,
+    attempts = attempts + 1,
+    response_status = $2,
+    last_error = $3,
+    delivered_at = $4
+WHERE id = $5
+RETURNING id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at
+`
+
+type UpdateWebhookDeliveryAttemptParams struct {
+	Status         string         `json:"status"`
+	ResponseStatus sql.NullInt32  `json:"response_status"`
+	LastError      sql.NullString `json:"last_error"`
+	DeliveredAt    sql.NullTime   `json:"delivered_at"`
+	ID             int64          `json:"id"`
+}
+
+func (q *Queries) UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) (WebhookDelivery, error) {
+	row := q.queryRow(ctx, q.updateWebhookDeliveryAttemptStmt, updateWebhookDeliveryAttempt,
+		arg.Status,
+		arg.ResponseStatus,
+		arg.LastError,
+		arg.DeliveredAt,
+		arg.ID,
+	)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.EndpointID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
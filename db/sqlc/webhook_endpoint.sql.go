@@ -0,0 +1,178 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: webhook_endpoint.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const createWebhookEndpoint = `-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (
+    owner,
+    url,
+    secret,
+    event_types
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, owner, url, secret, event_types, is_active, created_at
+`
+
+type CreateWebhookEndpointParams struct {
+	Owner      string   `json:"owner"`
+	Url        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+}
+
+func (q *Queries) CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error) {
+	row := q.queryRow(ctx, q.createWebhookEndpointStmt, createWebhookEndpoint,
+		arg.Owner,
+		arg.Url,
+		arg.Secret,
+		pq.Array(arg.EventTypes),
+	)
+	var i WebhookEndpoint
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Url,
+		&i.Secret,
+		pq.Array(&i.EventTypes),
+		&i.IsActive,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deactivateWebhookEndpoint = `-- name: DeactivateWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET is_active = false
+WHERE id = $1 AND owner = $2 AND is_active = true
+RETURNING id, owner, url, secret, event_types, is_active, created_at
+`
+
+type DeactivateWebhookEndpointParams struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+}
+
+func (q *Queries) DeactivateWebhookEndpoint(ctx context.Context, arg DeactivateWebhookEndpointParams) (WebhookEndpoint, error) {
+	row := q.queryRow(ctx, q.deactivateWebhookEndpointStmt, deactivateWebhookEndpoint, arg.ID, arg.Owner)
+	var i WebhookEndpoint
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Url,
+		&i.Secret,
+		pq.Array(&i.EventTypes),
+		&i.IsActive,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWebhookEndpoint = `-- name: GetWebhookEndpoint :one
+SELECT id, owner, url, secret, event_types, is_active, created_at FROM webhook_endpoints
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetWebhookEndpoint(ctx context.Context, id int64) (WebhookEndpoint, error) {
+	row := q.queryRow(ctx, q.getWebhookEndpointStmt, getWebhookEndpoint, id)
+	var i WebhookEndpoint
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Url,
+		&i.Secret,
+		pq.Array(&i.EventTypes),
+		&i.IsActive,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listSubscribedWebhookEndpoints = `-- name: ListSubscribedWebhookEndpoints :many
+SELECT id, owner, url, secret, event_types, is_active, created_at FROM webhook_endpoints
+WHERE owner = $1
+  AND is_active = true
+  AND $2::varchar = ANY(event_types)
+ORDER BY id
+`
+
+type ListSubscribedWebhookEndpointsParams struct {
+	Owner     string `json:"owner"`
+	EventType string `json:"event_type"`
+}
+
+func (q *Queries) ListSubscribedWebhookEndpoints(ctx context.Context, arg ListSubscribedWebhookEndpointsParams) ([]WebhookEndpoint, error) {
+	rows, err := q.query(ctx, q.listSubscribedWebhookEndpointsStmt, listSubscribedWebhookEndpoints, arg.Owner, arg.EventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookEndpoint{}
+	for rows.Next() {
+		var i WebhookEndpoint
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Url,
+			&i.Secret,
+			pq.Array(&i.EventTypes),
+			&i.IsActive,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookEndpoints = `-- name: ListWebhookEndpoints :many
+SELECT id, owner, url, secret, event_types, is_active, created_at FROM webhook_endpoints
+WHERE owner = $1 AND is_active = true
+ORDER BY id
+`
+
+func (q *Queries) ListWebhookEndpoints(ctx context.Context, owner string) ([]WebhookEndpoint, error) {
+	rows, err := q.query(ctx, q.listWebhookEndpointsStmt, listWebhookEndpoints, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookEndpoint{}
+	for rows.Next() {
+		var i WebhookEndpoint
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Url,
+			&i.Secret,
+			pq.Array(&i.EventTypes),
+			&i.IsActive,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Cell6969/go_bank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomWebhookEndpoint(t *testing.T, user User, eventTypes ...string) WebhookEndpoint {
+	arg := CreateWebhookEndpointParams{
+		Owner:      user.Username,
+		Url:        "https://example.com/" + util.RandomString(8),
+		Secret:     util.RandomString(32),
+		EventTypes: eventTypes,
+	}
+
+	endpoint, err := testQueries.CreateWebhookEndpoint(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, endpoint)
+
+	require.Equal(t, arg.Owner, endpoint.Owner)
+	require.Equal(t, arg.Url, endpoint.Url)
+	require.Equal(t, arg.Secret, endpoint.Secret)
+	require.Equal(t, arg.EventTypes, endpoint.EventTypes)
+	require.True(t, endpoint.IsActive)
+	return endpoint
+}
+
+func createRandomWebhookDelivery(t *testing.T, endpoint WebhookEndpoint) WebhookDelivery {
+	arg := CreateWebhookDeliveryParams{
+		EndpointID: endpoint.ID,
+		EventID:    uuid.New(),
+		EventType:  EventTransferCompleted,
+		Payload:    json.RawMessage(`{"transfer_id":1}`),
+	}
+
+	delivery, err := testQueries.CreateWebhookDelivery(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.EndpointID, delivery.EndpointID)
+	require.Equal(t, arg.EventID, delivery.EventID)
+	require.Equal(t, WebhookDeliveryPending, delivery.Status)
+	require.Zero(t, delivery.Attempts)
+	return delivery
+}
+
+func TestListSubscribedWebhookEndpoints(t *testing.T) {
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	transferEndpoint := createRandomWebhookEndpoint(t, user, EventTransferCompleted)
+	entryEndpoint := createRandomWebhookEndpoint(t, user, "entry.created")
+	deletedEndpoint := createRandomWebhookEndpoint(t, user, EventTransferCompleted)
+
+	_, err := testQueries.DeactivateWebhookEndpoint(ctx, DeactivateWebhookEndpointParams{
+		ID:    deletedEndpoint.ID,
+		Owner: user.Username,
+	})
+	require.NoError(t, err)
+
+	endpoints, err := testQueries.ListSubscribedWebhookEndpoints(ctx, ListSubscribedWebhookEndpointsParams{
+		Owner:     user.Username,
+		EventType: EventTransferCompleted,
+	})
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	require.Equal(t, transferEndpoint.ID, endpoints[0].ID)
+
+	endpoints, err = testQueries.ListWebhookEndpoints(ctx, user.Username)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+	require.Equal(t, transferEndpoint.ID, endpoints[0].ID)
+	require.Equal(t, entryEndpoint.ID, endpoints[1].ID)
+}
+
+func TestDeactivateWebhookEndpointOtherOwner(t *testing.T) {
+	user := createRandomUser(t)
+	otherUser := createRandomUser(t)
+	endpoint := createRandomWebhookEndpoint(t, user, EventTransferCompleted)
+
+	_, err := testQueries.DeactivateWebhookEndpoint(context.Background(), DeactivateWebhookEndpointParams{
+		ID:    endpoint.ID,
+		Owner: otherUser.Username,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCreateWebhookDeliveryOncePerEvent(t *testing.T) {
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	endpoint := createRandomWebhookEndpoint(t, user, EventTransferCompleted)
+	delivery := createRandomWebhookDelivery(t, endpoint)
+
+	_, err := testQueries.UpdateWebhookDeliveryAttempt(ctx, UpdateWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         WebhookDeliverySucceeded,
+		ResponseStatus: sql.NullInt32{Int32: 200, Valid: true},
+		DeliveredAt:    sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	// dispatching the same event again returns the delivery made before
+	duplicate, err := testQueries.CreateWebhookDelivery(ctx, CreateWebhookDeliveryParams{
+		EndpointID: endpoint.ID,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Payload:    delivery.Payload,
+	})
+	require.NoError(t, err)
+	require.Equal(t, delivery.ID, duplicate.ID)
+	require.Equal(t, WebhookDeliverySucceeded, duplicate.Status)
+	require.Equal(t, int32(1), duplicate.Attempts)
+
+	replayed, err := testQueries.ResetWebhookDelivery(ctx, delivery.ID)
+	require.NoError(t, err)
+	require.Equal(t, WebhookDeliveryPending, replayed.Status)
+	require.Equal(t, delivery.EventID, replayed.EventID)
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	user := createRandomUser(t)
+	endpoint := createRandomWebhookEndpoint(t, user, EventTransferCompleted)
+
+	for i := 0; i < 3; i++ {
+		createRandomWebhookDelivery(t, endpoint)
+	}
+
+	deliveries, err := testQueries.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		EndpointID: endpoint.ID,
+		PageSize:   2,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+
+	next, err := testQueries.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		EndpointID:      endpoint.ID,
+		CursorCreatedAt: deliveries[1].CreatedAt,
+		CursorID:        deliveries[1].ID,
+		PageSize:        2,
+	})
+	require.NoError(t, err)
+	require.Len(t, next, 1)
+}
@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/token"
+	"github.com/Cell6969/go_bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// accountStatusError maps ErrAccountFrozen and ErrAccountClosed to a status, it returns nil for other errors
+func accountStatusError(err error) error {
+	if errors.Is(err, db.ErrAccountFrozen) || errors.Is(err, db.ErrAccountClosed) {
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	}
+	return nil
+}
+
+func (server *Server) findAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	return account, nil
+}
+
+// authorizedAccount loads the account and makes sure it belongs to the authenticated user,
+// bank staff may look at any account
+func (server *Server) authorizedAccount(ctx context.Context, authPayload *token.Payload, accountID int64) (db.Account, error) {
+	account, err := server.findAccount(ctx, accountID)
+	if err != nil {
+		return account, err
+	}
+
+	if account.Owner != authPayload.Username && !authPayload.HasRole(util.StaffRoles...) {
+		return account, status.Errorf(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+	}
+
+	return account, nil
+}
@@ -2,6 +2,7 @@ package gapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -14,7 +15,13 @@ const (
 	authorizationBearer = "bearer"
 )
 
-func (server *Server) authorizerUser(ctx context.Context) (*token.Payload, error) {
+// errPermissionDenied is returned by authorizerUser when the user doesn't have any of the accessible roles
+var errPermissionDenied = errors.New("permission denied")
+
+// authorizerUser authenticates the request, when accessibleRoles are given the user must have one of them.
+// Access tokens issued before the last password change are refused, so a password reset also logs out
+// the clients that still hold an access token
+func (server *Server) authorizerUser(ctx context.Context, accessibleRoles ...string) (*token.Payload, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, fmt.Errorf("cannot get metadata")
@@ -44,5 +51,18 @@ func (server *Server) authorizerUser(ctx context.Context) (*token.Payload, error
 		return nil, fmt.Errorf("invalid token: %s", err)
 	}
 
+	user, err := server.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get user: %s", err)
+	}
+
+	if payload.IssuedAt.Before(user.PasswordChangedAt) {
+		return nil, fmt.Errorf("invalid token: password changed after the token was issued")
+	}
+
+	if len(accessibleRoles) > 0 && !payload.HasRole(accessibleRoles...) {
+		return nil, fmt.Errorf("%w: role %s is not allowed", errPermissionDenied, payload.Role)
+	}
+
 	return payload, nil
 }
@@ -0,0 +1,66 @@
+package gapi
+
+import (
+	"errors"
+	"fmt"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/valid"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cashRequest is the part deposit and withdraw requests have in common
+type cashRequest interface {
+	GetAccountId() int64
+	GetAmount() int64
+	GetCurrency() string
+	GetChannel() string
+	GetReference() string
+}
+
+func validateCashRequest(request cashRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if request.GetAmount() <= 0 {
+		violations = append(violations, fieldViolation("amount", fmt.Errorf("must be greater than 0")))
+	}
+
+	if err := valid.ValidateCurrency(request.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if err := valid.ValidateChannel(request.GetChannel()); err != nil {
+		violations = append(violations, fieldViolation("channel", err))
+	}
+
+	if err := valid.ValidateString(request.GetReference(), 1, 255); err != nil {
+		violations = append(violations, fieldViolation("reference", err))
+	}
+
+	return violations
+}
+
+// cashTxError maps the errors of DepositTx and WithdrawTx to a status
+func cashTxError(request cashRequest, action string, err error) error {
+	if errors.Is(err, db.ErrInsufficientFunds) {
+		return status.Errorf(codes.FailedPrecondition, "account [%d] has insufficient funds", request.GetAccountId())
+	}
+
+	if statusErr := accountStatusError(err); statusErr != nil {
+		return statusErr
+	}
+
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code.Name() {
+		case "unique_violation":
+			return status.Errorf(codes.AlreadyExists, "reference %s already used on channel %s", request.GetReference(), request.GetChannel())
+		}
+	}
+
+	return status.Errorf(codes.Internal, "failed to %s: %s", action, err)
+}
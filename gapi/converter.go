@@ -11,7 +11,211 @@ func convertUser(user db.User) *pb.User {
 		Username:          user.Username,
 		FullName:          user.FullName,
 		Email:             user.Email,
+		Role:              user.Role,
+		IsEmailVerified:   user.IsEmailVerified,
 		PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
 		CreatedAt:         timestamppb.New(user.CreatedAt),
 	}
 }
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:             account.ID,
+		Owner:          account.Owner,
+		Balance:        account.Balance,
+		Currency:       account.Currency,
+		CreatedAt:      timestamppb.New(account.CreatedAt),
+		OverdraftLimit: account.OverdraftLimit,
+		Status:         account.Status,
+	}
+}
+
+func convertEntry(entry db.Entry) *pb.Entry {
+	return &pb.Entry{
+		Id:                entry.ID,
+		AccountId:         entry.AccountID,
+		Amount:            entry.Amount,
+		CreatedAt:         timestamppb.New(entry.CreatedAt),
+		ExchangeRate:      entry.ExchangeRate,
+		SourceAmount:      entry.SourceAmount,
+		DestinationAmount: entry.DestinationAmount,
+		TransferId:        entry.TransferID.Int64,
+	}
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		CreatedAt:     timestamppb.New(transfer.CreatedAt),
+		ExchangeRate:  transfer.ExchangeRate,
+		ToAmount:      transfer.ToAmount,
+	}
+}
+
+func convertCashTransaction(cashTransaction db.CashTransaction) *pb.CashTransaction {
+	return &pb.CashTransaction{
+		Id:             cashTransaction.ID,
+		AccountId:      cashTransaction.AccountID,
+		Type:           cashTransaction.Type,
+		Amount:         cashTransaction.Amount,
+		Channel:        cashTransaction.Channel,
+		Reference:      cashTransaction.Reference,
+		JournalEntryId: cashTransaction.JournalEntryID,
+		EntryId:        cashTransaction.EntryID,
+		CreatedBy:      cashTransaction.CreatedBy,
+		CreatedAt:      timestamppb.New(cashTransaction.CreatedAt),
+	}
+}
+
+func convertTransferReversal(reversal db.TransferReversal) *pb.TransferReversal {
+	return &pb.TransferReversal{
+		Id:             reversal.ID,
+		TransferId:     reversal.TransferID,
+		Amount:         reversal.Amount,
+		ToAmount:       reversal.ToAmount,
+		Reason:         reversal.Reason,
+		JournalEntryId: reversal.JournalEntryID,
+		CreatedBy:      reversal.CreatedBy,
+		CreatedAt:      timestamppb.New(reversal.CreatedAt),
+	}
+}
+
+func convertScheduledTransfer(scheduledTransfer db.ScheduledTransfer) *pb.ScheduledTransfer {
+	result := &pb.ScheduledTransfer{
+		Id:            scheduledTransfer.ID,
+		Owner:         scheduledTransfer.Owner,
+		FromAccountId: scheduledTransfer.FromAccountID,
+		ToAccountId:   scheduledTransfer.ToAccountID,
+		Amount:        scheduledTransfer.Amount,
+		Currency:      scheduledTransfer.Currency,
+		Recurrence:    scheduledTransfer.Recurrence,
+		StartAt:       timestamppb.New(scheduledTransfer.StartAt),
+		NextRunAt:     timestamppb.New(scheduledTransfer.NextRunAt),
+		Status:        scheduledTransfer.Status,
+		CreatedAt:     timestamppb.New(scheduledTransfer.CreatedAt),
+	}
+
+	if scheduledTransfer.EndAt.Valid {
+		result.EndAt = timestamppb.New(scheduledTransfer.EndAt.Time)
+	}
+
+	return result
+}
+
+func convertScheduledTransferRun(run db.ScheduledTransferRun) *pb.ScheduledTransferRun {
+	return &pb.ScheduledTransferRun{
+		Id:                  run.ID,
+		ScheduledTransferId: run.ScheduledTransferID,
+		OccurrenceAt:        timestamppb.New(run.OccurrenceAt),
+		Status:              run.Status,
+		TransferId:          run.TransferID.Int64,
+		Error:               run.Error,
+		CreatedAt:           timestamppb.New(run.CreatedAt),
+	}
+}
+
+func convertAccountStatusChange(statusChange db.AccountStatusChange) *pb.AccountStatusChange {
+	return &pb.AccountStatusChange{
+		Id:         statusChange.ID,
+		AccountId:  statusChange.AccountID,
+		FromStatus: statusChange.FromStatus,
+		ToStatus:   statusChange.ToStatus,
+		Reason:     statusChange.Reason,
+		ChangedBy:  statusChange.ChangedBy,
+		CreatedAt:  timestamppb.New(statusChange.CreatedAt),
+	}
+}
+
+func convertTransferLimits(limit db.GetAccountTransferLimitRow) *pb.TransferLimits {
+	return &pb.TransferLimits{
+		AccountId:      limit.AccountID,
+		Currency:       limit.Currency,
+		MaxPerTransfer: limit.MaxPerTransfer,
+		DailyAmount:    limit.DailyAmount,
+		DailyCount:     limit.DailyCount,
+	}
+}
+
+func convertTransferAllowance(allowance db.TransferAllowance) *pb.TransferAllowance {
+	return &pb.TransferAllowance{
+		MaxPerTransfer:       allowance.MaxPerTransfer,
+		DailyAmountRemaining: allowance.DailyAmountRemaining,
+		DailyCountRemaining:  allowance.DailyCountRemaining,
+	}
+}
+
+func convertTransferChallenge(challenge db.TransferChallenge) *pb.TransferChallenge {
+	return &pb.TransferChallenge{
+		Id:            challenge.ID,
+		FromAccountId: challenge.FromAccountID,
+		ToAccountId:   challenge.ToAccountID,
+		Amount:        challenge.Amount,
+		ExchangeRate:  challenge.ExchangeRate,
+		Method:        challenge.Method,
+		ExpiredAt:     timestamppb.New(challenge.ExpiredAt),
+	}
+}
+
+func convertStatementLine(line db.StatementLine) *pb.StatementLine {
+	return &pb.StatementLine{
+		EntryId:               line.EntryID,
+		TransferId:            line.TransferID,
+		CounterpartyAccountId: line.CounterpartyAccountID,
+		Amount:                line.Amount,
+		RunningBalance:        line.RunningBalance,
+		CreatedAt:             timestamppb.New(line.CreatedAt),
+	}
+}
+
+func convertSession(session db.Session) *pb.Session {
+	return &pb.Session{
+		Id:        session.ID.String(),
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		IsBlocked: session.IsBlocked,
+		ExpiredAt: timestamppb.New(session.ExpiredAt),
+		CreatedAt: timestamppb.New(session.CreatedAt),
+	}
+}
+
+func convertWebhookEndpoint(endpoint db.WebhookEndpoint) *pb.WebhookEndpoint {
+	return &pb.WebhookEndpoint{
+		Id:         endpoint.ID,
+		Url:        endpoint.Url,
+		EventTypes: endpoint.EventTypes,
+		IsActive:   endpoint.IsActive,
+		CreatedAt:  timestamppb.New(endpoint.CreatedAt),
+	}
+}
+
+func convertWebhookDelivery(delivery db.WebhookDelivery) *pb.WebhookDelivery {
+	result := &pb.WebhookDelivery{
+		Id:             delivery.ID,
+		EndpointId:     delivery.EndpointID,
+		EventId:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus.Int32,
+		LastError:      delivery.LastError.String,
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+	}
+	if delivery.DeliveredAt.Valid {
+		result.DeliveredAt = timestamppb.New(delivery.DeliveredAt.Time)
+	}
+	return result
+}
+
+func convertLedgerAccount(ledgerAccount db.ListSystemLedgerAccountBalancesRow) *pb.LedgerAccount {
+	return &pb.LedgerAccount{
+		Id:       ledgerAccount.ID,
+		Code:     ledgerAccount.Code,
+		Name:     ledgerAccount.Name,
+		Type:     ledgerAccount.Type,
+		Currency: ledgerAccount.Currency,
+		Balance:  ledgerAccount.Balance,
+	}
+}
@@ -1,6 +1,9 @@
 package gapi
 
 import (
+	"errors"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -25,6 +28,18 @@ func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) er
 	return statusDetails.Err()
 }
 
+// unauthenticatedError maps errors of authorizerUser, a missing role is reported as permission denied
 func unauthenticatedError(err error) error {
+	if errors.Is(err, errPermissionDenied) {
+		return status.Errorf(codes.PermissionDenied, "%s", err)
+	}
 	return status.Errorf(codes.Unauthenticated, "unauthorized: %s", err)
 }
+
+// authLockoutError maps errors of CheckAuthLockout, a locked out user is told to retry later
+func authLockoutError(err error) error {
+	if errors.Is(err, db.ErrAuthLocked) {
+		return status.Errorf(codes.ResourceExhausted, "%s", err)
+	}
+	return status.Errorf(codes.Internal, "failed to check auth lockout: %s", err)
+}
@@ -41,6 +41,35 @@ func GrpcLogger(
 	return result, err
 }
 
+func GrpcStreamLogger(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	startTime := time.Now()
+	err := handler(srv, stream)
+	duration := time.Since(startTime)
+
+	statusCode := codes.Unknown
+	if st, ok := status.FromError(err); ok {
+		statusCode = st.Code()
+	}
+
+	logger := log.Info()
+	if err != nil {
+		logger = log.Error().Err(err)
+	}
+
+	logger.Str("protocol", "grpc").
+		Str("method", info.FullMethod).
+		Int("status_code", int(statusCode)).
+		Str("status_description", statusCode.String()).
+		Dur("duration", duration).
+		Msg("closed a gRPC stream")
+	return err
+}
+
 // Implement Response Recorder for write header
 type ResponseRecorder struct {
 	http.ResponseWriter
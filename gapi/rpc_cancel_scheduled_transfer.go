@@ -0,0 +1,56 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CancelScheduledTransfer(ctx context.Context, request *pb.CancelScheduledTransferRequest) (*pb.CancelScheduledTransferResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := valid.ValidateID(request.GetScheduledTransferId()); err != nil {
+		violations := []*errdetails.BadRequest_FieldViolation{
+			fieldViolation("scheduled_transfer_id", err),
+		}
+		return nil, invalidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.authorizedScheduledTransfer(ctx, authPayload, request.GetScheduledTransferId())
+	if err != nil {
+		return nil, err
+	}
+
+	if scheduledTransfer.Status != db.ScheduledTransferActive {
+		return nil, status.Errorf(codes.FailedPrecondition, "scheduled transfer is %s", scheduledTransfer.Status)
+	}
+
+	arg := db.CancelScheduledTransferParams{
+		ID:    scheduledTransfer.ID,
+		Owner: authPayload.Username,
+	}
+
+	scheduledTransfer, err = server.store.CancelScheduledTransfer(ctx, arg)
+	if err != nil {
+		// the last occurrence completed it in the meantime
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.FailedPrecondition, "scheduled transfer is no longer active")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to cancel scheduled transfer: %s", err)
+	}
+
+	response := &pb.CancelScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+
+	return response, nil
+}
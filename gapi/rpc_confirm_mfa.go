@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recoveryCodeCount is how many recovery codes are handed out when multi-factor authentication is enabled
+const recoveryCodeCount = 10
+
+func (server *Server) ConfirmMFA(ctx context.Context, request *pb.ConfirmMFARequest) (*pb.ConfirmMFAResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateConfirmMFARequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	recoveryCodes, err := util.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate recovery codes: %s", err)
+	}
+
+	codeHashes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		codeHashes[i] = util.HashSecretToken(code)
+	}
+
+	_, err = server.store.EnableMFATx(ctx, db.EnableMFATxParams{
+		Username:           authPayload.Username,
+		Code:               request.GetCode(),
+		RecoveryCodeHashes: codeHashes,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidMFACode) {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("code", err)})
+		}
+		if errors.Is(err, db.ErrMFANotEnabled) {
+			return nil, status.Errorf(codes.FailedPrecondition, "enroll an authenticator app first")
+		}
+		if errors.Is(err, db.ErrMFAAlreadyEnabled) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to enable mfa: %s", err)
+	}
+
+	response := &pb.ConfirmMFAResponse{
+		RecoveryCodes: recoveryCodes,
+	}
+
+	return response, nil
+}
+
+func validateConfirmMFARequest(request *pb.ConfirmMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateTOTPCode(request.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,81 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ConfirmTransfer(ctx context.Context, request *pb.ConfirmTransferRequest) (*pb.ConfirmTransferResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateConfirmTransferRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.ConfirmTransferTx(ctx, db.ConfirmTransferTxParams{
+		ChallengeID: request.GetChallengeId(),
+		TokenID:     authPayload.ID,
+		Username:    authPayload.Username,
+		Password:    request.GetPassword(),
+		Code:        request.GetCode(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAuthLocked) {
+			return nil, authLockoutError(err)
+		}
+		if errors.Is(err, db.ErrTransferChallengeInvalid) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		if errors.Is(err, db.ErrStepUpFailed) || errors.Is(err, db.ErrMFANotEnabled) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s", db.ErrStepUpFailed)
+		}
+		return nil, transferTxError(err, result.Challenge.FromAccountID)
+	}
+
+	response := &pb.ConfirmTransferResponse{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+		Allowance:   convertTransferAllowance(result.Allowance),
+	}
+
+	return response, nil
+}
+
+func validateConfirmTransferRequest(request *pb.ConfirmTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetChallengeId()); err != nil {
+		violations = append(violations, fieldViolation("challenge_id", err))
+	}
+
+	if request.GetPassword() == "" && request.GetCode() == "" {
+		violations = append(violations, fieldViolation("password", fmt.Errorf("password or code is required")))
+	}
+
+	if request.GetPassword() != "" {
+		if err := valid.ValidatePassword(request.GetPassword()); err != nil {
+			violations = append(violations, fieldViolation("password", err))
+		}
+	}
+
+	if request.GetCode() != "" {
+		if err := valid.ValidateMFACode(request.GetCode()); err != nil {
+			violations = append(violations, fieldViolation("code", err))
+		}
+	}
+
+	return violations
+}
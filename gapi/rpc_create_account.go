@@ -0,0 +1,68 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateAccount(ctx context.Context, request *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateAccountRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find user: %s", err)
+	}
+
+	if !user.IsEmailVerified {
+		return nil, status.Errorf(codes.PermissionDenied, "email must be verified before creating an account")
+	}
+
+	arg := db.CreateAccountParams{
+		Owner:    authPayload.Username,
+		Balance:  0,
+		Currency: request.GetCurrency(),
+	}
+
+	account, err := server.store.CreateAccount(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "account already created: %s", err)
+			case "foreign_key_violation":
+				return nil, status.Errorf(codes.PermissionDenied, "cannot create account: %s", err)
+			}
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to create account: %s", err)
+	}
+
+	response := &pb.CreateAccountResponse{
+		Account: convertAccount(account),
+	}
+
+	return response, nil
+}
+
+func validateCreateAccountRequest(request *pb.CreateAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateCurrency(request.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
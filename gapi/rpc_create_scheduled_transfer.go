@@ -0,0 +1,133 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateScheduledTransfer(ctx context.Context, request *pb.CreateScheduledTransferRequest) (*pb.CreateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateScheduledTransferRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, "from_account_id", request.GetFromAccountId(), request.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		err := errors.New("from account doesn't belong to authenticated user")
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("from_account_id", err)})
+	}
+
+	_, err = server.findAccount(ctx, request.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	// the scheduler runs without the user, so it can't ask for the fresh proof larger transfers need
+	stepUpAmount, err := server.stepUpAmount(ctx, fromAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if request.GetAmount() > stepUpAmount {
+		err := fmt.Errorf("must not be greater than %d, larger transfers need step-up verification", stepUpAmount)
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount", err)})
+	}
+
+	arg := db.CreateScheduledTransferParams{
+		Owner:         authPayload.Username,
+		FromAccountID: request.GetFromAccountId(),
+		ToAccountID:   request.GetToAccountId(),
+		Amount:        request.GetAmount(),
+		Currency:      request.GetCurrency(),
+		StartAt:       request.GetStartAt().AsTime(),
+		NextRunAt:     request.GetStartAt().AsTime(),
+	}
+
+	if request.EndAt != nil {
+		arg.EndAt = sql.NullTime{Time: request.GetEndAt().AsTime(), Valid: true}
+	}
+
+	// the rule is stored normalized and the first occurrence may come after the start, like the 1st of next month
+	if request.GetRecurrence() != "" {
+		recurrence, _ := util.ParseRecurrence(request.GetRecurrence())
+		arg.Recurrence = recurrence.String()
+		arg.NextRunAt = recurrence.Next(arg.StartAt, arg.StartAt.Add(-time.Nanosecond))
+	}
+
+	if arg.EndAt.Valid && arg.NextRunAt.After(arg.EndAt.Time) {
+		err := fmt.Errorf("must not be before the first occurrence at %s", arg.NextRunAt.Format(time.RFC3339))
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("end_at", err)})
+	}
+
+	scheduledTransfer, err := server.store.CreateScheduledTransfer(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create scheduled transfer: %s", err)
+	}
+
+	response := &pb.CreateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+
+	return response, nil
+}
+
+func validateCreateScheduledTransferRequest(request *pb.CreateScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := valid.ValidateID(request.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if request.GetFromAccountId() == request.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("cannot transfer to the same account")))
+	}
+
+	if request.GetAmount() <= 0 {
+		violations = append(violations, fieldViolation("amount", fmt.Errorf("must be greater than 0")))
+	}
+
+	if err := valid.ValidateCurrency(request.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if err := valid.ValidateRecurrence(request.GetRecurrence()); err != nil {
+		violations = append(violations, fieldViolation("recurrence", err))
+	}
+
+	if request.StartAt == nil {
+		violations = append(violations, fieldViolation("start_at", fmt.Errorf("is required")))
+	} else if !request.GetStartAt().AsTime().After(time.Now()) {
+		violations = append(violations, fieldViolation("start_at", fmt.Errorf("must be in the future")))
+	}
+
+	if request.EndAt != nil {
+		if request.GetRecurrence() == "" {
+			violations = append(violations, fieldViolation("end_at", fmt.Errorf("is only allowed with a recurrence")))
+		} else if request.StartAt != nil && !request.GetEndAt().AsTime().After(request.GetStartAt().AsTime()) {
+			violations = append(violations, fieldViolation("end_at", fmt.Errorf("must be after start_at")))
+		}
+	}
+
+	return violations
+}
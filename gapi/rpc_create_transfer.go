@@ -0,0 +1,166 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, request *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateTransferRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, "from_account_id", request.GetFromAccountId(), request.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		err := errors.New("from account doesn't belong to authenticated user")
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("from_account_id", err)})
+	}
+
+	toAccount, err := server.findAccount(ctx, request.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	exchangeRate, err := server.fxRateProvider.GetRate(ctx, fromAccount.Currency, toAccount.Currency)
+	if err != nil {
+		if errors.Is(err, util.ErrUnsupportedFXPair) {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("to_account_id", err)})
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get exchange rate: %s", err)
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountId:  request.GetFromAccountId(),
+		ToAccountId:    request.GetToAccountId(),
+		Amount:         request.GetAmount(),
+		ExchangeRate:   exchangeRate,
+		Owner:          authPayload.Username,
+		IdempotencyKey: request.GetIdempotencyKey(),
+	}
+
+	// large transfers wait for the user to prove their identity again
+	stepUpAmount, err := server.stepUpAmount(ctx, fromAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if arg.Amount > stepUpAmount {
+		// a retry of a transfer that was already confirmed gets its result, not a new challenge
+		if arg.IdempotencyKey != "" {
+			result, replayed, err := server.store.ReplayTransfer(ctx, arg)
+			if err != nil {
+				return nil, transferTxError(err, arg.FromAccountId)
+			}
+			if replayed {
+				return newCreateTransferResponse(result), nil
+			}
+		}
+
+		return server.createTransferChallenge(ctx, authPayload, arg)
+	}
+
+	result, err := server.store.TransferTx(ctx, arg)
+	if err != nil {
+		return nil, transferTxError(err, arg.FromAccountId)
+	}
+
+	return newCreateTransferResponse(result), nil
+}
+
+func newCreateTransferResponse(result db.TransferTxResult) *pb.CreateTransferResponse {
+	return &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+		Allowance:   convertTransferAllowance(result.Allowance),
+	}
+}
+
+// transferTxError maps the errors of a transfer to a status
+func transferTxError(err error, fromAccountID int64) error {
+	if errors.Is(err, db.ErrInsufficientFunds) {
+		return status.Errorf(codes.FailedPrecondition, "account [%d] has insufficient funds", fromAccountID)
+	}
+	if errors.Is(err, db.ErrTransferLimitExceeded) {
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	}
+	if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+		return status.Errorf(codes.AlreadyExists, "%s", err)
+	}
+	if errors.Is(err, db.ErrConvertedAmountTooSmall) {
+		return invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount", err)})
+	}
+	if statusErr := accountStatusError(err); statusErr != nil {
+		return statusErr
+	}
+	return status.Errorf(codes.Internal, "failed to transfer: %s", err)
+}
+
+// validAccount makes sure the account exists, is active and holds the requested currency
+func (server *Server) validAccount(ctx context.Context, field string, accountID int64, currency string) (db.Account, error) {
+	account, err := server.findAccount(ctx, accountID)
+	if err != nil {
+		return account, err
+	}
+
+	if err := db.CheckAccountActive(account); err != nil {
+		return account, accountStatusError(err)
+	}
+
+	if account.Currency != currency {
+		err := fmt.Errorf("account [%d] currency missmatch: %s vs %s", accountID, account.Currency, currency)
+		return account, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation(field, err)})
+	}
+
+	return account, nil
+}
+
+func validateCreateTransferRequest(request *pb.CreateTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := valid.ValidateID(request.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if request.GetFromAccountId() == request.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("cannot transfer to the same account")))
+	}
+
+	if request.GetAmount() <= 0 {
+		violations = append(violations, fieldViolation("amount", fmt.Errorf("must be greater than 0")))
+	}
+
+	if err := valid.ValidateCurrency(request.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if request.GetIdempotencyKey() != "" {
+		if err := valid.ValidateString(request.GetIdempotencyKey(), 1, 255); err != nil {
+			violations = append(violations, fieldViolation("idempotency_key", err))
+		}
+	}
+
+	return violations
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Cell6969/go_bank/pb"
 	"github.com/Cell6969/go_bank/util"
 	"github.com/Cell6969/go_bank/valid"
+	"github.com/Cell6969/go_bank/worker"
 	"github.com/lib/pq"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
@@ -24,14 +25,24 @@ func (server *Server) CreateUser(ctx context.Context, request *pb.CreateUserRequ
 		return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
 	}
 
-	arg := db.CreateUserParams{
-		Username: request.GetUsername(),
-		Password: hashedPassword,
-		FullName: request.GetFullName(),
-		Email:    request.GetEmail(),
+	arg := db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username: request.GetUsername(),
+			Password: hashedPassword,
+			FullName: request.GetFullName(),
+			Email:    request.GetEmail(),
+		},
+		SecretCode: util.RandomString(32),
+		VerifyEmailTask: func(user db.User, verifyEmail db.VerifyEmail) (db.CreateTaskParams, error) {
+			payload := &worker.PayloadSendVerifyEmail{
+				Username:      user.Username,
+				VerifyEmailID: verifyEmail.ID,
+			}
+			return worker.NewTaskSendVerifyEmail(payload, worker.Queue(worker.QueueCritical))
+		},
 	}
 
-	user, err := server.store.CreateUser(ctx, arg)
+	result, err := server.store.CreateUserTx(ctx, arg)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
@@ -44,7 +55,7 @@ func (server *Server) CreateUser(ctx context.Context, request *pb.CreateUserRequ
 	}
 
 	response := &pb.CreateUserResponse{
-		User: convertUser(user),
+		User: convertUser(result.User),
 	}
 
 	return response, nil
@@ -0,0 +1,93 @@
+package gapi
+
+import (
+	"context"
+	"net/url"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"github.com/Cell6969/go_bank/webhook"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateWebhookEndpoint(ctx context.Context, request *pb.CreateWebhookEndpointRequest) (*pb.CreateWebhookEndpointResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateWebhookEndpointRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// the client checks the address again on every connection, the host may resolve differently by then
+	endpointURL, err := url.Parse(request.GetUrl())
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("url", err)})
+	}
+	if err := webhook.CheckHost(ctx, endpointURL.Hostname()); err != nil {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("url", err)})
+	}
+
+	secret := request.GetSecret()
+	if request.Secret == nil {
+		secret, err = webhook.NewSecret()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate secret: %s", err)
+		}
+	}
+
+	// keep the event types unique so an event is delivered once per endpoint
+	eventTypes := make([]string, 0, len(request.GetEventTypes()))
+	seen := map[string]bool{}
+	for _, eventType := range request.GetEventTypes() {
+		if !seen[eventType] {
+			seen[eventType] = true
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	arg := db.CreateWebhookEndpointParams{
+		Owner:      authPayload.Username,
+		Url:        request.GetUrl(),
+		Secret:     secret,
+		EventTypes: eventTypes,
+	}
+
+	endpoint, err := server.store.CreateWebhookEndpoint(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create webhook endpoint: %s", err)
+	}
+
+	response := &pb.CreateWebhookEndpointResponse{
+		Endpoint: convertWebhookEndpoint(endpoint),
+		Secret:   endpoint.Secret,
+	}
+
+	return response, nil
+}
+
+func validateCreateWebhookEndpointRequest(request *pb.CreateWebhookEndpointRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateWebhookURL(request.GetUrl()); err != nil {
+		violations = append(violations, fieldViolation("url", err))
+	}
+
+	if err := valid.ValidateWebhookEventTypes(request.GetEventTypes()); err != nil {
+		violations = append(violations, fieldViolation("event_types", err))
+	}
+
+	if request.Secret != nil {
+		if err := valid.ValidateWebhookSecret(request.GetSecret()); err != nil {
+			violations = append(violations, fieldViolation("secret", err))
+		}
+	}
+
+	return violations
+}
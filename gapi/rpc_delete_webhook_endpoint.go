@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeleteWebhookEndpoint(ctx context.Context, request *pb.DeleteWebhookEndpointRequest) (*pb.DeleteWebhookEndpointResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := valid.ValidateID(request.GetEndpointId()); err != nil {
+		violations := []*errdetails.BadRequest_FieldViolation{
+			fieldViolation("endpoint_id", err),
+		}
+		return nil, invalidArgumentError(violations)
+	}
+
+	// the endpoint is only deactivated so its delivery log stays available,
+	// the owner filter makes endpoints of other users look like they don't exist
+	arg := db.DeactivateWebhookEndpointParams{
+		ID:    request.GetEndpointId(),
+		Owner: authPayload.Username,
+	}
+
+	endpoint, err := server.store.DeactivateWebhookEndpoint(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "webhook endpoint not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete webhook endpoint: %s", err)
+	}
+
+	response := &pb.DeleteWebhookEndpointResponse{
+		Endpoint: convertWebhookEndpoint(endpoint),
+	}
+
+	return response, nil
+}
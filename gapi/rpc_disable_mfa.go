@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DisableMFA(ctx context.Context, request *pb.DisableMFARequest) (*pb.DisableMFAResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDisableMFARequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	err = server.store.DisableMFATx(ctx, db.DisableMFATxParams{
+		Username: authPayload.Username,
+		Code:     request.GetCode(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAuthLocked) {
+			return nil, authLockoutError(err)
+		}
+		if errors.Is(err, db.ErrInvalidMFACode) {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("code", err)})
+		}
+		if errors.Is(err, db.ErrMFANotEnabled) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to disable mfa: %s", err)
+	}
+
+	return &pb.DisableMFAResponse{}, nil
+}
+
+func validateDisableMFARequest(request *pb.DisableMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateMFACode(request.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
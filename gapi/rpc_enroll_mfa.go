@@ -0,0 +1,46 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mfaIssuer is the name authenticator apps show next to the codes
+const mfaIssuer = "Go Bank"
+
+func (server *Server) EnrollMFA(ctx context.Context, request *pb.EnrollMFARequest) (*pb.EnrollMFAResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate totp secret: %s", err)
+	}
+
+	enrollment, err := server.store.CreateMFAEnrollment(ctx, db.CreateMFAEnrollmentParams{
+		Username:   authPayload.Username,
+		TotpSecret: secret,
+	})
+	if err != nil {
+		// the enrollment is only replaced while it is not enabled
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", db.ErrMFAAlreadyEnabled)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to enroll mfa: %s", err)
+	}
+
+	response := &pb.EnrollMFAResponse{
+		Secret:          enrollment.TotpSecret,
+		ProvisioningUri: util.TOTPProvisioningURI(mfaIssuer, enrollment.Username, enrollment.TotpSecret),
+	}
+
+	return response, nil
+}
@@ -0,0 +1,103 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetAccountStatement(ctx context.Context, request *pb.GetAccountStatementRequest) (*pb.GetAccountStatementResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetAccountStatementRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.authorizedAccount(ctx, authPayload, request.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	cursorCreatedAt, cursorID, _ := util.DecodePageToken(request.GetPageToken())
+
+	// fetch one extra line to find out whether there is a next page
+	arg := db.AccountStatementTxParams{
+		AccountID:       account.ID,
+		FromTime:        request.GetFromTime().AsTime(),
+		ToTime:          request.GetToTime().AsTime(),
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		PageSize:        request.GetPageSize() + 1,
+	}
+
+	statement, err := server.store.AccountStatementTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to build statement: %s", err)
+	}
+
+	pageSize := int(request.GetPageSize())
+	response := &pb.GetAccountStatementResponse{
+		Account:        convertAccount(statement.Account),
+		OpeningBalance: statement.OpeningBalance,
+		ClosingBalance: statement.ClosingBalance,
+		Lines:          make([]*pb.StatementLine, 0, pageSize),
+	}
+
+	lines := statement.Lines
+	if len(lines) > pageSize {
+		lines = lines[:pageSize]
+		last := lines[pageSize-1]
+		response.NextPageToken = util.EncodePageToken(last.CreatedAt, last.EntryID)
+	}
+
+	for _, line := range lines {
+		response.Lines = append(response.Lines, convertStatementLine(line))
+	}
+
+	return response, nil
+}
+
+func validateGetAccountStatementRequest(request *pb.GetAccountStatementRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := valid.ValidatePageSize(request.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	if _, _, err := util.DecodePageToken(request.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolation("page_token", err))
+	}
+
+	if request.FromTime == nil || request.ToTime == nil {
+		if request.FromTime == nil {
+			violations = append(violations, fieldViolation("from_time", fmt.Errorf("is required")))
+		}
+		if request.ToTime == nil {
+			violations = append(violations, fieldViolation("to_time", fmt.Errorf("is required")))
+		}
+		return violations
+	}
+
+	if err := valid.ValidateStatementPeriod(request.GetFromTime().AsTime(), request.GetToTime().AsTime()); err != nil {
+		violations = append(violations, fieldViolation("to_time", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,46 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (server *Server) GetAccountTransferLimits(ctx context.Context, request *pb.GetAccountTransferLimitsRequest) (*pb.GetAccountTransferLimitsResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetAccountTransferLimitsRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.authorizedAccount(ctx, authPayload, request.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	limit, allowance, err := server.transferLimits(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.GetAccountTransferLimitsResponse{
+		Limits:    convertTransferLimits(limit),
+		Allowance: convertTransferAllowance(allowance),
+	}
+
+	return response, nil
+}
+
+func validateGetAccountTransferLimitsRequest(request *pb.GetAccountTransferLimitsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	return violations
+}
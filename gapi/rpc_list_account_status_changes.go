@@ -0,0 +1,77 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAccountStatusChanges(ctx context.Context, request *pb.ListAccountStatusChangesRequest) (*pb.ListAccountStatusChangesResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListAccountStatusChangesRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.authorizedAccount(ctx, authPayload, request.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	cursorCreatedAt, cursorID, _ := util.DecodePageToken(request.GetPageToken())
+
+	// fetch one extra row to find out whether there is a next page
+	arg := db.ListAccountStatusChangesParams{
+		AccountID:       account.ID,
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		PageSize:        request.GetPageSize() + 1,
+	}
+
+	statusChanges, err := server.store.ListAccountStatusChanges(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list account status changes: %s", err)
+	}
+
+	pageSize := int(request.GetPageSize())
+	response := &pb.ListAccountStatusChangesResponse{
+		StatusChanges: make([]*pb.AccountStatusChange, 0, pageSize),
+	}
+	if len(statusChanges) > pageSize {
+		statusChanges = statusChanges[:pageSize]
+		last := statusChanges[pageSize-1]
+		response.NextPageToken = util.EncodePageToken(last.CreatedAt, last.ID)
+	}
+
+	for _, statusChange := range statusChanges {
+		response.StatusChanges = append(response.StatusChanges, convertAccountStatusChange(statusChange))
+	}
+
+	return response, nil
+}
+
+func validateListAccountStatusChangesRequest(request *pb.ListAccountStatusChangesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := valid.ValidatePageSize(request.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	if _, _, err := util.DecodePageToken(request.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolation("page_token", err))
+	}
+
+	return violations
+}
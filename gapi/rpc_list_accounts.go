@@ -0,0 +1,68 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAccounts(ctx context.Context, request *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListAccountsRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	cursorCreatedAt, cursorID, _ := util.DecodePageToken(request.GetPageToken())
+
+	// fetch one extra row to find out whether there is a next page
+	arg := db.ListAccountParams{
+		Owner:           authPayload.Username,
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		PageSize:        request.GetPageSize() + 1,
+	}
+
+	accounts, err := server.store.ListAccount(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list accounts: %s", err)
+	}
+
+	pageSize := int(request.GetPageSize())
+	response := &pb.ListAccountsResponse{
+		Accounts: make([]*pb.Account, 0, pageSize),
+	}
+	if len(accounts) > pageSize {
+		accounts = accounts[:pageSize]
+		last := accounts[pageSize-1]
+		response.NextPageToken = util.EncodePageToken(last.CreatedAt, last.ID)
+	}
+
+	for _, account := range accounts {
+		response.Accounts = append(response.Accounts, convertAccount(account))
+	}
+
+	return response, nil
+}
+
+func validateListAccountsRequest(request *pb.ListAccountsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidatePageSize(request.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	if _, _, err := util.DecodePageToken(request.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolation("page_token", err))
+	}
+
+	return violations
+}
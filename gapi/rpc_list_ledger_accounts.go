@@ -0,0 +1,31 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListLedgerAccounts(ctx context.Context, request *pb.ListLedgerAccountsRequest) (*pb.ListLedgerAccountsResponse, error) {
+	_, err := server.authorizerUser(ctx, util.BankerRole)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	ledgerAccounts, err := server.store.ListSystemLedgerAccountBalances(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list ledger accounts: %s", err)
+	}
+
+	response := &pb.ListLedgerAccountsResponse{
+		LedgerAccounts: make([]*pb.LedgerAccount, 0, len(ledgerAccounts)),
+	}
+	for _, ledgerAccount := range ledgerAccounts {
+		response.LedgerAccounts = append(response.LedgerAccounts, convertLedgerAccount(ledgerAccount))
+	}
+
+	return response, nil
+}
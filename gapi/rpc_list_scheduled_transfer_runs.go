@@ -0,0 +1,77 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListScheduledTransferRuns(ctx context.Context, request *pb.ListScheduledTransferRunsRequest) (*pb.ListScheduledTransferRunsResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListScheduledTransferRunsRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.authorizedScheduledTransfer(ctx, authPayload, request.GetScheduledTransferId())
+	if err != nil {
+		return nil, err
+	}
+
+	cursorCreatedAt, cursorID, _ := util.DecodePageToken(request.GetPageToken())
+
+	// fetch one extra row to find out whether there is a next page
+	arg := db.ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		CursorCreatedAt:     cursorCreatedAt,
+		CursorID:            cursorID,
+		PageSize:            request.GetPageSize() + 1,
+	}
+
+	runs, err := server.store.ListScheduledTransferRuns(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list scheduled transfer runs: %s", err)
+	}
+
+	pageSize := int(request.GetPageSize())
+	response := &pb.ListScheduledTransferRunsResponse{
+		Runs: make([]*pb.ScheduledTransferRun, 0, pageSize),
+	}
+	if len(runs) > pageSize {
+		runs = runs[:pageSize]
+		last := runs[pageSize-1]
+		response.NextPageToken = util.EncodePageToken(last.CreatedAt, last.ID)
+	}
+
+	for _, run := range runs {
+		response.Runs = append(response.Runs, convertScheduledTransferRun(run))
+	}
+
+	return response, nil
+}
+
+func validateListScheduledTransferRunsRequest(request *pb.ListScheduledTransferRunsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetScheduledTransferId()); err != nil {
+		violations = append(violations, fieldViolation("scheduled_transfer_id", err))
+	}
+
+	if err := valid.ValidatePageSize(request.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	if _, _, err := util.DecodePageToken(request.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolation("page_token", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/Cell6969/go_bank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListScheduledTransfers(ctx context.Context, request *pb.ListScheduledTransfersRequest) (*pb.ListScheduledTransfersResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	scheduledTransfers, err := server.store.ListScheduledTransfers(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list scheduled transfers: %s", err)
+	}
+
+	response := &pb.ListScheduledTransfersResponse{
+		ScheduledTransfers: make([]*pb.ScheduledTransfer, 0, len(scheduledTransfers)),
+	}
+	for _, scheduledTransfer := range scheduledTransfers {
+		response.ScheduledTransfers = append(response.ScheduledTransfers, convertScheduledTransfer(scheduledTransfer))
+	}
+
+	return response, nil
+}
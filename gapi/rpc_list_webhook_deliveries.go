@@ -0,0 +1,77 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListWebhookDeliveries(ctx context.Context, request *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListWebhookDeliveriesRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	endpoint, err := server.authorizedWebhookEndpoint(ctx, authPayload, request.GetEndpointId())
+	if err != nil {
+		return nil, err
+	}
+
+	cursorCreatedAt, cursorID, _ := util.DecodePageToken(request.GetPageToken())
+
+	// fetch one extra row to find out whether there is a next page
+	arg := db.ListWebhookDeliveriesParams{
+		EndpointID:      endpoint.ID,
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		PageSize:        request.GetPageSize() + 1,
+	}
+
+	deliveries, err := server.store.ListWebhookDeliveries(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhook deliveries: %s", err)
+	}
+
+	pageSize := int(request.GetPageSize())
+	response := &pb.ListWebhookDeliveriesResponse{
+		Deliveries: make([]*pb.WebhookDelivery, 0, pageSize),
+	}
+	if len(deliveries) > pageSize {
+		deliveries = deliveries[:pageSize]
+		last := deliveries[pageSize-1]
+		response.NextPageToken = util.EncodePageToken(last.CreatedAt, last.ID)
+	}
+
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, convertWebhookDelivery(delivery))
+	}
+
+	return response, nil
+}
+
+func validateListWebhookDeliveriesRequest(request *pb.ListWebhookDeliveriesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetEndpointId()); err != nil {
+		violations = append(violations, fieldViolation("endpoint_id", err))
+	}
+
+	if err := valid.ValidatePageSize(request.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	if _, _, err := util.DecodePageToken(request.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolation("page_token", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/Cell6969/go_bank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListWebhookEndpoints(ctx context.Context, request *pb.ListWebhookEndpointsRequest) (*pb.ListWebhookEndpointsResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	endpoints, err := server.store.ListWebhookEndpoints(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhook endpoints: %s", err)
+	}
+
+	response := &pb.ListWebhookEndpointsResponse{
+		Endpoints: make([]*pb.WebhookEndpoint, 0, len(endpoints)),
+	}
+	for _, endpoint := range endpoints {
+		response.Endpoints = append(response.Endpoints, convertWebhookEndpoint(endpoint))
+	}
+
+	return response, nil
+}
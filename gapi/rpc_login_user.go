@@ -3,9 +3,11 @@ package gapi
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	db "github.com/Cell6969/go_bank/db/sqlc"
 	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/token"
 	"github.com/Cell6969/go_bank/util"
 	"github.com/Cell6969/go_bank/valid"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -36,39 +38,119 @@ func (server *Server) LoginUser(ctx context.Context, request *pb.LoginUserReques
 		return nil, status.Errorf(codes.Unauthenticated, "invalid credentials")
 	}
 
+	// users with multi-factor authentication get their tokens from VerifyMFA
+	mfaEnabled, err := server.isMFAEnabled(ctx, user.Username)
+	if err != nil {
+		return nil, err
+	}
+	if mfaEnabled {
+		return server.createMFAChallenge(ctx, user)
+	}
+
+	login, err := server.createLoginSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.LoginUserResponse{
+		User:                  convertUser(user),
+		SessionId:             login.session.ID.String(),
+		XToken:                login.accessToken,
+		XRefreshToken:         login.refreshToken,
+		TokenExpiredAt:        timestamppb.New(login.accessPayload.ExpiredAt),
+		RefreshTokenExpiredAt: timestamppb.New(login.refreshPayload.ExpiredAt),
+	}
+
+	return response, nil
+}
+
+// loginSession holds the tokens and the session of a completed login
+type loginSession struct {
+	session        db.Session
+	accessToken    string
+	accessPayload  *token.Payload
+	refreshToken   string
+	refreshPayload *token.Payload
+}
+
+// createLoginSession creates the access and refresh tokens of the user and stores the refresh token in a new session
+func (server *Server) createLoginSession(ctx context.Context, user db.User) (loginSession, error) {
+	var login loginSession
+	var err error
+
 	// Generate Token
-	token, access_payload, err := server.tokenMaker.CreateToken(user.Username, server.config.TokenDuration)
+	login.accessToken, login.accessPayload, err = server.tokenMaker.CreateToken(user.Username, user.Role, server.config.TokenDuration)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create token")
+		return login, status.Errorf(codes.Internal, "failed to create token")
 	}
 
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.RefreshTokenDuration)
+	login.refreshToken, login.refreshPayload, err = server.tokenMaker.CreateToken(user.Username, user.Role, server.config.RefreshTokenDuration)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create token")
+		return login, status.Errorf(codes.Internal, "failed to create token")
 	}
 
 	meta := server.extractMetaData(ctx)
 	arg := db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    meta.UserAgent,
-		ClientIp:     meta.ClientIp,
-		IsBlocked:    false,
-		ExpiredAt:    refreshPayload.ExpiredAt,
-	}
-	session, err := server.store.CreateSession(ctx, arg)
+		ID:              login.refreshPayload.ID,
+		Username:        user.Username,
+		RefreshToken:    login.refreshToken,
+		UserAgent:       meta.UserAgent,
+		ClientIp:        meta.ClientIp,
+		IsBlocked:       false,
+		FamilyID:        login.refreshPayload.ID,
+		FamilyExpiredAt: login.refreshPayload.ExpiredAt,
+		ExpiredAt:       login.refreshPayload.ExpiredAt,
+	}
+	login.session, err = server.store.CreateSession(ctx, arg)
+	if err != nil {
+		return login, status.Errorf(codes.Internal, "failed to create session")
+	}
+
+	return login, nil
+}
+
+// isMFAEnabled returns true when the user confirmed an authenticator app
+func (server *Server) isMFAEnabled(ctx context.Context, username string) (bool, error) {
+	enrollment, err := server.store.GetMFAEnrollment(ctx, username)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create session")
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, status.Errorf(codes.Internal, "failed to get mfa enrollment: %s", err)
+	}
+
+	return enrollment.IsEnabled, nil
+}
+
+// createMFAChallenge stores the hash of a new challenge token, the token itself is only returned to the client.
+// No challenge is issued while the user is locked out
+func (server *Server) createMFAChallenge(ctx context.Context, user db.User) (*pb.LoginUserResponse, error) {
+	err := server.store.CheckAuthLockout(ctx, user.Username)
+	if err != nil {
+		return nil, authLockoutError(err)
+	}
+
+	challengeToken, err := util.GenerateSecretToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create mfa challenge: %s", err)
+	}
+
+	meta := server.extractMetaData(ctx)
+	challenge, err := server.store.CreateMFAChallenge(ctx, db.CreateMFAChallengeParams{
+		Username:  user.Username,
+		TokenHash: util.HashSecretToken(challengeToken),
+		UserAgent: meta.UserAgent,
+		ClientIp:  meta.ClientIp,
+		ExpiredAt: time.Now().Add(server.config.MFAChallengeDuration),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create mfa challenge: %s", err)
 	}
 
 	response := &pb.LoginUserResponse{
-		User:                  convertUser(user),
-		SessionId:             session.ID.String(),
-		XToken:                token,
-		XRefreshToken:         refreshToken,
-		TokenExpiredAt:        timestamppb.New(access_payload.ExpiredAt),
-		RefreshTokenExpiredAt: timestamppb.New(refreshPayload.ExpiredAt),
+		MfaRequired:           true,
+		MfaChallengeToken:     challengeToken,
+		MfaChallengeExpiredAt: timestamppb.New(challenge.ExpiredAt),
 	}
 
 	return response, nil
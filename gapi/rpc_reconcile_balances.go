@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/reconcile"
+	"github.com/Cell6969/go_bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ReconcileBalances(ctx context.Context, request *pb.ReconcileBalancesRequest) (*pb.ReconcileBalancesResponse, error) {
+	_, err := server.authorizerUser(ctx, util.BankerRole)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	report, err := reconcile.NewReconciler(server.store).Run(ctx, request.GetFix())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reconcile balances: %s", err)
+	}
+
+	response := &pb.ReconcileBalancesResponse{
+		Drifts:             make([]*pb.BalanceDrift, 0, len(report.Drifts)),
+		UnbalancedJournals: make([]*pb.UnbalancedJournal, 0, len(report.UnbalancedJournals)),
+	}
+	for _, drift := range report.Drifts {
+		response.Drifts = append(response.Drifts, &pb.BalanceDrift{
+			AccountId:      drift.AccountID,
+			Owner:          drift.Owner,
+			Currency:       drift.Currency,
+			Balance:        drift.Balance,
+			EntriesTotal:   drift.EntriesTotal,
+			Drift:          drift.Drift,
+			JournalEntryId: drift.JournalEntryID,
+		})
+	}
+	for _, journal := range report.UnbalancedJournals {
+		response.UnbalancedJournals = append(response.UnbalancedJournals, &pb.UnbalancedJournal{
+			JournalEntryId: journal.JournalEntryID,
+			Currency:       journal.Currency,
+			Total:          journal.Total,
+		})
+	}
+
+	return response, nil
+}
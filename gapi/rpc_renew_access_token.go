@@ -0,0 +1,117 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) RenewAccessToken(ctx context.Context, request *pb.RenewAccessTokenRequest) (*pb.RenewAccessTokenResponse, error) {
+	violations := validateRenewAccessTokenRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(request.GetRefreshToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: %s", err)
+	}
+
+	session, err := server.store.GetSession(ctx, refreshPayload.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.Unauthenticated, "session not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find session")
+	}
+
+	if session.IsBlocked {
+		return nil, status.Errorf(codes.Unauthenticated, "session blocked")
+	}
+
+	if session.Username != refreshPayload.Username {
+		return nil, status.Errorf(codes.Unauthenticated, "incorrect user")
+	}
+
+	if session.RefreshToken != request.GetRefreshToken() {
+		return nil, status.Errorf(codes.Unauthenticated, "session not valid")
+	}
+
+	if time.Now().After(session.ExpiredAt) {
+		return nil, status.Errorf(codes.Unauthenticated, "session expired")
+	}
+
+	// the role is read again so that role changes apply on the next renewal
+	user, err := server.store.GetUser(ctx, session.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find user")
+	}
+
+	token, accessPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, server.config.TokenDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create token")
+	}
+
+	// the family of the login has an absolute expiry, renewals don't extend it
+	refreshTokenDuration := server.config.RefreshTokenDuration
+	if remaining := time.Until(session.FamilyExpiredAt); remaining < refreshTokenDuration {
+		refreshTokenDuration = remaining
+	}
+
+	refreshToken, newRefreshPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, refreshTokenDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create token")
+	}
+
+	meta := server.extractMetaData(ctx)
+	arg := db.RotateSessionTxParams{
+		ParentID: session.ID,
+		Session: db.CreateSessionParams{
+			ID:           newRefreshPayload.ID,
+			Username:     session.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    meta.UserAgent,
+			ClientIp:     meta.ClientIp,
+			IsBlocked:    false,
+			ExpiredAt:    newRefreshPayload.ExpiredAt,
+		},
+	}
+
+	result, err := server.store.RotateSessionTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrRefreshTokenReused) {
+			return nil, status.Errorf(codes.Unauthenticated, "refresh token already used, all sessions of this login are revoked")
+		}
+		if errors.Is(err, db.ErrSessionBlocked) || errors.Is(err, db.ErrSessionExpired) {
+			return nil, status.Errorf(codes.Unauthenticated, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to rotate session: %s", err)
+	}
+
+	response := &pb.RenewAccessTokenResponse{
+		SessionId:             result.Session.ID.String(),
+		XToken:                token,
+		XRefreshToken:         refreshToken,
+		TokenExpiredAt:        timestamppb.New(accessPayload.ExpiredAt),
+		RefreshTokenExpiredAt: timestamppb.New(result.Session.ExpiredAt),
+	}
+
+	return response, nil
+}
+
+func validateRenewAccessTokenRequest(request *pb.RenewAccessTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateString(request.GetRefreshToken(), 1, 1024); err != nil {
+		violations = append(violations, fieldViolation("refresh_token", err))
+	}
+
+	return violations
+}
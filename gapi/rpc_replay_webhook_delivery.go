@@ -0,0 +1,64 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"github.com/Cell6969/go_bank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ReplayWebhookDelivery(ctx context.Context, request *pb.ReplayWebhookDeliveryRequest) (*pb.ReplayWebhookDeliveryResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := valid.ValidateID(request.GetDeliveryId()); err != nil {
+		violations := []*errdetails.BadRequest_FieldViolation{
+			fieldViolation("delivery_id", err),
+		}
+		return nil, invalidArgumentError(violations)
+	}
+
+	delivery, err := server.store.GetWebhookDelivery(ctx, request.GetDeliveryId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "webhook delivery not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get webhook delivery: %s", err)
+	}
+
+	endpoint, err := server.authorizedWebhookEndpoint(ctx, authPayload, delivery.EndpointID)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "webhook delivery not found")
+		}
+		return nil, err
+	}
+
+	if !endpoint.IsActive {
+		return nil, status.Errorf(codes.FailedPrecondition, "webhook endpoint is deleted")
+	}
+
+	// the replay keeps the event id, so receivers can tell it apart from a new event
+	delivery, err = server.store.ResetWebhookDelivery(ctx, delivery.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reset webhook delivery: %s", err)
+	}
+
+	err = server.taskDistributor.DistributeTaskDeliverWebhook(ctx, &worker.PayloadDeliverWebhook{DeliveryID: delivery.ID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to enqueue webhook delivery: %s", err)
+	}
+
+	response := &pb.ReplayWebhookDeliveryResponse{
+		Delivery: convertWebhookDelivery(delivery),
+	}
+
+	return response, nil
+}
@@ -0,0 +1,38 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"github.com/Cell6969/go_bank/worker"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (server *Server) RequestPasswordReset(ctx context.Context, request *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	violations := validateRequestPasswordResetRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// the user is looked up by the task, every username gets the same response in the same time,
+	// so the RPC can't be used to find out who has an account
+	payload := &worker.PayloadSendResetPassword{
+		Username: request.GetUsername(),
+	}
+	err := server.taskDistributor.DistributeTaskSendResetPassword(ctx, payload, worker.Queue(worker.QueueCritical))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to enqueue reset password email")
+	}
+
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+func validateRequestPasswordResetRequest(request *pb.RequestPasswordResetRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateUsername(request.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,55 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ResetPassword(ctx context.Context, request *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	violations := validateResetPasswordRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hashedPassword, err := util.HashPassword(request.GetPassword())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
+	}
+
+	_, err = server.store.ResetPasswordTx(ctx, db.ResetPasswordTxParams{
+		TokenHash:      util.HashSecretToken(request.GetToken()),
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid or expired reset token")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reset password: %s", err)
+	}
+
+	response := &pb.ResetPasswordResponse{
+		IsReset: true,
+	}
+
+	return response, nil
+}
+
+func validateResetPasswordRequest(request *pb.ResetPasswordRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateString(request.GetToken(), 1, 128); err != nil {
+		violations = append(violations, fieldViolation("token", err))
+	}
+
+	if err := valid.ValidatePassword(request.GetPassword()); err != nil {
+		violations = append(violations, fieldViolation("password", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,94 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ReverseTransfer(ctx context.Context, request *pb.ReverseTransferRequest) (*pb.ReverseTransferResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateReverseTransferRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	transfer, err := server.store.GetTransfer(ctx, request.GetTransferId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "transfer [%d] not found", request.GetTransferId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get transfer: %s", err)
+	}
+
+	// the money is taken back from the recipient, so only the recipient may refund it, or a banker on their behalf
+	toAccount, err := server.findAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if toAccount.Owner != authPayload.Username && !authPayload.HasRole(util.BankerRole) {
+		return nil, status.Errorf(codes.PermissionDenied, "only the recipient of the transfer can reverse it")
+	}
+
+	result, err := server.store.ReverseTransferTx(ctx, db.ReverseTransferTxParams{
+		TransferID: transfer.ID,
+		Amount:     request.GetAmount(),
+		Reason:     request.GetReason(),
+		CreatedBy:  authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account [%d] has insufficient funds", transfer.ToAccountID)
+		}
+		if statusErr := accountStatusError(err); statusErr != nil {
+			return nil, statusErr
+		}
+		if errors.Is(err, db.ErrTransferAlreadyReversed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "transfer [%d] already reversed", transfer.ID)
+		}
+		if errors.Is(err, db.ErrReversalAmountTooLarge) {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount", err)})
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reverse transfer: %s", err)
+	}
+
+	response := &pb.ReverseTransferResponse{
+		Reversal:    convertTransferReversal(result.Reversal),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}
+
+	return response, nil
+}
+
+func validateReverseTransferRequest(request *pb.ReverseTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetTransferId()); err != nil {
+		violations = append(violations, fieldViolation("transfer_id", err))
+	}
+
+	if request.GetAmount() < 0 {
+		violations = append(violations, fieldViolation("amount", fmt.Errorf("must not be negative")))
+	}
+
+	if err := valid.ValidateString(request.GetReason(), 0, 255); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+
+	return violations
+}
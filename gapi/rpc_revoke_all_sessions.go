@@ -0,0 +1,27 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/Cell6969/go_bank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RevokeAllSessions(ctx context.Context, request *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	revoked, err := server.store.BlockUserSessions(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke sessions: %s", err)
+	}
+
+	response := &pb.RevokeAllSessionsResponse{
+		RevokedSessions: revoked,
+	}
+
+	return response, nil
+}
@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RevokeSession(ctx context.Context, request *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	sessionID, err := uuid.Parse(request.GetSessionId())
+	if err != nil {
+		violations := []*errdetails.BadRequest_FieldViolation{
+			fieldViolation("session_id", fmt.Errorf("must be a valid session id")),
+		}
+		return nil, invalidArgumentError(violations)
+	}
+
+	// the username filter makes sessions of other users look like they don't exist
+	arg := db.BlockSessionParams{
+		ID:       sessionID,
+		Username: authPayload.Username,
+	}
+
+	session, err := server.store.BlockSession(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke session: %s", err)
+	}
+
+	response := &pb.RevokeSessionResponse{
+		Session: convertSession(session),
+	}
+
+	return response, nil
+}
@@ -0,0 +1,90 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateAccountStatus(ctx context.Context, request *pb.UpdateAccountStatusRequest) (*pb.UpdateAccountStatusResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateAccountStatusRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.findAccount(ctx, request.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	// owners can only close their own active accounts, freezing, reopening and closing a frozen account
+	// is left to the bank
+	var fromStatus string
+	if !authPayload.HasRole(util.BankerRole) {
+		if account.Owner != authPayload.Username {
+			return nil, status.Errorf(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+		}
+		if request.GetStatus() != db.AccountClosed {
+			return nil, status.Errorf(codes.PermissionDenied, "only bankers can change an account to %s", request.GetStatus())
+		}
+		if account.Status != db.AccountActive {
+			return nil, status.Errorf(codes.PermissionDenied, "only bankers can change a %s account", account.Status)
+		}
+		// checked again under the lock, the bank may freeze the account in the meantime
+		fromStatus = db.AccountActive
+	}
+
+	result, err := server.store.ChangeAccountStatusTx(ctx, db.ChangeAccountStatusTxParams{
+		AccountID:  account.ID,
+		Status:     request.GetStatus(),
+		FromStatus: fromStatus,
+		Reason:     request.GetReason(),
+		ChangedBy:  authPayload.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account [%d] not found", account.ID)
+		}
+		if errors.Is(err, db.ErrInvalidStatusChange) || errors.Is(err, db.ErrAccountBalanceNotZero) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update account status: %s", err)
+	}
+
+	response := &pb.UpdateAccountStatusResponse{
+		Account:      convertAccount(result.Account),
+		StatusChange: convertAccountStatusChange(result.StatusChange),
+	}
+
+	return response, nil
+}
+
+func validateUpdateAccountStatusRequest(request *pb.UpdateAccountStatusRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if !db.IsSupportedAccountStatus(request.GetStatus()) {
+		violations = append(violations, fieldViolation("status", fmt.Errorf("unsupported account status: %s", request.GetStatus())))
+	}
+
+	if err := valid.ValidateString(request.GetReason(), 3, 255); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,117 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateAccountTransferLimits(ctx context.Context, request *pb.UpdateAccountTransferLimitsRequest) (*pb.UpdateAccountTransferLimitsResponse, error) {
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateAccountTransferLimitsRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.findAccount(ctx, request.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	// owners can only lower their limits, raising them over the defaults is left to the bank
+	if !authPayload.HasRole(util.BankerRole) {
+		if account.Owner != authPayload.Username {
+			return nil, status.Errorf(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+		}
+
+		defaults, err := server.store.GetTransferLimitDefault(ctx, account.Currency)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get default transfer limits: %s", err)
+		}
+
+		violations := validateTransferLimitsWithinDefaults(request, defaults)
+		if violations != nil {
+			return nil, invalidArgumentError(violations)
+		}
+	}
+
+	arg := db.UpsertAccountTransferLimitParams{
+		AccountID: account.ID,
+		UpdatedBy: authPayload.Username,
+	}
+	if request.MaxPerTransfer != nil {
+		arg.MaxPerTransfer = sql.NullInt64{Int64: request.GetMaxPerTransfer(), Valid: true}
+	}
+	if request.DailyAmount != nil {
+		arg.DailyAmount = sql.NullInt64{Int64: request.GetDailyAmount(), Valid: true}
+	}
+	if request.DailyCount != nil {
+		arg.DailyCount = sql.NullInt32{Int32: request.GetDailyCount(), Valid: true}
+	}
+
+	_, err = server.store.UpsertAccountTransferLimit(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update transfer limits: %s", err)
+	}
+
+	limit, allowance, err := server.transferLimits(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.UpdateAccountTransferLimitsResponse{
+		Limits:    convertTransferLimits(limit),
+		Allowance: convertTransferAllowance(allowance),
+	}
+
+	return response, nil
+}
+
+func validateUpdateAccountTransferLimitsRequest(request *pb.UpdateAccountTransferLimitsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if request.MaxPerTransfer != nil && request.GetMaxPerTransfer() <= 0 {
+		violations = append(violations, fieldViolation("max_per_transfer", fmt.Errorf("must be greater than 0")))
+	}
+
+	if request.DailyAmount != nil && request.GetDailyAmount() <= 0 {
+		violations = append(violations, fieldViolation("daily_amount", fmt.Errorf("must be greater than 0")))
+	}
+
+	if request.DailyCount != nil && request.GetDailyCount() <= 0 {
+		violations = append(violations, fieldViolation("daily_count", fmt.Errorf("must be greater than 0")))
+	}
+
+	return violations
+}
+
+// validateTransferLimitsWithinDefaults reports the requested limits that are over the defaults of the currency
+func validateTransferLimitsWithinDefaults(request *pb.UpdateAccountTransferLimitsRequest, defaults db.TransferLimitDefault) (violations []*errdetails.BadRequest_FieldViolation) {
+	if request.GetMaxPerTransfer() > defaults.MaxPerTransfer {
+		violations = append(violations, fieldViolation("max_per_transfer", fmt.Errorf("must be at most %d", defaults.MaxPerTransfer)))
+	}
+
+	if request.GetDailyAmount() > defaults.DailyAmount {
+		violations = append(violations, fieldViolation("daily_amount", fmt.Errorf("must be at most %d", defaults.DailyAmount)))
+	}
+
+	if request.GetDailyCount() > defaults.DailyCount {
+		violations = append(violations, fieldViolation("daily_count", fmt.Errorf("must be at most %d", defaults.DailyCount)))
+	}
+
+	return violations
+}
@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) VerifyEmail(ctx context.Context, request *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	violations := validateVerifyEmailRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := db.VerifyEmailTxParams{
+		EmailID:    request.GetEmailId(),
+		SecretCode: request.GetSecretCode(),
+	}
+
+	result, err := server.store.VerifyEmailTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid or expired verification code")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to verify email: %s", err)
+	}
+
+	response := &pb.VerifyEmailResponse{
+		IsVerified: result.User.IsEmailVerified,
+	}
+
+	return response, nil
+}
+
+func validateVerifyEmailRequest(request *pb.VerifyEmailRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetEmailId()); err != nil {
+		violations = append(violations, fieldViolation("email_id", err))
+	}
+
+	if err := valid.ValidateSecretCode(request.GetSecretCode()); err != nil {
+		violations = append(violations, fieldViolation("secret_code", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,64 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) VerifyMFA(ctx context.Context, request *pb.VerifyMFARequest) (*pb.VerifyMFAResponse, error) {
+	violations := validateVerifyMFARequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.VerifyMFATx(ctx, db.VerifyMFATxParams{
+		TokenHash: util.HashSecretToken(request.GetChallengeToken()),
+		Code:      request.GetCode(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAuthLocked) {
+			return nil, authLockoutError(err)
+		}
+		if errors.Is(err, db.ErrInvalidMFACode) || errors.Is(err, db.ErrMFAChallengeInvalid) || errors.Is(err, db.ErrMFANotEnabled) {
+			return nil, status.Errorf(codes.Unauthenticated, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to verify mfa: %s", err)
+	}
+
+	login, err := server.createLoginSession(ctx, result.User)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.VerifyMFAResponse{
+		User:                  convertUser(result.User),
+		SessionId:             login.session.ID.String(),
+		XToken:                login.accessToken,
+		XRefreshToken:         login.refreshToken,
+		TokenExpiredAt:        timestamppb.New(login.accessPayload.ExpiredAt),
+		RefreshTokenExpiredAt: timestamppb.New(login.refreshPayload.ExpiredAt),
+	}
+
+	return response, nil
+}
+
+func validateVerifyMFARequest(request *pb.VerifyMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateString(request.GetChallengeToken(), 1, 128); err != nil {
+		violations = append(violations, fieldViolation("challenge_token", err))
+	}
+
+	if err := valid.ValidateMFACode(request.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,174 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/valid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// watchCatchUpPageSize is how many missed entries are read at once when a stream starts
+	watchCatchUpPageSize = 100
+	// watchMaxCatchUpPages limits the missed entries sent by one stream,
+	// the client gets the rest by resuming from the last entry it received
+	watchMaxCatchUpPages = 10
+)
+
+// WatchAccount streams the account every time an entry posts to it.
+// The client resumes a broken stream by passing the id of the last entry it received as after_entry_id,
+// the entries posted since then are sent before the live ones. Without after_entry_id only new entries are sent.
+// Entry ids are taken when the entry is inserted, so an entry with a lower id that commits after the last one
+// the client received is not sent again on resume, clients that can't miss one reconcile with ListEntries
+func (server *Server) WatchAccount(request *pb.WatchAccountRequest, stream grpc.ServerStreamingServer[pb.WatchAccountResponse]) error {
+	ctx := stream.Context()
+
+	authPayload, err := server.authorizerUser(ctx)
+	if err != nil {
+		return unauthenticatedError(err)
+	}
+
+	violations := validateWatchAccountRequest(request)
+	if violations != nil {
+		return invalidArgumentError(violations)
+	}
+
+	account, err := server.authorizedAccount(ctx, authPayload, request.GetAccountId())
+	if err != nil {
+		return err
+	}
+
+	if server.entryNotifier == nil {
+		return status.Errorf(codes.Unavailable, "account notifications are not available")
+	}
+
+	// subscribe before reading the missed entries so no entry falls in between
+	subscription := server.entryNotifier.Subscribe(account.ID)
+	defer server.entryNotifier.Unsubscribe(subscription)
+
+	err = stream.Send(&pb.WatchAccountResponse{Account: convertAccount(account)})
+	if err != nil {
+		return err
+	}
+
+	// entries sent while catching up may be notified again, at most watchMaxCatchUpPages pages of them
+	sent := map[int64]bool{}
+	if afterID := request.GetAfterEntryId(); afterID > 0 {
+		for page := 0; ; page++ {
+			if page == watchMaxCatchUpPages {
+				return status.Errorf(codes.OutOfRange, "too many missed entries, resume with the last entry id")
+			}
+
+			entries, err := server.store.ListEntriesAfter(ctx, db.ListEntriesAfterParams{
+				AccountID: account.ID,
+				AfterID:   afterID,
+				PageSize:  watchCatchUpPageSize,
+			})
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to list entries: %s", err)
+			}
+
+			if err := server.sendAccountEntries(ctx, stream, account.ID, entries); err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				sent[entry.ID] = true
+				afterID = entry.ID
+			}
+
+			if len(entries) < watchCatchUpPageSize {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case entryID, ok := <-subscription.Entries():
+			if !ok {
+				return status.Errorf(codes.Unavailable, "account notifications interrupted, resume with the last entry id: %s", subscription.Err())
+			}
+
+			// the entries already waiting are sent together with a single read of the account
+			entryIDs := []int64{entryID}
+		drain:
+			for len(entryIDs) < watchCatchUpPageSize {
+				select {
+				case entryID, ok := <-subscription.Entries():
+					if !ok {
+						break drain
+					}
+					entryIDs = append(entryIDs, entryID)
+				default:
+					break drain
+				}
+			}
+
+			entries := make([]db.Entry, 0, len(entryIDs))
+			for _, entryID := range entryIDs {
+				if sent[entryID] {
+					delete(sent, entryID)
+					continue
+				}
+
+				// notifications come in commit order, once an entry committed after the catch-up
+				// shows up the remaining ones are new too
+				sent = nil
+
+				entry, err := server.store.GetEntry(ctx, entryID)
+				if err != nil {
+					return status.Errorf(codes.Internal, "failed to get entry: %s", err)
+				}
+				entries = append(entries, entry)
+			}
+
+			if err := server.sendAccountEntries(ctx, stream, account.ID, entries); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendAccountEntries sends the entries of the account, each together with the current state of the account
+func (server *Server) sendAccountEntries(ctx context.Context, stream grpc.ServerStreamingServer[pb.WatchAccountResponse], accountID int64, entries []db.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	for _, entry := range entries {
+		err := stream.Send(&pb.WatchAccountResponse{
+			Account: convertAccount(account),
+			Entry:   convertEntry(entry),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateWatchAccountRequest(request *pb.WatchAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := valid.ValidateID(request.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if request.GetAfterEntryId() < 0 {
+		violations = append(violations, fieldViolation("after_entry_id", fmt.Errorf("must not be negative")))
+	}
+
+	return violations
+}
@@ -0,0 +1,45 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/util"
+)
+
+func (server *Server) Withdraw(ctx context.Context, request *pb.WithdrawRequest) (*pb.WithdrawResponse, error) {
+	authPayload, err := server.authorizerUser(ctx, util.CashRoles...)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCashRequest(request)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.validAccount(ctx, "account_id", request.GetAccountId(), request.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.WithdrawTx(ctx, db.CashTxParams{
+		AccountID: request.GetAccountId(),
+		Amount:    request.GetAmount(),
+		Channel:   request.GetChannel(),
+		Reference: request.GetReference(),
+		CreatedBy: authPayload.Username,
+	})
+	if err != nil {
+		return nil, cashTxError(request, "withdraw", err)
+	}
+
+	response := &pb.WithdrawResponse{
+		CashTransaction: convertCashTransaction(result.CashTransaction),
+		Account:         convertAccount(result.Account),
+		Entry:           convertEntry(result.Entry),
+	}
+
+	return response, nil
+}
@@ -0,0 +1,29 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authorizedScheduledTransfer loads the scheduled transfer and makes sure it belongs to the authenticated user,
+// scheduled transfers of other users are reported as not found
+func (server *Server) authorizedScheduledTransfer(ctx context.Context, authPayload *token.Payload, scheduledTransferID int64) (db.ScheduledTransfer, error) {
+	scheduledTransfer, err := server.store.GetScheduledTransfer(ctx, scheduledTransferID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return scheduledTransfer, status.Errorf(codes.NotFound, "scheduled transfer not found")
+		}
+		return scheduledTransfer, status.Errorf(codes.Internal, "failed to get scheduled transfer: %s", err)
+	}
+
+	if scheduledTransfer.Owner != authPayload.Username {
+		return scheduledTransfer, status.Errorf(codes.NotFound, "scheduled transfer not found")
+	}
+
+	return scheduledTransfer, nil
+}
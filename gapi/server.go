@@ -4,30 +4,43 @@ import (
 	"fmt"
 
 	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/notify"
 	"github.com/Cell6969/go_bank/pb"
 	"github.com/Cell6969/go_bank/token"
 	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/worker"
 )
 
 // Server serves gRPC requests for banking service
 type Server struct {
 	pb.UnimplementedSimpleBankServer
-	config     util.Config
-	store      db.Store
-	tokenMaker token.Maker
+	config          util.Config
+	store           db.Store
+	tokenMaker      token.Maker
+	fxRateProvider  util.FXRateProvider
+	taskDistributor worker.TaskDistributor
+	entryNotifier   notify.EntryNotifier
 }
 
 // NewServer creates a new gRPC server.
-func NewServer(config util.Config, store db.Store) (*Server, error) {
+func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, entryNotifier notify.EntryNotifier) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.TokenKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	fxRateProvider, err := util.LoadFXRateProvider(config.FXRatesFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create fx rate provider: %w", err)
+	}
+
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:          config,
+		store:           store,
+		tokenMaker:      tokenMaker,
+		fxRateProvider:  fxRateProvider,
+		taskDistributor: taskDistributor,
+		entryNotifier:   entryNotifier,
 	}
 	return server, nil
 }
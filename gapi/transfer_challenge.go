@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stepUpAmount returns the amount above which a transfer in the currency needs a fresh proof of the user
+func (server *Server) stepUpAmount(ctx context.Context, currency string) (int64, error) {
+	defaults, err := server.store.GetTransferLimitDefault(ctx, currency)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get step-up amount: %s", err)
+	}
+
+	return defaults.StepUpAmount, nil
+}
+
+// createTransferChallenge stores the transfer until the user confirms it, bound to the access token of the request.
+// No challenge is issued while the user is locked out
+func (server *Server) createTransferChallenge(ctx context.Context, authPayload *token.Payload, arg db.TransferTxParams) (*pb.CreateTransferResponse, error) {
+	err := server.store.CheckAuthLockout(ctx, authPayload.Username)
+	if err != nil {
+		return nil, authLockoutError(err)
+	}
+
+	method := db.StepUpPassword
+	mfaEnabled, err := server.isMFAEnabled(ctx, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+	if mfaEnabled {
+		method = db.StepUpMFA
+	}
+
+	challenge, err := server.store.CreateTransferChallenge(ctx, db.CreateTransferChallengeParams{
+		TokenID:        authPayload.ID,
+		Username:       authPayload.Username,
+		FromAccountID:  arg.FromAccountId,
+		ToAccountID:    arg.ToAccountId,
+		Amount:         arg.Amount,
+		ExchangeRate:   arg.ExchangeRate,
+		IdempotencyKey: arg.IdempotencyKey,
+		Method:         method,
+		ExpiredAt:      time.Now().Add(server.config.StepUpDuration),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create transfer challenge: %s", err)
+	}
+
+	response := &pb.CreateTransferResponse{
+		StepUpRequired: true,
+		Challenge:      convertTransferChallenge(challenge),
+	}
+
+	return response, nil
+}
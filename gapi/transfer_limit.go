@@ -0,0 +1,26 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// transferLimits returns the limits in force for the account and what it may still send today
+func (server *Server) transferLimits(ctx context.Context, accountID int64) (db.GetAccountTransferLimitRow, db.TransferAllowance, error) {
+	var allowance db.TransferAllowance
+
+	limit, err := server.store.GetAccountTransferLimit(ctx, accountID)
+	if err != nil {
+		return limit, allowance, status.Errorf(codes.Internal, "failed to get transfer limits: %s", err)
+	}
+
+	total, err := server.store.GetDailyTransferTotal(ctx, accountID)
+	if err != nil {
+		return limit, allowance, status.Errorf(codes.Internal, "failed to get daily transfer total: %s", err)
+	}
+
+	return limit, db.NewTransferAllowance(limit, total), nil
+}
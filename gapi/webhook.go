@@ -0,0 +1,29 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authorizedWebhookEndpoint loads the endpoint and makes sure it belongs to the authenticated user,
+// endpoints of other users are reported as not found
+func (server *Server) authorizedWebhookEndpoint(ctx context.Context, authPayload *token.Payload, endpointID int64) (db.WebhookEndpoint, error) {
+	endpoint, err := server.store.GetWebhookEndpoint(ctx, endpointID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return endpoint, status.Errorf(codes.NotFound, "webhook endpoint not found")
+		}
+		return endpoint, status.Errorf(codes.Internal, "failed to get webhook endpoint: %s", err)
+	}
+
+	if endpoint.Owner != authPayload.Username {
+		return endpoint, status.Errorf(codes.NotFound, "webhook endpoint not found")
+	}
+
+	return endpoint, nil
+}
@@ -0,0 +1,25 @@
+package mail
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ResetPasswordLink builds the link of the page where the user chooses a new password with the given token
+func ResetPasswordLink(baseURL string, token string) string {
+	query := url.Values{}
+	query.Set("token", token)
+	return fmt.Sprintf("%s?%s", baseURL, query.Encode())
+}
+
+// NewResetPasswordEmail returns subject and content of the email letting a user reset a forgotten password
+func NewResetPasswordEmail(fullName string, link string, expiredAt time.Time) (subject string, content string) {
+	subject = "Reset your Simple Bank password"
+	content = fmt.Sprintf(`Hello %s,<br/>
+We received a request to reset your password.<br/>
+Please <a href="%s">click here</a> to choose a new one before %s.<br/>
+If you didn't ask for it, you can ignore this email, your password stays the same.<br/>
+`, fullName, link, expiredAt.UTC().Format(time.RFC1123))
+	return subject, content
+}
@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// EmailSender is an interface for sending emails
+type EmailSender interface {
+	SendEmail(subject string, content string, to []string) error
+}
+
+// LocalSender is an EmailSender for local development, it never talks to a mail server.
+// Emails are logged and, when a directory is set, written into it as one file per email
+type LocalSender struct {
+	dir string
+}
+
+// NewLocalSender creates a LocalSender writing into dir, an empty dir only logs the emails
+func NewLocalSender(dir string) (EmailSender, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("cannot create mail directory: %w", err)
+		}
+	}
+
+	return &LocalSender{dir: dir}, nil
+}
+
+func (sender *LocalSender) SendEmail(subject string, content string, to []string) error {
+	if len(to) == 0 {
+		return fmt.Errorf("email has no recipient")
+	}
+
+	log.Info().
+		Str("subject", subject).
+		Strs("to", to).
+		Msg("send email")
+
+	if sender.dir == "" {
+		return nil
+	}
+
+	message := fmt.Sprintf("To: %s\r\nSubject: %s\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s\r\n",
+		strings.Join(to, ", "), subject, content)
+
+	name := fmt.Sprintf("%d.eml", time.Now().UnixNano())
+	return os.WriteFile(filepath.Join(sender.dir, name), []byte(message), 0o644)
+}
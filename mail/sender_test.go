@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalSenderWritesEmail(t *testing.T) {
+	dir := t.TempDir()
+
+	sender, err := NewLocalSender(dir)
+	require.NoError(t, err)
+
+	err = sender.SendEmail("Welcome", "<p>verify your email</p>", []string{"user@example.com"})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Contains(t, string(data), "To: user@example.com")
+	require.Contains(t, string(data), "Subject: Welcome")
+	require.Contains(t, string(data), "<p>verify your email</p>")
+}
+
+func TestLocalSenderNoRecipient(t *testing.T) {
+	sender, err := NewLocalSender("")
+	require.NoError(t, err)
+
+	err = sender.SendEmail("Welcome", "content", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package mail
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// VerifyEmailLink builds the link that verifies an email with the given secret code
+func VerifyEmailLink(baseURL string, emailID int64, secretCode string) string {
+	query := url.Values{}
+	query.Set("email_id", fmt.Sprint(emailID))
+	query.Set("secret_code", secretCode)
+	return fmt.Sprintf("%s?%s", baseURL, query.Encode())
+}
+
+// NewVerifyEmail returns subject and content of the email asking a new user to verify the email address
+func NewVerifyEmail(fullName string, link string) (subject string, content string) {
+	subject = "Welcome to Simple Bank"
+	content = fmt.Sprintf(`Hello %s,<br/>
+Thank you for registering with us!<br/>
+Please <a href="%s">click here</a> to verify your email address.<br/>
+`, fullName, link)
+	return subject, content
+}
@@ -15,8 +15,13 @@ import (
 	db "github.com/Cell6969/go_bank/db/sqlc"
 	_ "github.com/Cell6969/go_bank/doc/statik"
 	"github.com/Cell6969/go_bank/gapi"
+	"github.com/Cell6969/go_bank/mail"
+	"github.com/Cell6969/go_bank/notify"
+	"github.com/Cell6969/go_bank/outbox"
 	"github.com/Cell6969/go_bank/pb"
+	"github.com/Cell6969/go_bank/scheduler"
 	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/worker"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
@@ -49,9 +54,15 @@ func main() {
 
 	store := db.NewStore(conn)
 
-	// runGinServer(config, store)
-	go runGatewayServer(config, store)
-	runGrpcServer(config, store)
+	taskDistributor := worker.NewPostgresTaskDistributor(store)
+	runTaskProcessor(config, store)
+	runOutboxRelay(config, store, taskDistributor)
+	runScheduler(config, store)
+	entryNotifier := runEntryListener(config)
+
+	// runGinServer(config, store, taskDistributor)
+	go runGatewayServer(config, store, taskDistributor, entryNotifier)
+	runGrpcServer(config, store, taskDistributor, entryNotifier)
 }
 
 func runDBMigration(migrationURL string, dbSource string) {
@@ -66,8 +77,56 @@ func runDBMigration(migrationURL string, dbSource string) {
 	log.Info().Msg("db migration successfully")
 }
 
-func runGinServer(config util.Config, store db.Store) {
-	server, err := api.NewServer(config, store)
+func runTaskProcessor(config util.Config, store db.Store) {
+	mailer, err := mail.NewLocalSender(config.MailDir)
+	if err != nil {
+		log.Fatal().Msg("cannot create email sender")
+	}
+
+	taskProcessor := worker.NewPostgresTaskProcessor(config, store, mailer)
+	taskProcessor.Start()
+}
+
+func runOutboxRelay(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
+	var publisher outbox.Publisher = outbox.NewMemoryPublisher()
+	if config.OutboxFile != "" {
+		filePublisher, err := outbox.NewFilePublisher(config.OutboxFile)
+		if err != nil {
+			log.Fatal().Msg("cannot create outbox publisher")
+		}
+		publisher = filePublisher
+	}
+
+	// the events are also pushed to the webhook endpoints of the account owners
+	publisher = outbox.NewMultiPublisher(publisher, worker.NewWebhookPublisher(taskDistributor))
+
+	relay := outbox.NewRelay(store, publisher)
+	relay.Start()
+}
+
+func runScheduler(config util.Config, store db.Store) {
+	fxRateProvider, err := util.LoadFXRateProvider(config.FXRatesFile)
+	if err != nil {
+		log.Fatal().Msg("cannot create fx rate provider")
+	}
+
+	transferScheduler := scheduler.NewScheduler(store, fxRateProvider)
+	transferScheduler.Start()
+}
+
+func runEntryListener(config util.Config) notify.EntryNotifier {
+	hub := notify.NewHub()
+
+	listener := notify.NewPostgresListener(config.DBSource, hub)
+	if err := listener.Start(); err != nil {
+		log.Fatal().Msg("cannot start entry listener")
+	}
+
+	return hub
+}
+
+func runGinServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
+	server, err := api.NewServer(config, store, taskDistributor)
 	if err != nil {
 		log.Fatal().Msg("cannot create server")
 	}
@@ -78,17 +137,18 @@ func runGinServer(config util.Config, store db.Store) {
 	}
 }
 
-func runGrpcServer(config util.Config, store db.Store) {
+func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, entryNotifier notify.EntryNotifier) {
 	// Initialize api for grpc server
-	server, err := gapi.NewServer(config, store)
+	server, err := gapi.NewServer(config, store, taskDistributor, entryNotifier)
 	if err != nil {
 		log.Fatal().Msg("cannot create server")
 	}
 	// Add grpc log
 	grpcLogerr := grpc.UnaryInterceptor(gapi.GrpcLogger)
+	grpcStreamLogger := grpc.StreamInterceptor(gapi.GrpcStreamLogger)
 
 	// initialize grpc
-	grpcServer := grpc.NewServer(grpcLogerr)
+	grpcServer := grpc.NewServer(grpcLogerr, grpcStreamLogger)
 
 	// register protobuf into grpc
 	pb.RegisterSimpleBankServer(grpcServer, server)
@@ -111,9 +171,9 @@ func runGrpcServer(config util.Config, store db.Store) {
 	}
 }
 
-func runGatewayServer(config util.Config, store db.Store) {
+func runGatewayServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, entryNotifier notify.EntryNotifier) {
 	// Initialize api for grpc server
-	server, err := gapi.NewServer(config, store)
+	server, err := gapi.NewServer(config, store, taskDistributor, entryNotifier)
 	if err != nil {
 		log.Fatal().Msg("cannot create server")
 	}
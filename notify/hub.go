@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"errors"
+	"sync"
+)
+
+// subscriptionBuffer is how many notifications a subscriber may fall behind before it is dropped
+const subscriptionBuffer = 100
+
+var (
+	// ErrSubscriberTooSlow is reported by a subscription closed because it fell behind
+	ErrSubscriberTooSlow = errors.New("subscriber is too slow")
+	// ErrNotificationsLost is reported by a subscription closed because notifications may have been missed
+	ErrNotificationsLost = errors.New("notifications may have been lost")
+)
+
+// EntryNotification tells that an entry was posted to an account
+type EntryNotification struct {
+	AccountID int64 `json:"account_id"`
+	EntryID   int64 `json:"entry_id"`
+}
+
+// EntryNotifier lets subscribers follow the entries posted to an account
+type EntryNotifier interface {
+	Subscribe(accountID int64) *Subscription
+	Unsubscribe(subscription *Subscription)
+}
+
+// Subscription receives the ids of the entries posted to one account.
+// The channel is closed when the subscription ends, Err tells why
+type Subscription struct {
+	accountID int64
+	entries   chan int64
+	err       error
+}
+
+// Entries returns the channel of posted entry ids
+func (subscription *Subscription) Entries() <-chan int64 {
+	return subscription.entries
+}
+
+// Err returns the reason the subscription was closed, it must only be called once the channel is closed.
+// It is nil after an Unsubscribe
+func (subscription *Subscription) Err() error {
+	return subscription.err
+}
+
+// Hub fans out entry notifications to the subscribers of each account
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[int64]map[*Subscription]struct{}
+}
+
+// NewHub creates a new hub without subscribers
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[int64]map[*Subscription]struct{}),
+	}
+}
+
+func (hub *Hub) Subscribe(accountID int64) *Subscription {
+	subscription := &Subscription{
+		accountID: accountID,
+		entries:   make(chan int64, subscriptionBuffer),
+	}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	if hub.subscribers[accountID] == nil {
+		hub.subscribers[accountID] = make(map[*Subscription]struct{})
+	}
+	hub.subscribers[accountID][subscription] = struct{}{}
+	return subscription
+}
+
+func (hub *Hub) Unsubscribe(subscription *Subscription) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	hub.remove(subscription, nil)
+}
+
+// Publish hands the notification to the subscribers of the account.
+// It never blocks, a subscriber whose buffer is full is closed with ErrSubscriberTooSlow
+func (hub *Hub) Publish(notification EntryNotification) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	for subscription := range hub.subscribers[notification.AccountID] {
+		select {
+		case subscription.entries <- notification.EntryID:
+		default:
+			hub.remove(subscription, ErrSubscriberTooSlow)
+		}
+	}
+}
+
+// CloseAll closes every subscription with the given error
+func (hub *Hub) CloseAll(err error) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	for _, subscriptions := range hub.subscribers {
+		for subscription := range subscriptions {
+			hub.remove(subscription, err)
+		}
+	}
+}
+
+// remove closes the subscription, the caller must hold the lock
+func (hub *Hub) remove(subscription *Subscription, err error) {
+	subscriptions, ok := hub.subscribers[subscription.accountID]
+	if !ok {
+		return
+	}
+	if _, ok := subscriptions[subscription]; !ok {
+		return
+	}
+
+	delete(subscriptions, subscription)
+	if len(subscriptions) == 0 {
+		delete(hub.subscribers, subscription.accountID)
+	}
+
+	subscription.err = err
+	close(subscription.entries)
+}
@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHubPublish(t *testing.T) {
+	hub := NewHub()
+
+	subscription1 := hub.Subscribe(1)
+	subscription2 := hub.Subscribe(1)
+	other := hub.Subscribe(2)
+
+	hub.Publish(EntryNotification{AccountID: 1, EntryID: 10})
+
+	require.Equal(t, int64(10), <-subscription1.Entries())
+	require.Equal(t, int64(10), <-subscription2.Entries())
+	require.Empty(t, other.Entries())
+
+	hub.Unsubscribe(subscription1)
+	_, ok := <-subscription1.Entries()
+	require.False(t, ok)
+	require.NoError(t, subscription1.Err())
+
+	// unsubscribing twice is a no-op
+	hub.Unsubscribe(subscription1)
+
+	hub.Publish(EntryNotification{AccountID: 1, EntryID: 11})
+	require.Equal(t, int64(11), <-subscription2.Entries())
+}
+
+func TestHubSlowSubscriber(t *testing.T) {
+	hub := NewHub()
+	subscription := hub.Subscribe(1)
+
+	for i := 0; i <= subscriptionBuffer; i++ {
+		hub.Publish(EntryNotification{AccountID: 1, EntryID: int64(i + 1)})
+	}
+
+	received := 0
+	for range subscription.Entries() {
+		received++
+	}
+	require.Equal(t, subscriptionBuffer, received)
+	require.ErrorIs(t, subscription.Err(), ErrSubscriberTooSlow)
+}
+
+func TestHubCloseAll(t *testing.T) {
+	hub := NewHub()
+	subscription1 := hub.Subscribe(1)
+	subscription2 := hub.Subscribe(2)
+
+	hub.CloseAll(ErrNotificationsLost)
+
+	for _, subscription := range []*Subscription{subscription1, subscription2} {
+		_, ok := <-subscription.Entries()
+		require.False(t, ok)
+		require.ErrorIs(t, subscription.Err(), ErrNotificationsLost)
+	}
+
+	// a new subscription after the reset works again
+	subscription3 := hub.Subscribe(1)
+	hub.Publish(EntryNotification{AccountID: 1, EntryID: 5})
+	require.Equal(t, int64(5), <-subscription3.Entries())
+}
+
+func TestParseEntryNotification(t *testing.T) {
+	notification, err := parseEntryNotification(`{"account_id": 3, "entry_id": 42}`)
+	require.NoError(t, err)
+	require.Equal(t, EntryNotification{AccountID: 3, EntryID: 42}, notification)
+
+	_, err = parseEntryNotification(`not json`)
+	require.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// EntryChannel is the Postgres channel the entries trigger notifies on
+const EntryChannel = "entry_created"
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+)
+
+// PostgresListener receives the entry notifications with LISTEN and publishes them to the hub
+type PostgresListener struct {
+	hub      *Hub
+	listener *pq.Listener
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewPostgresListener creates a listener on its own connection to the database
+func NewPostgresListener(dbSource string, hub *Hub) *PostgresListener {
+	listener := pq.NewListener(dbSource, minReconnectInterval, maxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Error().Err(err).Msg("entry listener connection problem")
+		}
+	})
+
+	return &PostgresListener{
+		hub:      hub,
+		listener: listener,
+		done:     make(chan struct{}),
+	}
+}
+
+// Start listens on the entry channel and runs the fan out in a goroutine, it returns immediately
+func (listener *PostgresListener) Start() error {
+	if err := listener.listener.Listen(EntryChannel); err != nil {
+		return err
+	}
+
+	log.Info().Str("channel", EntryChannel).Msg("start entry listener")
+
+	listener.wg.Add(1)
+	go func() {
+		defer listener.wg.Done()
+		listener.run()
+	}()
+	return nil
+}
+
+// Shutdown stops listening and closes all subscriptions
+func (listener *PostgresListener) Shutdown() {
+	close(listener.done)
+	listener.wg.Wait()
+	listener.listener.Close()
+	listener.hub.CloseAll(ErrNotificationsLost)
+}
+
+func (listener *PostgresListener) run() {
+	for {
+		select {
+		case <-listener.done:
+			return
+		case notification := <-listener.listener.Notify:
+			listener.handle(notification)
+		}
+	}
+}
+
+func (listener *PostgresListener) handle(notification *pq.Notification) {
+	// a nil notification is sent after the connection was re-established,
+	// the notifications sent in between are gone so the subscribers have to resume
+	if notification == nil {
+		log.Warn().Msg("entry listener reconnected")
+		listener.hub.CloseAll(ErrNotificationsLost)
+		return
+	}
+
+	entry, err := parseEntryNotification(notification.Extra)
+	if err != nil {
+		log.Error().Err(err).Str("payload", notification.Extra).Msg("invalid entry notification")
+		return
+	}
+
+	listener.hub.Publish(entry)
+}
+
+func parseEntryNotification(payload string) (EntryNotification, error) {
+	var notification EntryNotification
+	err := json.Unmarshal([]byte(payload), &notification)
+	return notification, err
+}
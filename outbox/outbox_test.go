@@ -0,0 +1,150 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mockdb "github.com/Cell6969/go_bank/db/mock"
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func randomOutboxEvent() db.OutboxEvent {
+	return db.OutboxEvent{
+		ID:            uuid.New(),
+		AggregateType: db.AggregateTransfer,
+		AggregateID:   "1",
+		EventType:     db.EventTransferCompleted,
+		Payload:       json.RawMessage(`{"transfer_id":1}`),
+		CreatedAt:     time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "outbox.jsonl")
+
+	publisher, err := NewFilePublisher(path)
+	require.NoError(t, err)
+
+	events := []Event{NewEvent(randomOutboxEvent()), NewEvent(randomOutboxEvent())}
+	for _, event := range events {
+		require.NoError(t, publisher.Publish(context.Background(), event))
+	}
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var published []Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		published = append(published, event)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, published, 2)
+	for i := range events {
+		require.Equal(t, events[i].ID, published[i].ID)
+		require.Equal(t, events[i].Type, published[i].Type)
+		require.JSONEq(t, string(events[i].Payload), string(published[i].Payload))
+	}
+}
+
+type failingPublisher struct {
+	failing   map[uuid.UUID]bool
+	published []Event
+}
+
+func (publisher *failingPublisher) Publish(ctx context.Context, event Event) error {
+	if publisher.failing[event.ID] {
+		return errors.New("broker unavailable")
+	}
+	publisher.published = append(publisher.published, event)
+	return nil
+}
+
+func TestRelayBatch(t *testing.T) {
+	events := []db.OutboxEvent{randomOutboxEvent(), randomOutboxEvent(), randomOutboxEvent()}
+
+	testCases := []struct {
+		name      string
+		publisher *failingPublisher
+		published []db.OutboxEvent
+		wantErr   bool
+	}{
+		{
+			name:      "OK",
+			publisher: &failingPublisher{},
+			published: events,
+		},
+		{
+			name:      "First Event Fails",
+			publisher: &failingPublisher{failing: map[uuid.UUID]bool{events[0].ID: true}},
+			published: events[1:],
+			wantErr:   true,
+		},
+		{
+			name: "Publisher Error",
+			publisher: &failingPublisher{failing: map[uuid.UUID]bool{
+				events[0].ID: true,
+				events[1].ID: true,
+				events[2].ID: true,
+			}},
+			wantErr: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// the mock behaves like the transaction, it skips the failed events and returns the first error
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				PublishOutboxEventsTx(gomock.Any(), gomock.Eq(int32(defaultBatchSize)), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, _ int32, publish func(event db.OutboxEvent) error) (int, error) {
+					published := 0
+					var firstErr error
+					for _, event := range events {
+						if err := publish(event); err != nil {
+							if firstErr == nil {
+								firstErr = err
+							}
+							continue
+						}
+						published++
+					}
+					return published, firstErr
+				})
+
+			relay := NewRelay(store, tc.publisher)
+			published, err := relay.relayBatch(context.Background())
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, len(tc.published), published)
+			require.Len(t, tc.publisher.published, len(tc.published))
+
+			for i, event := range tc.publisher.published {
+				require.Equal(t, tc.published[i].ID, event.ID)
+				require.Equal(t, db.EventTransferCompleted, event.Type)
+			}
+		})
+	}
+}
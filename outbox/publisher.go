@@ -0,0 +1,139 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// Event is a domain event published to other systems.
+// ID is stable across redeliveries, consumers use it to drop duplicates
+type Event struct {
+	ID            uuid.UUID       `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// NewEvent converts an outbox row into the published event
+func NewEvent(event db.OutboxEvent) Event {
+	return Event{
+		ID:            event.ID,
+		Type:          event.EventType,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		Payload:       event.Payload,
+		CreatedAt:     event.CreatedAt,
+	}
+}
+
+// Publisher is an interface for delivering events to other systems
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// MemoryPublisher keeps the published events in memory, it is meant for tests and local runs
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// NewMemoryPublisher creates a new in-memory publisher
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (publisher *MemoryPublisher) Publish(ctx context.Context, event Event) error {
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+
+	publisher.events = append(publisher.events, event)
+	return nil
+}
+
+// Events returns a copy of the events published so far
+func (publisher *MemoryPublisher) Events() []Event {
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+
+	events := make([]Event, len(publisher.events))
+	copy(events, publisher.events)
+	return events
+}
+
+// FilePublisher appends every event as one JSON line to a file
+type FilePublisher struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFilePublisher creates a publisher appending to the file at path, missing directories are created
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	if path == "" {
+		return nil, fmt.Errorf("outbox file path must not be empty")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("cannot create outbox directory: %w", err)
+	}
+
+	return &FilePublisher{path: path}, nil
+}
+
+func (publisher *FilePublisher) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+
+	file, err := os.OpenFile(publisher.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+
+	// the event is only reported as published once it is on disk
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// MultiPublisher publishes every event to all of its publishers in order.
+// It stops at the first error, so an event may reach the first publishers more than once
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+// NewMultiPublisher creates a publisher fanning out the events to the given publishers
+func NewMultiPublisher(publishers ...Publisher) *MultiPublisher {
+	return &MultiPublisher{
+		publishers: publishers,
+	}
+}
+
+func (publisher *MultiPublisher) Publish(ctx context.Context, event Event) error {
+	for _, p := range publisher.publishers {
+		if err := p.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Second
+)
+
+// Relay moves the events written to the outbox table to the publisher
+type Relay struct {
+	store        db.Store
+	publisher    Publisher
+	batchSize    int32
+	pollInterval time.Duration
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+// NewRelay creates a new relay publishing the outbox events of the store
+func NewRelay(store db.Store, publisher Publisher) *Relay {
+	return &Relay{
+		store:        store,
+		publisher:    publisher,
+		batchSize:    defaultBatchSize,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// Start runs the relay in a goroutine, it returns immediately
+func (relay *Relay) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	relay.cancel = cancel
+
+	log.Info().Msg("start outbox relay")
+
+	relay.wg.Add(1)
+	go func() {
+		defer relay.wg.Done()
+		relay.run(ctx)
+	}()
+}
+
+// Shutdown stops the relay and waits for the current batch to finish
+func (relay *Relay) Shutdown() {
+	if relay.cancel != nil {
+		relay.cancel()
+	}
+	relay.wg.Wait()
+}
+
+func (relay *Relay) run(ctx context.Context) {
+	for {
+		published, err := relay.relayBatch(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to relay outbox events")
+		}
+
+		// keep draining while full batches are published
+		if err == nil && published == int(relay.batchSize) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(relay.pollInterval):
+		}
+	}
+}
+
+// relayBatch publishes one batch of events and returns how many were published
+func (relay *Relay) relayBatch(ctx context.Context) (int, error) {
+	return relay.store.PublishOutboxEventsTx(ctx, relay.batchSize, func(event db.OutboxEvent) error {
+		return relay.publisher.Publish(ctx, NewEvent(event))
+	})
+}
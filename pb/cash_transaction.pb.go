@@ -0,0 +1,211 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: cash_transaction.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CashTransaction struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId int64                  `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// deposit or withdrawal
+	Type           string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Amount         int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Channel        string                 `protobuf:"bytes,5,opt,name=channel,proto3" json:"channel,omitempty"`
+	Reference      string                 `protobuf:"bytes,6,opt,name=reference,proto3" json:"reference,omitempty"`
+	JournalEntryId int64                  `protobuf:"varint,7,opt,name=journal_entry_id,json=journalEntryId,proto3" json:"journal_entry_id,omitempty"`
+	EntryId        int64                  `protobuf:"varint,8,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	CreatedBy      string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CashTransaction) Reset() {
+	*x = CashTransaction{}
+	mi := &file_cash_transaction_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CashTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CashTransaction) ProtoMessage() {}
+
+func (x *CashTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_cash_transaction_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CashTransaction.ProtoReflect.Descriptor instead.
+func (*CashTransaction) Descriptor() ([]byte, []int) {
+	return file_cash_transaction_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CashTransaction) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CashTransaction) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *CashTransaction) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CashTransaction) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CashTransaction) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *CashTransaction) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *CashTransaction) GetJournalEntryId() int64 {
+	if x != nil {
+		return x.JournalEntryId
+	}
+	return 0
+}
+
+func (x *CashTransaction) GetEntryId() int64 {
+	if x != nil {
+		return x.EntryId
+	}
+	return 0
+}
+
+func (x *CashTransaction) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *CashTransaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_cash_transaction_proto protoreflect.FileDescriptor
+
+const file_cash_transaction_proto_rawDesc = "" +
+	"\n" +
+	"\x16cash_transaction.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc3\x02\n" +
+	"\x0fCashTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\x03R\taccountId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x18\n" +
+	"\achannel\x18\x05 \x01(\tR\achannel\x12\x1c\n" +
+	"\treference\x18\x06 \x01(\tR\treference\x12(\n" +
+	"\x10journal_entry_id\x18\a \x01(\x03R\x0ejournalEntryId\x12\x19\n" +
+	"\bentry_id\x18\b \x01(\x03R\aentryId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_cash_transaction_proto_rawDescOnce sync.Once
+	file_cash_transaction_proto_rawDescData []byte
+)
+
+func file_cash_transaction_proto_rawDescGZIP() []byte {
+	file_cash_transaction_proto_rawDescOnce.Do(func() {
+		file_cash_transaction_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cash_transaction_proto_rawDesc), len(file_cash_transaction_proto_rawDesc)))
+	})
+	return file_cash_transaction_proto_rawDescData
+}
+
+var file_cash_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_cash_transaction_proto_goTypes = []any{
+	(*CashTransaction)(nil),       // 0: pb.CashTransaction
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_cash_transaction_proto_depIdxs = []int32{
+	1, // 0: pb.CashTransaction.created_at:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_cash_transaction_proto_init() }
+func file_cash_transaction_proto_init() {
+	if File_cash_transaction_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cash_transaction_proto_rawDesc), len(file_cash_transaction_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_cash_transaction_proto_goTypes,
+		DependencyIndexes: file_cash_transaction_proto_depIdxs,
+		MessageInfos:      file_cash_transaction_proto_msgTypes,
+	}.Build()
+	File_cash_transaction_proto = out.File
+	file_cash_transaction_proto_goTypes = nil
+	file_cash_transaction_proto_depIdxs = nil
+}
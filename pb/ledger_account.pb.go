@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: ledger_account.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LedgerAccount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code  string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name  string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// asset, liability, equity, revenue or expense
+	Type     string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Currency string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	// sum of the postings to the account
+	Balance       int64 `protobuf:"varint,6,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerAccount) Reset() {
+	*x = LedgerAccount{}
+	mi := &file_ledger_account_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerAccount) ProtoMessage() {}
+
+func (x *LedgerAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_account_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerAccount.ProtoReflect.Descriptor instead.
+func (*LedgerAccount) Descriptor() ([]byte, []int) {
+	return file_ledger_account_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LedgerAccount) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LedgerAccount) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LedgerAccount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LedgerAccount) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LedgerAccount) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *LedgerAccount) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+var File_ledger_account_proto protoreflect.FileDescriptor
+
+const file_ledger_account_proto_rawDesc = "" +
+	"\n" +
+	"\x14ledger_account.proto\x12\x02pb\"\x91\x01\n" +
+	"\rLedgerAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x18\n" +
+	"\abalance\x18\x06 \x01(\x03R\abalanceB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_ledger_account_proto_rawDescOnce sync.Once
+	file_ledger_account_proto_rawDescData []byte
+)
+
+func file_ledger_account_proto_rawDescGZIP() []byte {
+	file_ledger_account_proto_rawDescOnce.Do(func() {
+		file_ledger_account_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ledger_account_proto_rawDesc), len(file_ledger_account_proto_rawDesc)))
+	})
+	return file_ledger_account_proto_rawDescData
+}
+
+var file_ledger_account_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_ledger_account_proto_goTypes = []any{
+	(*LedgerAccount)(nil), // 0: pb.LedgerAccount
+}
+var file_ledger_account_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_ledger_account_proto_init() }
+func file_ledger_account_proto_init() {
+	if File_ledger_account_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ledger_account_proto_rawDesc), len(file_ledger_account_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ledger_account_proto_goTypes,
+		DependencyIndexes: file_ledger_account_proto_depIdxs,
+		MessageInfos:      file_ledger_account_proto_msgTypes,
+	}.Build()
+	File_ledger_account_proto = out.File
+	file_ledger_account_proto_goTypes = nil
+	file_ledger_account_proto_depIdxs = nil
+}
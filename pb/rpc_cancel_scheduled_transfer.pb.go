@@ -0,0 +1,172 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_cancel_scheduled_transfer.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CancelScheduledTransferRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledTransferId int64                  `protobuf:"varint,1,opt,name=scheduled_transfer_id,json=scheduledTransferId,proto3" json:"scheduled_transfer_id,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CancelScheduledTransferRequest) Reset() {
+	*x = CancelScheduledTransferRequest{}
+	mi := &file_rpc_cancel_scheduled_transfer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledTransferRequest) ProtoMessage() {}
+
+func (x *CancelScheduledTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_cancel_scheduled_transfer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledTransferRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledTransferRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_cancel_scheduled_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CancelScheduledTransferRequest) GetScheduledTransferId() int64 {
+	if x != nil {
+		return x.ScheduledTransferId
+	}
+	return 0
+}
+
+type CancelScheduledTransferResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledTransfer *ScheduledTransfer     `protobuf:"bytes,1,opt,name=scheduled_transfer,json=scheduledTransfer,proto3" json:"scheduled_transfer,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CancelScheduledTransferResponse) Reset() {
+	*x = CancelScheduledTransferResponse{}
+	mi := &file_rpc_cancel_scheduled_transfer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledTransferResponse) ProtoMessage() {}
+
+func (x *CancelScheduledTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_cancel_scheduled_transfer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledTransferResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledTransferResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_cancel_scheduled_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CancelScheduledTransferResponse) GetScheduledTransfer() *ScheduledTransfer {
+	if x != nil {
+		return x.ScheduledTransfer
+	}
+	return nil
+}
+
+var File_rpc_cancel_scheduled_transfer_proto protoreflect.FileDescriptor
+
+const file_rpc_cancel_scheduled_transfer_proto_rawDesc = "" +
+	"\n" +
+	"#rpc_cancel_scheduled_transfer.proto\x12\x02pb\x1a\x18scheduled_transfer.proto\"T\n" +
+	"\x1eCancelScheduledTransferRequest\x122\n" +
+	"\x15scheduled_transfer_id\x18\x01 \x01(\x03R\x13scheduledTransferId\"g\n" +
+	"\x1fCancelScheduledTransferResponse\x12D\n" +
+	"\x12scheduled_transfer\x18\x01 \x01(\v2\x15.pb.ScheduledTransferR\x11scheduledTransferB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_cancel_scheduled_transfer_proto_rawDescOnce sync.Once
+	file_rpc_cancel_scheduled_transfer_proto_rawDescData []byte
+)
+
+func file_rpc_cancel_scheduled_transfer_proto_rawDescGZIP() []byte {
+	file_rpc_cancel_scheduled_transfer_proto_rawDescOnce.Do(func() {
+		file_rpc_cancel_scheduled_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_cancel_scheduled_transfer_proto_rawDesc), len(file_rpc_cancel_scheduled_transfer_proto_rawDesc)))
+	})
+	return file_rpc_cancel_scheduled_transfer_proto_rawDescData
+}
+
+var file_rpc_cancel_scheduled_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_cancel_scheduled_transfer_proto_goTypes = []any{
+	(*CancelScheduledTransferRequest)(nil),  // 0: pb.CancelScheduledTransferRequest
+	(*CancelScheduledTransferResponse)(nil), // 1: pb.CancelScheduledTransferResponse
+	(*ScheduledTransfer)(nil),               // 2: pb.ScheduledTransfer
+}
+var file_rpc_cancel_scheduled_transfer_proto_depIdxs = []int32{
+	2, // 0: pb.CancelScheduledTransferResponse.scheduled_transfer:type_name -> pb.ScheduledTransfer
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_cancel_scheduled_transfer_proto_init() }
+func file_rpc_cancel_scheduled_transfer_proto_init() {
+	if File_rpc_cancel_scheduled_transfer_proto != nil {
+		return
+	}
+	file_scheduled_transfer_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_cancel_scheduled_transfer_proto_rawDesc), len(file_rpc_cancel_scheduled_transfer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_cancel_scheduled_transfer_proto_goTypes,
+		DependencyIndexes: file_rpc_cancel_scheduled_transfer_proto_depIdxs,
+		MessageInfos:      file_rpc_cancel_scheduled_transfer_proto_msgTypes,
+	}.Build()
+	File_rpc_cancel_scheduled_transfer_proto = out.File
+	file_rpc_cancel_scheduled_transfer_proto_goTypes = nil
+	file_rpc_cancel_scheduled_transfer_proto_depIdxs = nil
+}
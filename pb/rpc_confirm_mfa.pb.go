@@ -0,0 +1,171 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_confirm_mfa.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// code is the current code of the authenticator app
+type ConfirmMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmMFARequest) Reset() {
+	*x = ConfirmMFARequest{}
+	mi := &file_rpc_confirm_mfa_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmMFARequest) ProtoMessage() {}
+
+func (x *ConfirmMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_confirm_mfa_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmMFARequest.ProtoReflect.Descriptor instead.
+func (*ConfirmMFARequest) Descriptor() ([]byte, []int) {
+	return file_rpc_confirm_mfa_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConfirmMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// each recovery code can replace a code of the authenticator app once, they are only shown here
+type ConfirmMFAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmMFAResponse) Reset() {
+	*x = ConfirmMFAResponse{}
+	mi := &file_rpc_confirm_mfa_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmMFAResponse) ProtoMessage() {}
+
+func (x *ConfirmMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_confirm_mfa_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmMFAResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmMFAResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_confirm_mfa_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConfirmMFAResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+var File_rpc_confirm_mfa_proto protoreflect.FileDescriptor
+
+const file_rpc_confirm_mfa_proto_rawDesc = "" +
+	"\n" +
+	"\x15rpc_confirm_mfa.proto\x12\x02pb\"'\n" +
+	"\x11ConfirmMFARequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\";\n" +
+	"\x12ConfirmMFAResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodesB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_confirm_mfa_proto_rawDescOnce sync.Once
+	file_rpc_confirm_mfa_proto_rawDescData []byte
+)
+
+func file_rpc_confirm_mfa_proto_rawDescGZIP() []byte {
+	file_rpc_confirm_mfa_proto_rawDescOnce.Do(func() {
+		file_rpc_confirm_mfa_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_confirm_mfa_proto_rawDesc), len(file_rpc_confirm_mfa_proto_rawDesc)))
+	})
+	return file_rpc_confirm_mfa_proto_rawDescData
+}
+
+var file_rpc_confirm_mfa_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_confirm_mfa_proto_goTypes = []any{
+	(*ConfirmMFARequest)(nil),  // 0: pb.ConfirmMFARequest
+	(*ConfirmMFAResponse)(nil), // 1: pb.ConfirmMFAResponse
+}
+var file_rpc_confirm_mfa_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_confirm_mfa_proto_init() }
+func file_rpc_confirm_mfa_proto_init() {
+	if File_rpc_confirm_mfa_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_confirm_mfa_proto_rawDesc), len(file_rpc_confirm_mfa_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_confirm_mfa_proto_goTypes,
+		DependencyIndexes: file_rpc_confirm_mfa_proto_depIdxs,
+		MessageInfos:      file_rpc_confirm_mfa_proto_msgTypes,
+	}.Build()
+	File_rpc_confirm_mfa_proto = out.File
+	file_rpc_confirm_mfa_proto_goTypes = nil
+	file_rpc_confirm_mfa_proto_depIdxs = nil
+}
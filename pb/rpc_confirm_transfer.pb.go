@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_confirm_transfer.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// the transfer must be confirmed with the access token that created the challenge,
+// password is given for the password method and code, a code of the authenticator app or a recovery code, for the mfa method
+type ConfirmTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId   int64                  `protobuf:"varint,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTransferRequest) Reset() {
+	*x = ConfirmTransferRequest{}
+	mi := &file_rpc_confirm_transfer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTransferRequest) ProtoMessage() {}
+
+func (x *ConfirmTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_confirm_transfer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTransferRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTransferRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_confirm_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConfirmTransferRequest) GetChallengeId() int64 {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return 0
+}
+
+func (x *ConfirmTransferRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ConfirmTransferRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	FromAccount   *Account               `protobuf:"bytes,2,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
+	ToAccount     *Account               `protobuf:"bytes,3,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
+	FromEntry     *Entry                 `protobuf:"bytes,4,opt,name=from_entry,json=fromEntry,proto3" json:"from_entry,omitempty"`
+	ToEntry       *Entry                 `protobuf:"bytes,5,opt,name=to_entry,json=toEntry,proto3" json:"to_entry,omitempty"`
+	Allowance     *TransferAllowance     `protobuf:"bytes,6,opt,name=allowance,proto3" json:"allowance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTransferResponse) Reset() {
+	*x = ConfirmTransferResponse{}
+	mi := &file_rpc_confirm_transfer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTransferResponse) ProtoMessage() {}
+
+func (x *ConfirmTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_confirm_transfer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTransferResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTransferResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_confirm_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConfirmTransferResponse) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *ConfirmTransferResponse) GetFromAccount() *Account {
+	if x != nil {
+		return x.FromAccount
+	}
+	return nil
+}
+
+func (x *ConfirmTransferResponse) GetToAccount() *Account {
+	if x != nil {
+		return x.ToAccount
+	}
+	return nil
+}
+
+func (x *ConfirmTransferResponse) GetFromEntry() *Entry {
+	if x != nil {
+		return x.FromEntry
+	}
+	return nil
+}
+
+func (x *ConfirmTransferResponse) GetToEntry() *Entry {
+	if x != nil {
+		return x.ToEntry
+	}
+	return nil
+}
+
+func (x *ConfirmTransferResponse) GetAllowance() *TransferAllowance {
+	if x != nil {
+		return x.Allowance
+	}
+	return nil
+}
+
+var File_rpc_confirm_transfer_proto protoreflect.FileDescriptor
+
+const file_rpc_confirm_transfer_proto_rawDesc = "" +
+	"\n" +
+	"\x1arpc_confirm_transfer.proto\x12\x02pb\x1a\raccount.proto\x1a\ventry.proto\x1a\x0etransfer.proto\x1a\x14transfer_limit.proto\"k\n" +
+	"\x16ConfirmTransferRequest\x12!\n" +
+	"\fchallenge_id\x18\x01 \x01(\x03R\vchallengeId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\"\xa4\x02\n" +
+	"\x17ConfirmTransferResponse\x12(\n" +
+	"\btransfer\x18\x01 \x01(\v2\f.pb.TransferR\btransfer\x12.\n" +
+	"\ffrom_account\x18\x02 \x01(\v2\v.pb.AccountR\vfromAccount\x12*\n" +
+	"\n" +
+	"to_account\x18\x03 \x01(\v2\v.pb.AccountR\ttoAccount\x12(\n" +
+	"\n" +
+	"from_entry\x18\x04 \x01(\v2\t.pb.EntryR\tfromEntry\x12$\n" +
+	"\bto_entry\x18\x05 \x01(\v2\t.pb.EntryR\atoEntry\x123\n" +
+	"\tallowance\x18\x06 \x01(\v2\x15.pb.TransferAllowanceR\tallowanceB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_confirm_transfer_proto_rawDescOnce sync.Once
+	file_rpc_confirm_transfer_proto_rawDescData []byte
+)
+
+func file_rpc_confirm_transfer_proto_rawDescGZIP() []byte {
+	file_rpc_confirm_transfer_proto_rawDescOnce.Do(func() {
+		file_rpc_confirm_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_confirm_transfer_proto_rawDesc), len(file_rpc_confirm_transfer_proto_rawDesc)))
+	})
+	return file_rpc_confirm_transfer_proto_rawDescData
+}
+
+var file_rpc_confirm_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_confirm_transfer_proto_goTypes = []any{
+	(*ConfirmTransferRequest)(nil),  // 0: pb.ConfirmTransferRequest
+	(*ConfirmTransferResponse)(nil), // 1: pb.ConfirmTransferResponse
+	(*Transfer)(nil),                // 2: pb.Transfer
+	(*Account)(nil),                 // 3: pb.Account
+	(*Entry)(nil),                   // 4: pb.Entry
+	(*TransferAllowance)(nil),       // 5: pb.TransferAllowance
+}
+var file_rpc_confirm_transfer_proto_depIdxs = []int32{
+	2, // 0: pb.ConfirmTransferResponse.transfer:type_name -> pb.Transfer
+	3, // 1: pb.ConfirmTransferResponse.from_account:type_name -> pb.Account
+	3, // 2: pb.ConfirmTransferResponse.to_account:type_name -> pb.Account
+	4, // 3: pb.ConfirmTransferResponse.from_entry:type_name -> pb.Entry
+	4, // 4: pb.ConfirmTransferResponse.to_entry:type_name -> pb.Entry
+	5, // 5: pb.ConfirmTransferResponse.allowance:type_name -> pb.TransferAllowance
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_rpc_confirm_transfer_proto_init() }
+func file_rpc_confirm_transfer_proto_init() {
+	if File_rpc_confirm_transfer_proto != nil {
+		return
+	}
+	file_account_proto_init()
+	file_entry_proto_init()
+	file_transfer_proto_init()
+	file_transfer_limit_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_confirm_transfer_proto_rawDesc), len(file_rpc_confirm_transfer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_confirm_transfer_proto_goTypes,
+		DependencyIndexes: file_rpc_confirm_transfer_proto_depIdxs,
+		MessageInfos:      file_rpc_confirm_transfer_proto_msgTypes,
+	}.Build()
+	File_rpc_confirm_transfer_proto = out.File
+	file_rpc_confirm_transfer_proto_goTypes = nil
+	file_rpc_confirm_transfer_proto_depIdxs = nil
+}
@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_create_scheduled_transfer.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// recurrence is empty for a one-off transfer at start_at, otherwise a rule like FREQ=MONTHLY;BYMONTHDAY=1
+// supporting FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL and BYMONTHDAY
+type CreateScheduledTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromAccountId int64                  `protobuf:"varint,1,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId   int64                  `protobuf:"varint,2,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Recurrence    string                 `protobuf:"bytes,5,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	StartAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	// optional, the last occurrence is the one at or before end_at
+	EndAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScheduledTransferRequest) Reset() {
+	*x = CreateScheduledTransferRequest{}
+	mi := &file_rpc_create_scheduled_transfer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduledTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduledTransferRequest) ProtoMessage() {}
+
+func (x *CreateScheduledTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_scheduled_transfer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduledTransferRequest.ProtoReflect.Descriptor instead.
+func (*CreateScheduledTransferRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_create_scheduled_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateScheduledTransferRequest) GetFromAccountId() int64 {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return 0
+}
+
+func (x *CreateScheduledTransferRequest) GetToAccountId() int64 {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return 0
+}
+
+func (x *CreateScheduledTransferRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateScheduledTransferRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateScheduledTransferRequest) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *CreateScheduledTransferRequest) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *CreateScheduledTransferRequest) GetEndAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndAt
+	}
+	return nil
+}
+
+type CreateScheduledTransferResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledTransfer *ScheduledTransfer     `protobuf:"bytes,1,opt,name=scheduled_transfer,json=scheduledTransfer,proto3" json:"scheduled_transfer,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateScheduledTransferResponse) Reset() {
+	*x = CreateScheduledTransferResponse{}
+	mi := &file_rpc_create_scheduled_transfer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduledTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduledTransferResponse) ProtoMessage() {}
+
+func (x *CreateScheduledTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_scheduled_transfer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduledTransferResponse.ProtoReflect.Descriptor instead.
+func (*CreateScheduledTransferResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_create_scheduled_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateScheduledTransferResponse) GetScheduledTransfer() *ScheduledTransfer {
+	if x != nil {
+		return x.ScheduledTransfer
+	}
+	return nil
+}
+
+var File_rpc_create_scheduled_transfer_proto protoreflect.FileDescriptor
+
+const file_rpc_create_scheduled_transfer_proto_rawDesc = "" +
+	"\n" +
+	"#rpc_create_scheduled_transfer.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18scheduled_transfer.proto\"\xaa\x02\n" +
+	"\x1eCreateScheduledTransferRequest\x12&\n" +
+	"\x0ffrom_account_id\x18\x01 \x01(\x03R\rfromAccountId\x12\"\n" +
+	"\rto_account_id\x18\x02 \x01(\x03R\vtoAccountId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x05 \x01(\tR\n" +
+	"recurrence\x125\n" +
+	"\bstart_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x121\n" +
+	"\x06end_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\"g\n" +
+	"\x1fCreateScheduledTransferResponse\x12D\n" +
+	"\x12scheduled_transfer\x18\x01 \x01(\v2\x15.pb.ScheduledTransferR\x11scheduledTransferB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_create_scheduled_transfer_proto_rawDescOnce sync.Once
+	file_rpc_create_scheduled_transfer_proto_rawDescData []byte
+)
+
+func file_rpc_create_scheduled_transfer_proto_rawDescGZIP() []byte {
+	file_rpc_create_scheduled_transfer_proto_rawDescOnce.Do(func() {
+		file_rpc_create_scheduled_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_create_scheduled_transfer_proto_rawDesc), len(file_rpc_create_scheduled_transfer_proto_rawDesc)))
+	})
+	return file_rpc_create_scheduled_transfer_proto_rawDescData
+}
+
+var file_rpc_create_scheduled_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_create_scheduled_transfer_proto_goTypes = []any{
+	(*CreateScheduledTransferRequest)(nil),  // 0: pb.CreateScheduledTransferRequest
+	(*CreateScheduledTransferResponse)(nil), // 1: pb.CreateScheduledTransferResponse
+	(*timestamppb.Timestamp)(nil),           // 2: google.protobuf.Timestamp
+	(*ScheduledTransfer)(nil),               // 3: pb.ScheduledTransfer
+}
+var file_rpc_create_scheduled_transfer_proto_depIdxs = []int32{
+	2, // 0: pb.CreateScheduledTransferRequest.start_at:type_name -> google.protobuf.Timestamp
+	2, // 1: pb.CreateScheduledTransferRequest.end_at:type_name -> google.protobuf.Timestamp
+	3, // 2: pb.CreateScheduledTransferResponse.scheduled_transfer:type_name -> pb.ScheduledTransfer
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rpc_create_scheduled_transfer_proto_init() }
+func file_rpc_create_scheduled_transfer_proto_init() {
+	if File_rpc_create_scheduled_transfer_proto != nil {
+		return
+	}
+	file_scheduled_transfer_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_create_scheduled_transfer_proto_rawDesc), len(file_rpc_create_scheduled_transfer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_create_scheduled_transfer_proto_goTypes,
+		DependencyIndexes: file_rpc_create_scheduled_transfer_proto_depIdxs,
+		MessageInfos:      file_rpc_create_scheduled_transfer_proto_msgTypes,
+	}.Build()
+	File_rpc_create_scheduled_transfer_proto = out.File
+	file_rpc_create_scheduled_transfer_proto_goTypes = nil
+	file_rpc_create_scheduled_transfer_proto_depIdxs = nil
+}
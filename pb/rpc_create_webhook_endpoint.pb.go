@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_create_webhook_endpoint.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateWebhookEndpointRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Url        string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes []string               `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// optional, a random secret is generated when empty
+	Secret        *string `protobuf:"bytes,3,opt,name=secret,proto3,oneof" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookEndpointRequest) Reset() {
+	*x = CreateWebhookEndpointRequest{}
+	mi := &file_rpc_create_webhook_endpoint_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookEndpointRequest) ProtoMessage() {}
+
+func (x *CreateWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_webhook_endpoint_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_create_webhook_endpoint_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateWebhookEndpointRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookEndpointRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *CreateWebhookEndpointRequest) GetSecret() string {
+	if x != nil && x.Secret != nil {
+		return *x.Secret
+	}
+	return ""
+}
+
+type CreateWebhookEndpointResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint *WebhookEndpoint       `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// key of the X-Webhook-Signature header, it is only returned once
+	Secret        string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookEndpointResponse) Reset() {
+	*x = CreateWebhookEndpointResponse{}
+	mi := &file_rpc_create_webhook_endpoint_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookEndpointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookEndpointResponse) ProtoMessage() {}
+
+func (x *CreateWebhookEndpointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_webhook_endpoint_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookEndpointResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookEndpointResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_create_webhook_endpoint_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateWebhookEndpointResponse) GetEndpoint() *WebhookEndpoint {
+	if x != nil {
+		return x.Endpoint
+	}
+	return nil
+}
+
+func (x *CreateWebhookEndpointResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+var File_rpc_create_webhook_endpoint_proto protoreflect.FileDescriptor
+
+const file_rpc_create_webhook_endpoint_proto_rawDesc = "" +
+	"\n" +
+	"!rpc_create_webhook_endpoint.proto\x12\x02pb\x1a\rwebhook.proto\"y\n" +
+	"\x1cCreateWebhookEndpointRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x02 \x03(\tR\n" +
+	"eventTypes\x12\x1b\n" +
+	"\x06secret\x18\x03 \x01(\tH\x00R\x06secret\x88\x01\x01B\t\n" +
+	"\a_secret\"h\n" +
+	"\x1dCreateWebhookEndpointResponse\x12/\n" +
+	"\bendpoint\x18\x01 \x01(\v2\x13.pb.WebhookEndpointR\bendpoint\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secretB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_create_webhook_endpoint_proto_rawDescOnce sync.Once
+	file_rpc_create_webhook_endpoint_proto_rawDescData []byte
+)
+
+func file_rpc_create_webhook_endpoint_proto_rawDescGZIP() []byte {
+	file_rpc_create_webhook_endpoint_proto_rawDescOnce.Do(func() {
+		file_rpc_create_webhook_endpoint_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_create_webhook_endpoint_proto_rawDesc), len(file_rpc_create_webhook_endpoint_proto_rawDesc)))
+	})
+	return file_rpc_create_webhook_endpoint_proto_rawDescData
+}
+
+var file_rpc_create_webhook_endpoint_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_create_webhook_endpoint_proto_goTypes = []any{
+	(*CreateWebhookEndpointRequest)(nil),  // 0: pb.CreateWebhookEndpointRequest
+	(*CreateWebhookEndpointResponse)(nil), // 1: pb.CreateWebhookEndpointResponse
+	(*WebhookEndpoint)(nil),               // 2: pb.WebhookEndpoint
+}
+var file_rpc_create_webhook_endpoint_proto_depIdxs = []int32{
+	2, // 0: pb.CreateWebhookEndpointResponse.endpoint:type_name -> pb.WebhookEndpoint
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_create_webhook_endpoint_proto_init() }
+func file_rpc_create_webhook_endpoint_proto_init() {
+	if File_rpc_create_webhook_endpoint_proto != nil {
+		return
+	}
+	file_webhook_proto_init()
+	file_rpc_create_webhook_endpoint_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_create_webhook_endpoint_proto_rawDesc), len(file_rpc_create_webhook_endpoint_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_create_webhook_endpoint_proto_goTypes,
+		DependencyIndexes: file_rpc_create_webhook_endpoint_proto_depIdxs,
+		MessageInfos:      file_rpc_create_webhook_endpoint_proto_msgTypes,
+	}.Build()
+	File_rpc_create_webhook_endpoint_proto = out.File
+	file_rpc_create_webhook_endpoint_proto_goTypes = nil
+	file_rpc_create_webhook_endpoint_proto_depIdxs = nil
+}
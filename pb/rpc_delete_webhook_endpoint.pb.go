@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_delete_webhook_endpoint.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DeleteWebhookEndpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId    int64                  `protobuf:"varint,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookEndpointRequest) Reset() {
+	*x = DeleteWebhookEndpointRequest{}
+	mi := &file_rpc_delete_webhook_endpoint_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookEndpointRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_delete_webhook_endpoint_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_delete_webhook_endpoint_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeleteWebhookEndpointRequest) GetEndpointId() int64 {
+	if x != nil {
+		return x.EndpointId
+	}
+	return 0
+}
+
+type DeleteWebhookEndpointResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      *WebhookEndpoint       `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookEndpointResponse) Reset() {
+	*x = DeleteWebhookEndpointResponse{}
+	mi := &file_rpc_delete_webhook_endpoint_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookEndpointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookEndpointResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookEndpointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_delete_webhook_endpoint_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookEndpointResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookEndpointResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_delete_webhook_endpoint_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeleteWebhookEndpointResponse) GetEndpoint() *WebhookEndpoint {
+	if x != nil {
+		return x.Endpoint
+	}
+	return nil
+}
+
+var File_rpc_delete_webhook_endpoint_proto protoreflect.FileDescriptor
+
+const file_rpc_delete_webhook_endpoint_proto_rawDesc = "" +
+	"\n" +
+	"!rpc_delete_webhook_endpoint.proto\x12\x02pb\x1a\rwebhook.proto\"?\n" +
+	"\x1cDeleteWebhookEndpointRequest\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\x03R\n" +
+	"endpointId\"P\n" +
+	"\x1dDeleteWebhookEndpointResponse\x12/\n" +
+	"\bendpoint\x18\x01 \x01(\v2\x13.pb.WebhookEndpointR\bendpointB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_delete_webhook_endpoint_proto_rawDescOnce sync.Once
+	file_rpc_delete_webhook_endpoint_proto_rawDescData []byte
+)
+
+func file_rpc_delete_webhook_endpoint_proto_rawDescGZIP() []byte {
+	file_rpc_delete_webhook_endpoint_proto_rawDescOnce.Do(func() {
+		file_rpc_delete_webhook_endpoint_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_delete_webhook_endpoint_proto_rawDesc), len(file_rpc_delete_webhook_endpoint_proto_rawDesc)))
+	})
+	return file_rpc_delete_webhook_endpoint_proto_rawDescData
+}
+
+var file_rpc_delete_webhook_endpoint_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_delete_webhook_endpoint_proto_goTypes = []any{
+	(*DeleteWebhookEndpointRequest)(nil),  // 0: pb.DeleteWebhookEndpointRequest
+	(*DeleteWebhookEndpointResponse)(nil), // 1: pb.DeleteWebhookEndpointResponse
+	(*WebhookEndpoint)(nil),               // 2: pb.WebhookEndpoint
+}
+var file_rpc_delete_webhook_endpoint_proto_depIdxs = []int32{
+	2, // 0: pb.DeleteWebhookEndpointResponse.endpoint:type_name -> pb.WebhookEndpoint
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_delete_webhook_endpoint_proto_init() }
+func file_rpc_delete_webhook_endpoint_proto_init() {
+	if File_rpc_delete_webhook_endpoint_proto != nil {
+		return
+	}
+	file_webhook_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_delete_webhook_endpoint_proto_rawDesc), len(file_rpc_delete_webhook_endpoint_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_delete_webhook_endpoint_proto_goTypes,
+		DependencyIndexes: file_rpc_delete_webhook_endpoint_proto_depIdxs,
+		MessageInfos:      file_rpc_delete_webhook_endpoint_proto_msgTypes,
+	}.Build()
+	File_rpc_delete_webhook_endpoint_proto = out.File
+	file_rpc_delete_webhook_endpoint_proto_goTypes = nil
+	file_rpc_delete_webhook_endpoint_proto_depIdxs = nil
+}
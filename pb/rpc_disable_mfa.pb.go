@@ -0,0 +1,161 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_disable_mfa.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// code is a code of the authenticator app or a recovery code
+type DisableMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableMFARequest) Reset() {
+	*x = DisableMFARequest{}
+	mi := &file_rpc_disable_mfa_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableMFARequest) ProtoMessage() {}
+
+func (x *DisableMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_disable_mfa_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableMFARequest.ProtoReflect.Descriptor instead.
+func (*DisableMFARequest) Descriptor() ([]byte, []int) {
+	return file_rpc_disable_mfa_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DisableMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type DisableMFAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableMFAResponse) Reset() {
+	*x = DisableMFAResponse{}
+	mi := &file_rpc_disable_mfa_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableMFAResponse) ProtoMessage() {}
+
+func (x *DisableMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_disable_mfa_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableMFAResponse.ProtoReflect.Descriptor instead.
+func (*DisableMFAResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_disable_mfa_proto_rawDescGZIP(), []int{1}
+}
+
+var File_rpc_disable_mfa_proto protoreflect.FileDescriptor
+
+const file_rpc_disable_mfa_proto_rawDesc = "" +
+	"\n" +
+	"\x15rpc_disable_mfa.proto\x12\x02pb\"'\n" +
+	"\x11DisableMFARequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x14\n" +
+	"\x12DisableMFAResponseB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_disable_mfa_proto_rawDescOnce sync.Once
+	file_rpc_disable_mfa_proto_rawDescData []byte
+)
+
+func file_rpc_disable_mfa_proto_rawDescGZIP() []byte {
+	file_rpc_disable_mfa_proto_rawDescOnce.Do(func() {
+		file_rpc_disable_mfa_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_disable_mfa_proto_rawDesc), len(file_rpc_disable_mfa_proto_rawDesc)))
+	})
+	return file_rpc_disable_mfa_proto_rawDescData
+}
+
+var file_rpc_disable_mfa_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_disable_mfa_proto_goTypes = []any{
+	(*DisableMFARequest)(nil),  // 0: pb.DisableMFARequest
+	(*DisableMFAResponse)(nil), // 1: pb.DisableMFAResponse
+}
+var file_rpc_disable_mfa_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_disable_mfa_proto_init() }
+func file_rpc_disable_mfa_proto_init() {
+	if File_rpc_disable_mfa_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_disable_mfa_proto_rawDesc), len(file_rpc_disable_mfa_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_disable_mfa_proto_goTypes,
+		DependencyIndexes: file_rpc_disable_mfa_proto_depIdxs,
+		MessageInfos:      file_rpc_disable_mfa_proto_msgTypes,
+	}.Build()
+	File_rpc_disable_mfa_proto = out.File
+	file_rpc_disable_mfa_proto_goTypes = nil
+	file_rpc_disable_mfa_proto_depIdxs = nil
+}
@@ -0,0 +1,184 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_get_account_transfer_limits.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetAccountTransferLimitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountTransferLimitsRequest) Reset() {
+	*x = GetAccountTransferLimitsRequest{}
+	mi := &file_rpc_get_account_transfer_limits_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountTransferLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountTransferLimitsRequest) ProtoMessage() {}
+
+func (x *GetAccountTransferLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_account_transfer_limits_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountTransferLimitsRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountTransferLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_get_account_transfer_limits_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetAccountTransferLimitsRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type GetAccountTransferLimitsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limits        *TransferLimits        `protobuf:"bytes,1,opt,name=limits,proto3" json:"limits,omitempty"`
+	Allowance     *TransferAllowance     `protobuf:"bytes,2,opt,name=allowance,proto3" json:"allowance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountTransferLimitsResponse) Reset() {
+	*x = GetAccountTransferLimitsResponse{}
+	mi := &file_rpc_get_account_transfer_limits_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountTransferLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountTransferLimitsResponse) ProtoMessage() {}
+
+func (x *GetAccountTransferLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_account_transfer_limits_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountTransferLimitsResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountTransferLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_get_account_transfer_limits_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetAccountTransferLimitsResponse) GetLimits() *TransferLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *GetAccountTransferLimitsResponse) GetAllowance() *TransferAllowance {
+	if x != nil {
+		return x.Allowance
+	}
+	return nil
+}
+
+var File_rpc_get_account_transfer_limits_proto protoreflect.FileDescriptor
+
+const file_rpc_get_account_transfer_limits_proto_rawDesc = "" +
+	"\n" +
+	"%rpc_get_account_transfer_limits.proto\x12\x02pb\x1a\x14transfer_limit.proto\"@\n" +
+	"\x1fGetAccountTransferLimitsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\"\x83\x01\n" +
+	" GetAccountTransferLimitsResponse\x12*\n" +
+	"\x06limits\x18\x01 \x01(\v2\x12.pb.TransferLimitsR\x06limits\x123\n" +
+	"\tallowance\x18\x02 \x01(\v2\x15.pb.TransferAllowanceR\tallowanceB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_get_account_transfer_limits_proto_rawDescOnce sync.Once
+	file_rpc_get_account_transfer_limits_proto_rawDescData []byte
+)
+
+func file_rpc_get_account_transfer_limits_proto_rawDescGZIP() []byte {
+	file_rpc_get_account_transfer_limits_proto_rawDescOnce.Do(func() {
+		file_rpc_get_account_transfer_limits_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_get_account_transfer_limits_proto_rawDesc), len(file_rpc_get_account_transfer_limits_proto_rawDesc)))
+	})
+	return file_rpc_get_account_transfer_limits_proto_rawDescData
+}
+
+var file_rpc_get_account_transfer_limits_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_get_account_transfer_limits_proto_goTypes = []any{
+	(*GetAccountTransferLimitsRequest)(nil),  // 0: pb.GetAccountTransferLimitsRequest
+	(*GetAccountTransferLimitsResponse)(nil), // 1: pb.GetAccountTransferLimitsResponse
+	(*TransferLimits)(nil),                   // 2: pb.TransferLimits
+	(*TransferAllowance)(nil),                // 3: pb.TransferAllowance
+}
+var file_rpc_get_account_transfer_limits_proto_depIdxs = []int32{
+	2, // 0: pb.GetAccountTransferLimitsResponse.limits:type_name -> pb.TransferLimits
+	3, // 1: pb.GetAccountTransferLimitsResponse.allowance:type_name -> pb.TransferAllowance
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_get_account_transfer_limits_proto_init() }
+func file_rpc_get_account_transfer_limits_proto_init() {
+	if File_rpc_get_account_transfer_limits_proto != nil {
+		return
+	}
+	file_transfer_limit_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_get_account_transfer_limits_proto_rawDesc), len(file_rpc_get_account_transfer_limits_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_get_account_transfer_limits_proto_goTypes,
+		DependencyIndexes: file_rpc_get_account_transfer_limits_proto_depIdxs,
+		MessageInfos:      file_rpc_get_account_transfer_limits_proto_msgTypes,
+	}.Build()
+	File_rpc_get_account_transfer_limits_proto = out.File
+	file_rpc_get_account_transfer_limits_proto_goTypes = nil
+	file_rpc_get_account_transfer_limits_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_list_ledger_accounts.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListLedgerAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerAccountsRequest) Reset() {
+	*x = ListLedgerAccountsRequest{}
+	mi := &file_rpc_list_ledger_accounts_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerAccountsRequest) ProtoMessage() {}
+
+func (x *ListLedgerAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_ledger_accounts_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListLedgerAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_ledger_accounts_proto_rawDescGZIP(), []int{0}
+}
+
+type ListLedgerAccountsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LedgerAccounts []*LedgerAccount       `protobuf:"bytes,1,rep,name=ledger_accounts,json=ledgerAccounts,proto3" json:"ledger_accounts,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListLedgerAccountsResponse) Reset() {
+	*x = ListLedgerAccountsResponse{}
+	mi := &file_rpc_list_ledger_accounts_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerAccountsResponse) ProtoMessage() {}
+
+func (x *ListLedgerAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_ledger_accounts_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListLedgerAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_ledger_accounts_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListLedgerAccountsResponse) GetLedgerAccounts() []*LedgerAccount {
+	if x != nil {
+		return x.LedgerAccounts
+	}
+	return nil
+}
+
+var File_rpc_list_ledger_accounts_proto protoreflect.FileDescriptor
+
+const file_rpc_list_ledger_accounts_proto_rawDesc = "" +
+	"\n" +
+	"\x1erpc_list_ledger_accounts.proto\x12\x02pb\x1a\x14ledger_account.proto\"\x1b\n" +
+	"\x19ListLedgerAccountsRequest\"X\n" +
+	"\x1aListLedgerAccountsResponse\x12:\n" +
+	"\x0fledger_accounts\x18\x01 \x03(\v2\x11.pb.LedgerAccountR\x0eledgerAccountsB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_list_ledger_accounts_proto_rawDescOnce sync.Once
+	file_rpc_list_ledger_accounts_proto_rawDescData []byte
+)
+
+func file_rpc_list_ledger_accounts_proto_rawDescGZIP() []byte {
+	file_rpc_list_ledger_accounts_proto_rawDescOnce.Do(func() {
+		file_rpc_list_ledger_accounts_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_list_ledger_accounts_proto_rawDesc), len(file_rpc_list_ledger_accounts_proto_rawDesc)))
+	})
+	return file_rpc_list_ledger_accounts_proto_rawDescData
+}
+
+var file_rpc_list_ledger_accounts_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_ledger_accounts_proto_goTypes = []any{
+	(*ListLedgerAccountsRequest)(nil),  // 0: pb.ListLedgerAccountsRequest
+	(*ListLedgerAccountsResponse)(nil), // 1: pb.ListLedgerAccountsResponse
+	(*LedgerAccount)(nil),              // 2: pb.LedgerAccount
+}
+var file_rpc_list_ledger_accounts_proto_depIdxs = []int32{
+	2, // 0: pb.ListLedgerAccountsResponse.ledger_accounts:type_name -> pb.LedgerAccount
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_ledger_accounts_proto_init() }
+func file_rpc_list_ledger_accounts_proto_init() {
+	if File_rpc_list_ledger_accounts_proto != nil {
+		return
+	}
+	file_ledger_account_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_list_ledger_accounts_proto_rawDesc), len(file_rpc_list_ledger_accounts_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_ledger_accounts_proto_goTypes,
+		DependencyIndexes: file_rpc_list_ledger_accounts_proto_depIdxs,
+		MessageInfos:      file_rpc_list_ledger_accounts_proto_msgTypes,
+	}.Build()
+	File_rpc_list_ledger_accounts_proto = out.File
+	file_rpc_list_ledger_accounts_proto_goTypes = nil
+	file_rpc_list_ledger_accounts_proto_depIdxs = nil
+}
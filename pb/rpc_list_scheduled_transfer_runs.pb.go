@@ -0,0 +1,202 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_list_scheduled_transfer_runs.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListScheduledTransferRunsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledTransferId int64                  `protobuf:"varint,1,opt,name=scheduled_transfer_id,json=scheduledTransferId,proto3" json:"scheduled_transfer_id,omitempty"`
+	PageSize            int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous response, empty for the first page
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledTransferRunsRequest) Reset() {
+	*x = ListScheduledTransferRunsRequest{}
+	mi := &file_rpc_list_scheduled_transfer_runs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledTransferRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledTransferRunsRequest) ProtoMessage() {}
+
+func (x *ListScheduledTransferRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_scheduled_transfer_runs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledTransferRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledTransferRunsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_scheduled_transfer_runs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListScheduledTransferRunsRequest) GetScheduledTransferId() int64 {
+	if x != nil {
+		return x.ScheduledTransferId
+	}
+	return 0
+}
+
+func (x *ListScheduledTransferRunsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListScheduledTransferRunsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListScheduledTransferRunsResponse struct {
+	state protoimpl.MessageState  `protogen:"open.v1"`
+	Runs  []*ScheduledTransferRun `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	// empty when there are no more runs
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledTransferRunsResponse) Reset() {
+	*x = ListScheduledTransferRunsResponse{}
+	mi := &file_rpc_list_scheduled_transfer_runs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledTransferRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledTransferRunsResponse) ProtoMessage() {}
+
+func (x *ListScheduledTransferRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_scheduled_transfer_runs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledTransferRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledTransferRunsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_scheduled_transfer_runs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListScheduledTransferRunsResponse) GetRuns() []*ScheduledTransferRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+func (x *ListScheduledTransferRunsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_rpc_list_scheduled_transfer_runs_proto protoreflect.FileDescriptor
+
+const file_rpc_list_scheduled_transfer_runs_proto_rawDesc = "" +
+	"\n" +
+	"&rpc_list_scheduled_transfer_runs.proto\x12\x02pb\x1a\x18scheduled_transfer.proto\"\x92\x01\n" +
+	" ListScheduledTransferRunsRequest\x122\n" +
+	"\x15scheduled_transfer_id\x18\x01 \x01(\x03R\x13scheduledTransferId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"y\n" +
+	"!ListScheduledTransferRunsResponse\x12,\n" +
+	"\x04runs\x18\x01 \x03(\v2\x18.pb.ScheduledTransferRunR\x04runs\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageTokenB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_list_scheduled_transfer_runs_proto_rawDescOnce sync.Once
+	file_rpc_list_scheduled_transfer_runs_proto_rawDescData []byte
+)
+
+func file_rpc_list_scheduled_transfer_runs_proto_rawDescGZIP() []byte {
+	file_rpc_list_scheduled_transfer_runs_proto_rawDescOnce.Do(func() {
+		file_rpc_list_scheduled_transfer_runs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_list_scheduled_transfer_runs_proto_rawDesc), len(file_rpc_list_scheduled_transfer_runs_proto_rawDesc)))
+	})
+	return file_rpc_list_scheduled_transfer_runs_proto_rawDescData
+}
+
+var file_rpc_list_scheduled_transfer_runs_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_scheduled_transfer_runs_proto_goTypes = []any{
+	(*ListScheduledTransferRunsRequest)(nil),  // 0: pb.ListScheduledTransferRunsRequest
+	(*ListScheduledTransferRunsResponse)(nil), // 1: pb.ListScheduledTransferRunsResponse
+	(*ScheduledTransferRun)(nil),              // 2: pb.ScheduledTransferRun
+}
+var file_rpc_list_scheduled_transfer_runs_proto_depIdxs = []int32{
+	2, // 0: pb.ListScheduledTransferRunsResponse.runs:type_name -> pb.ScheduledTransferRun
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_scheduled_transfer_runs_proto_init() }
+func file_rpc_list_scheduled_transfer_runs_proto_init() {
+	if File_rpc_list_scheduled_transfer_runs_proto != nil {
+		return
+	}
+	file_scheduled_transfer_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_list_scheduled_transfer_runs_proto_rawDesc), len(file_rpc_list_scheduled_transfer_runs_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_scheduled_transfer_runs_proto_goTypes,
+		DependencyIndexes: file_rpc_list_scheduled_transfer_runs_proto_depIdxs,
+		MessageInfos:      file_rpc_list_scheduled_transfer_runs_proto_msgTypes,
+	}.Build()
+	File_rpc_list_scheduled_transfer_runs_proto = out.File
+	file_rpc_list_scheduled_transfer_runs_proto_goTypes = nil
+	file_rpc_list_scheduled_transfer_runs_proto_depIdxs = nil
+}
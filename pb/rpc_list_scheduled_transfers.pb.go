@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_list_scheduled_transfers.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListScheduledTransfersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledTransfersRequest) Reset() {
+	*x = ListScheduledTransfersRequest{}
+	mi := &file_rpc_list_scheduled_transfers_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledTransfersRequest) ProtoMessage() {}
+
+func (x *ListScheduledTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_scheduled_transfers_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledTransfersRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_scheduled_transfers_proto_rawDescGZIP(), []int{0}
+}
+
+type ListScheduledTransfersResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledTransfers []*ScheduledTransfer   `protobuf:"bytes,1,rep,name=scheduled_transfers,json=scheduledTransfers,proto3" json:"scheduled_transfers,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListScheduledTransfersResponse) Reset() {
+	*x = ListScheduledTransfersResponse{}
+	mi := &file_rpc_list_scheduled_transfers_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledTransfersResponse) ProtoMessage() {}
+
+func (x *ListScheduledTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_scheduled_transfers_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledTransfersResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_scheduled_transfers_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListScheduledTransfersResponse) GetScheduledTransfers() []*ScheduledTransfer {
+	if x != nil {
+		return x.ScheduledTransfers
+	}
+	return nil
+}
+
+var File_rpc_list_scheduled_transfers_proto protoreflect.FileDescriptor
+
+const file_rpc_list_scheduled_transfers_proto_rawDesc = "" +
+	"\n" +
+	"\"rpc_list_scheduled_transfers.proto\x12\x02pb\x1a\x18scheduled_transfer.proto\"\x1f\n" +
+	"\x1dListScheduledTransfersRequest\"h\n" +
+	"\x1eListScheduledTransfersResponse\x12F\n" +
+	"\x13scheduled_transfers\x18\x01 \x03(\v2\x15.pb.ScheduledTransferR\x12scheduledTransfersB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_list_scheduled_transfers_proto_rawDescOnce sync.Once
+	file_rpc_list_scheduled_transfers_proto_rawDescData []byte
+)
+
+func file_rpc_list_scheduled_transfers_proto_rawDescGZIP() []byte {
+	file_rpc_list_scheduled_transfers_proto_rawDescOnce.Do(func() {
+		file_rpc_list_scheduled_transfers_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_list_scheduled_transfers_proto_rawDesc), len(file_rpc_list_scheduled_transfers_proto_rawDesc)))
+	})
+	return file_rpc_list_scheduled_transfers_proto_rawDescData
+}
+
+var file_rpc_list_scheduled_transfers_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_scheduled_transfers_proto_goTypes = []any{
+	(*ListScheduledTransfersRequest)(nil),  // 0: pb.ListScheduledTransfersRequest
+	(*ListScheduledTransfersResponse)(nil), // 1: pb.ListScheduledTransfersResponse
+	(*ScheduledTransfer)(nil),              // 2: pb.ScheduledTransfer
+}
+var file_rpc_list_scheduled_transfers_proto_depIdxs = []int32{
+	2, // 0: pb.ListScheduledTransfersResponse.scheduled_transfers:type_name -> pb.ScheduledTransfer
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_scheduled_transfers_proto_init() }
+func file_rpc_list_scheduled_transfers_proto_init() {
+	if File_rpc_list_scheduled_transfers_proto != nil {
+		return
+	}
+	file_scheduled_transfer_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_list_scheduled_transfers_proto_rawDesc), len(file_rpc_list_scheduled_transfers_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_scheduled_transfers_proto_goTypes,
+		DependencyIndexes: file_rpc_list_scheduled_transfers_proto_depIdxs,
+		MessageInfos:      file_rpc_list_scheduled_transfers_proto_msgTypes,
+	}.Build()
+	File_rpc_list_scheduled_transfers_proto = out.File
+	file_rpc_list_scheduled_transfers_proto_goTypes = nil
+	file_rpc_list_scheduled_transfers_proto_depIdxs = nil
+}
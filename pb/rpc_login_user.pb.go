@@ -74,6 +74,8 @@ func (x *LoginUserRequest) GetPassword() string {
 	return ""
 }
 
+// users with multi-factor authentication get no tokens, mfa_required is set and
+// the challenge token is exchanged for them with VerifyMFA before mfa_challenge_expired_at
 type LoginUserResponse struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	User                  *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -82,6 +84,9 @@ type LoginUserResponse struct {
 	XRefreshToken         string                 `protobuf:"bytes,4,opt,name=_refresh_token,json=RefreshToken,proto3" json:"_refresh_token,omitempty"`
 	TokenExpiredAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=token_expired_at,json=tokenExpiredAt,proto3" json:"token_expired_at,omitempty"`
 	RefreshTokenExpiredAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=refresh_token_expired_at,json=refreshTokenExpiredAt,proto3" json:"refresh_token_expired_at,omitempty"`
+	MfaRequired           bool                   `protobuf:"varint,7,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"`
+	MfaChallengeToken     string                 `protobuf:"bytes,8,opt,name=mfa_challenge_token,json=mfaChallengeToken,proto3" json:"mfa_challenge_token,omitempty"`
+	MfaChallengeExpiredAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=mfa_challenge_expired_at,json=mfaChallengeExpiredAt,proto3" json:"mfa_challenge_expired_at,omitempty"`
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -158,6 +163,27 @@ func (x *LoginUserResponse) GetRefreshTokenExpiredAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *LoginUserResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *LoginUserResponse) GetMfaChallengeToken() string {
+	if x != nil {
+		return x.MfaChallengeToken
+	}
+	return ""
+}
+
+func (x *LoginUserResponse) GetMfaChallengeExpiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MfaChallengeExpiredAt
+	}
+	return nil
+}
+
 var File_rpc_login_user_proto protoreflect.FileDescriptor
 
 const file_rpc_login_user_proto_rawDesc = "" +
@@ -166,7 +192,7 @@ const file_rpc_login_user_proto_rawDesc = "" +
 	"user.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"J\n" +
 	"\x10LoginUserRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xa8\x02\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xd0\x03\n" +
 	"\x11LoginUserResponse\x12\x1c\n" +
 	"\x04user\x18\x01 \x01(\v2\b.pb.UserR\x04user\x12\x1d\n" +
 	"\n" +
@@ -174,7 +200,10 @@ const file_rpc_login_user_proto_rawDesc = "" +
 	"\x06_token\x18\x03 \x01(\tR\x05Token\x12$\n" +
 	"\x0e_refresh_token\x18\x04 \x01(\tR\fRefreshToken\x12D\n" +
 	"\x10token_expired_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0etokenExpiredAt\x12S\n" +
-	"\x18refresh_token_expired_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x15refreshTokenExpiredAtB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+	"\x18refresh_token_expired_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x15refreshTokenExpiredAt\x12!\n" +
+	"\fmfa_required\x18\a \x01(\bR\vmfaRequired\x12.\n" +
+	"\x13mfa_challenge_token\x18\b \x01(\tR\x11mfaChallengeToken\x12S\n" +
+	"\x18mfa_challenge_expired_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x15mfaChallengeExpiredAtB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
 
 var (
 	file_rpc_login_user_proto_rawDescOnce sync.Once
@@ -199,11 +228,12 @@ var file_rpc_login_user_proto_depIdxs = []int32{
 	2, // 0: pb.LoginUserResponse.user:type_name -> pb.User
 	3, // 1: pb.LoginUserResponse.token_expired_at:type_name -> google.protobuf.Timestamp
 	3, // 2: pb.LoginUserResponse.refresh_token_expired_at:type_name -> google.protobuf.Timestamp
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 3: pb.LoginUserResponse.mfa_challenge_expired_at:type_name -> google.protobuf.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_rpc_login_user_proto_init() }
@@ -0,0 +1,350 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_reconcile_balances.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReconcileBalancesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// write adjustment journal entries for the drifting accounts
+	Fix           bool `protobuf:"varint,1,opt,name=fix,proto3" json:"fix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileBalancesRequest) Reset() {
+	*x = ReconcileBalancesRequest{}
+	mi := &file_rpc_reconcile_balances_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileBalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileBalancesRequest) ProtoMessage() {}
+
+func (x *ReconcileBalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reconcile_balances_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileBalancesRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileBalancesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_reconcile_balances_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReconcileBalancesRequest) GetFix() bool {
+	if x != nil {
+		return x.Fix
+	}
+	return false
+}
+
+type BalanceDrift struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AccountId    int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Owner        string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Currency     string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Balance      int64                  `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	EntriesTotal int64                  `protobuf:"varint,5,opt,name=entries_total,json=entriesTotal,proto3" json:"entries_total,omitempty"`
+	// balance minus the sum of the entries
+	Drift int64 `protobuf:"varint,6,opt,name=drift,proto3" json:"drift,omitempty"`
+	// adjustment written by a fixing run, 0 when the drift was only reported
+	JournalEntryId int64 `protobuf:"varint,7,opt,name=journal_entry_id,json=journalEntryId,proto3" json:"journal_entry_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BalanceDrift) Reset() {
+	*x = BalanceDrift{}
+	mi := &file_rpc_reconcile_balances_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceDrift) ProtoMessage() {}
+
+func (x *BalanceDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reconcile_balances_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceDrift.ProtoReflect.Descriptor instead.
+func (*BalanceDrift) Descriptor() ([]byte, []int) {
+	return file_rpc_reconcile_balances_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BalanceDrift) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *BalanceDrift) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *BalanceDrift) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *BalanceDrift) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *BalanceDrift) GetEntriesTotal() int64 {
+	if x != nil {
+		return x.EntriesTotal
+	}
+	return 0
+}
+
+func (x *BalanceDrift) GetDrift() int64 {
+	if x != nil {
+		return x.Drift
+	}
+	return 0
+}
+
+func (x *BalanceDrift) GetJournalEntryId() int64 {
+	if x != nil {
+		return x.JournalEntryId
+	}
+	return 0
+}
+
+type UnbalancedJournal struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	JournalEntryId int64                  `protobuf:"varint,1,opt,name=journal_entry_id,json=journalEntryId,proto3" json:"journal_entry_id,omitempty"`
+	Currency       string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Total          int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UnbalancedJournal) Reset() {
+	*x = UnbalancedJournal{}
+	mi := &file_rpc_reconcile_balances_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbalancedJournal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbalancedJournal) ProtoMessage() {}
+
+func (x *UnbalancedJournal) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reconcile_balances_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbalancedJournal.ProtoReflect.Descriptor instead.
+func (*UnbalancedJournal) Descriptor() ([]byte, []int) {
+	return file_rpc_reconcile_balances_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UnbalancedJournal) GetJournalEntryId() int64 {
+	if x != nil {
+		return x.JournalEntryId
+	}
+	return 0
+}
+
+func (x *UnbalancedJournal) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *UnbalancedJournal) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ReconcileBalancesResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Drifts             []*BalanceDrift        `protobuf:"bytes,1,rep,name=drifts,proto3" json:"drifts,omitempty"`
+	UnbalancedJournals []*UnbalancedJournal   `protobuf:"bytes,2,rep,name=unbalanced_journals,json=unbalancedJournals,proto3" json:"unbalanced_journals,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ReconcileBalancesResponse) Reset() {
+	*x = ReconcileBalancesResponse{}
+	mi := &file_rpc_reconcile_balances_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileBalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileBalancesResponse) ProtoMessage() {}
+
+func (x *ReconcileBalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reconcile_balances_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileBalancesResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileBalancesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_reconcile_balances_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReconcileBalancesResponse) GetDrifts() []*BalanceDrift {
+	if x != nil {
+		return x.Drifts
+	}
+	return nil
+}
+
+func (x *ReconcileBalancesResponse) GetUnbalancedJournals() []*UnbalancedJournal {
+	if x != nil {
+		return x.UnbalancedJournals
+	}
+	return nil
+}
+
+var File_rpc_reconcile_balances_proto protoreflect.FileDescriptor
+
+const file_rpc_reconcile_balances_proto_rawDesc = "" +
+	"\n" +
+	"\x1crpc_reconcile_balances.proto\x12\x02pb\",\n" +
+	"\x18ReconcileBalancesRequest\x12\x10\n" +
+	"\x03fix\x18\x01 \x01(\bR\x03fix\"\xde\x01\n" +
+	"\fBalanceDrift\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x03R\abalance\x12#\n" +
+	"\rentries_total\x18\x05 \x01(\x03R\fentriesTotal\x12\x14\n" +
+	"\x05drift\x18\x06 \x01(\x03R\x05drift\x12(\n" +
+	"\x10journal_entry_id\x18\a \x01(\x03R\x0ejournalEntryId\"o\n" +
+	"\x11UnbalancedJournal\x12(\n" +
+	"\x10journal_entry_id\x18\x01 \x01(\x03R\x0ejournalEntryId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\"\x8d\x01\n" +
+	"\x19ReconcileBalancesResponse\x12(\n" +
+	"\x06drifts\x18\x01 \x03(\v2\x10.pb.BalanceDriftR\x06drifts\x12F\n" +
+	"\x13unbalanced_journals\x18\x02 \x03(\v2\x15.pb.UnbalancedJournalR\x12unbalancedJournalsB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_reconcile_balances_proto_rawDescOnce sync.Once
+	file_rpc_reconcile_balances_proto_rawDescData []byte
+)
+
+func file_rpc_reconcile_balances_proto_rawDescGZIP() []byte {
+	file_rpc_reconcile_balances_proto_rawDescOnce.Do(func() {
+		file_rpc_reconcile_balances_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_reconcile_balances_proto_rawDesc), len(file_rpc_reconcile_balances_proto_rawDesc)))
+	})
+	return file_rpc_reconcile_balances_proto_rawDescData
+}
+
+var file_rpc_reconcile_balances_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_rpc_reconcile_balances_proto_goTypes = []any{
+	(*ReconcileBalancesRequest)(nil),  // 0: pb.ReconcileBalancesRequest
+	(*BalanceDrift)(nil),              // 1: pb.BalanceDrift
+	(*UnbalancedJournal)(nil),         // 2: pb.UnbalancedJournal
+	(*ReconcileBalancesResponse)(nil), // 3: pb.ReconcileBalancesResponse
+}
+var file_rpc_reconcile_balances_proto_depIdxs = []int32{
+	1, // 0: pb.ReconcileBalancesResponse.drifts:type_name -> pb.BalanceDrift
+	2, // 1: pb.ReconcileBalancesResponse.unbalanced_journals:type_name -> pb.UnbalancedJournal
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_reconcile_balances_proto_init() }
+func file_rpc_reconcile_balances_proto_init() {
+	if File_rpc_reconcile_balances_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_reconcile_balances_proto_rawDesc), len(file_rpc_reconcile_balances_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_reconcile_balances_proto_goTypes,
+		DependencyIndexes: file_rpc_reconcile_balances_proto_depIdxs,
+		MessageInfos:      file_rpc_reconcile_balances_proto_msgTypes,
+	}.Build()
+	File_rpc_reconcile_balances_proto = out.File
+	file_rpc_reconcile_balances_proto_goTypes = nil
+	file_rpc_reconcile_balances_proto_depIdxs = nil
+}
@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_replay_webhook_delivery.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReplayWebhookDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId    int64                  `protobuf:"varint,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookDeliveryRequest) Reset() {
+	*x = ReplayWebhookDeliveryRequest{}
+	mi := &file_rpc_replay_webhook_delivery_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookDeliveryRequest) ProtoMessage() {}
+
+func (x *ReplayWebhookDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_replay_webhook_delivery_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_replay_webhook_delivery_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReplayWebhookDeliveryRequest) GetDeliveryId() int64 {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return 0
+}
+
+type ReplayWebhookDeliveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delivery      *WebhookDelivery       `protobuf:"bytes,1,opt,name=delivery,proto3" json:"delivery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookDeliveryResponse) Reset() {
+	*x = ReplayWebhookDeliveryResponse{}
+	mi := &file_rpc_replay_webhook_delivery_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookDeliveryResponse) ProtoMessage() {}
+
+func (x *ReplayWebhookDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_replay_webhook_delivery_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_replay_webhook_delivery_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReplayWebhookDeliveryResponse) GetDelivery() *WebhookDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+var File_rpc_replay_webhook_delivery_proto protoreflect.FileDescriptor
+
+const file_rpc_replay_webhook_delivery_proto_rawDesc = "" +
+	"\n" +
+	"!rpc_replay_webhook_delivery.proto\x12\x02pb\x1a\rwebhook.proto\"?\n" +
+	"\x1cReplayWebhookDeliveryRequest\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\x03R\n" +
+	"deliveryId\"P\n" +
+	"\x1dReplayWebhookDeliveryResponse\x12/\n" +
+	"\bdelivery\x18\x01 \x01(\v2\x13.pb.WebhookDeliveryR\bdeliveryB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_replay_webhook_delivery_proto_rawDescOnce sync.Once
+	file_rpc_replay_webhook_delivery_proto_rawDescData []byte
+)
+
+func file_rpc_replay_webhook_delivery_proto_rawDescGZIP() []byte {
+	file_rpc_replay_webhook_delivery_proto_rawDescOnce.Do(func() {
+		file_rpc_replay_webhook_delivery_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_replay_webhook_delivery_proto_rawDesc), len(file_rpc_replay_webhook_delivery_proto_rawDesc)))
+	})
+	return file_rpc_replay_webhook_delivery_proto_rawDescData
+}
+
+var file_rpc_replay_webhook_delivery_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_replay_webhook_delivery_proto_goTypes = []any{
+	(*ReplayWebhookDeliveryRequest)(nil),  // 0: pb.ReplayWebhookDeliveryRequest
+	(*ReplayWebhookDeliveryResponse)(nil), // 1: pb.ReplayWebhookDeliveryResponse
+	(*WebhookDelivery)(nil),               // 2: pb.WebhookDelivery
+}
+var file_rpc_replay_webhook_delivery_proto_depIdxs = []int32{
+	2, // 0: pb.ReplayWebhookDeliveryResponse.delivery:type_name -> pb.WebhookDelivery
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_replay_webhook_delivery_proto_init() }
+func file_rpc_replay_webhook_delivery_proto_init() {
+	if File_rpc_replay_webhook_delivery_proto != nil {
+		return
+	}
+	file_webhook_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_replay_webhook_delivery_proto_rawDesc), len(file_rpc_replay_webhook_delivery_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_replay_webhook_delivery_proto_goTypes,
+		DependencyIndexes: file_rpc_replay_webhook_delivery_proto_depIdxs,
+		MessageInfos:      file_rpc_replay_webhook_delivery_proto_msgTypes,
+	}.Build()
+	File_rpc_replay_webhook_delivery_proto = out.File
+	file_rpc_replay_webhook_delivery_proto_goTypes = nil
+	file_rpc_replay_webhook_delivery_proto_depIdxs = nil
+}
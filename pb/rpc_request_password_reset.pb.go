@@ -0,0 +1,161 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_request_password_reset.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RequestPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_rpc_request_password_reset_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_request_password_reset_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_request_password_reset_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RequestPasswordResetRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// the response is the same whether the user exists or not, the reset link is sent to the email of the user
+type RequestPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetResponse) Reset() {
+	*x = RequestPasswordResetResponse{}
+	mi := &file_rpc_request_password_reset_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetResponse) ProtoMessage() {}
+
+func (x *RequestPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_request_password_reset_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_request_password_reset_proto_rawDescGZIP(), []int{1}
+}
+
+var File_rpc_request_password_reset_proto protoreflect.FileDescriptor
+
+const file_rpc_request_password_reset_proto_rawDesc = "" +
+	"\n" +
+	" rpc_request_password_reset.proto\x12\x02pb\"9\n" +
+	"\x1bRequestPasswordResetRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\x1e\n" +
+	"\x1cRequestPasswordResetResponseB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_request_password_reset_proto_rawDescOnce sync.Once
+	file_rpc_request_password_reset_proto_rawDescData []byte
+)
+
+func file_rpc_request_password_reset_proto_rawDescGZIP() []byte {
+	file_rpc_request_password_reset_proto_rawDescOnce.Do(func() {
+		file_rpc_request_password_reset_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_request_password_reset_proto_rawDesc), len(file_rpc_request_password_reset_proto_rawDesc)))
+	})
+	return file_rpc_request_password_reset_proto_rawDescData
+}
+
+var file_rpc_request_password_reset_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_request_password_reset_proto_goTypes = []any{
+	(*RequestPasswordResetRequest)(nil),  // 0: pb.RequestPasswordResetRequest
+	(*RequestPasswordResetResponse)(nil), // 1: pb.RequestPasswordResetResponse
+}
+var file_rpc_request_password_reset_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_request_password_reset_proto_init() }
+func file_rpc_request_password_reset_proto_init() {
+	if File_rpc_request_password_reset_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_request_password_reset_proto_rawDesc), len(file_rpc_request_password_reset_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_request_password_reset_proto_goTypes,
+		DependencyIndexes: file_rpc_request_password_reset_proto_depIdxs,
+		MessageInfos:      file_rpc_request_password_reset_proto_msgTypes,
+	}.Build()
+	File_rpc_request_password_reset_proto = out.File
+	file_rpc_request_password_reset_proto_goTypes = nil
+	file_rpc_request_password_reset_proto_depIdxs = nil
+}
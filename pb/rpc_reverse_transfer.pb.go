@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_reverse_transfer.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// amount is in the source account currency, 0 reverses everything that was not reversed yet
+type ReverseTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    int64                  `protobuf:"varint,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseTransferRequest) Reset() {
+	*x = ReverseTransferRequest{}
+	mi := &file_rpc_reverse_transfer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseTransferRequest) ProtoMessage() {}
+
+func (x *ReverseTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reverse_transfer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseTransferRequest.ProtoReflect.Descriptor instead.
+func (*ReverseTransferRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_reverse_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReverseTransferRequest) GetTransferId() int64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *ReverseTransferRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ReverseTransferRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// from_account is the sender of the original transfer, who gets the money back
+type ReverseTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reversal      *TransferReversal      `protobuf:"bytes,1,opt,name=reversal,proto3" json:"reversal,omitempty"`
+	FromAccount   *Account               `protobuf:"bytes,2,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
+	ToAccount     *Account               `protobuf:"bytes,3,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
+	FromEntry     *Entry                 `protobuf:"bytes,4,opt,name=from_entry,json=fromEntry,proto3" json:"from_entry,omitempty"`
+	ToEntry       *Entry                 `protobuf:"bytes,5,opt,name=to_entry,json=toEntry,proto3" json:"to_entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseTransferResponse) Reset() {
+	*x = ReverseTransferResponse{}
+	mi := &file_rpc_reverse_transfer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseTransferResponse) ProtoMessage() {}
+
+func (x *ReverseTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reverse_transfer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseTransferResponse.ProtoReflect.Descriptor instead.
+func (*ReverseTransferResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_reverse_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReverseTransferResponse) GetReversal() *TransferReversal {
+	if x != nil {
+		return x.Reversal
+	}
+	return nil
+}
+
+func (x *ReverseTransferResponse) GetFromAccount() *Account {
+	if x != nil {
+		return x.FromAccount
+	}
+	return nil
+}
+
+func (x *ReverseTransferResponse) GetToAccount() *Account {
+	if x != nil {
+		return x.ToAccount
+	}
+	return nil
+}
+
+func (x *ReverseTransferResponse) GetFromEntry() *Entry {
+	if x != nil {
+		return x.FromEntry
+	}
+	return nil
+}
+
+func (x *ReverseTransferResponse) GetToEntry() *Entry {
+	if x != nil {
+		return x.ToEntry
+	}
+	return nil
+}
+
+var File_rpc_reverse_transfer_proto protoreflect.FileDescriptor
+
+const file_rpc_reverse_transfer_proto_rawDesc = "" +
+	"\n" +
+	"\x1arpc_reverse_transfer.proto\x12\x02pb\x1a\raccount.proto\x1a\ventry.proto\x1a\x17transfer_reversal.proto\"i\n" +
+	"\x16ReverseTransferRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\x03R\n" +
+	"transferId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xf7\x01\n" +
+	"\x17ReverseTransferResponse\x120\n" +
+	"\breversal\x18\x01 \x01(\v2\x14.pb.TransferReversalR\breversal\x12.\n" +
+	"\ffrom_account\x18\x02 \x01(\v2\v.pb.AccountR\vfromAccount\x12*\n" +
+	"\n" +
+	"to_account\x18\x03 \x01(\v2\v.pb.AccountR\ttoAccount\x12(\n" +
+	"\n" +
+	"from_entry\x18\x04 \x01(\v2\t.pb.EntryR\tfromEntry\x12$\n" +
+	"\bto_entry\x18\x05 \x01(\v2\t.pb.EntryR\atoEntryB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_reverse_transfer_proto_rawDescOnce sync.Once
+	file_rpc_reverse_transfer_proto_rawDescData []byte
+)
+
+func file_rpc_reverse_transfer_proto_rawDescGZIP() []byte {
+	file_rpc_reverse_transfer_proto_rawDescOnce.Do(func() {
+		file_rpc_reverse_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_reverse_transfer_proto_rawDesc), len(file_rpc_reverse_transfer_proto_rawDesc)))
+	})
+	return file_rpc_reverse_transfer_proto_rawDescData
+}
+
+var file_rpc_reverse_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_reverse_transfer_proto_goTypes = []any{
+	(*ReverseTransferRequest)(nil),  // 0: pb.ReverseTransferRequest
+	(*ReverseTransferResponse)(nil), // 1: pb.ReverseTransferResponse
+	(*TransferReversal)(nil),        // 2: pb.TransferReversal
+	(*Account)(nil),                 // 3: pb.Account
+	(*Entry)(nil),                   // 4: pb.Entry
+}
+var file_rpc_reverse_transfer_proto_depIdxs = []int32{
+	2, // 0: pb.ReverseTransferResponse.reversal:type_name -> pb.TransferReversal
+	3, // 1: pb.ReverseTransferResponse.from_account:type_name -> pb.Account
+	3, // 2: pb.ReverseTransferResponse.to_account:type_name -> pb.Account
+	4, // 3: pb.ReverseTransferResponse.from_entry:type_name -> pb.Entry
+	4, // 4: pb.ReverseTransferResponse.to_entry:type_name -> pb.Entry
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_rpc_reverse_transfer_proto_init() }
+func file_rpc_reverse_transfer_proto_init() {
+	if File_rpc_reverse_transfer_proto != nil {
+		return
+	}
+	file_account_proto_init()
+	file_entry_proto_init()
+	file_transfer_reversal_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_reverse_transfer_proto_rawDesc), len(file_rpc_reverse_transfer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_reverse_transfer_proto_goTypes,
+		DependencyIndexes: file_rpc_reverse_transfer_proto_depIdxs,
+		MessageInfos:      file_rpc_reverse_transfer_proto_msgTypes,
+	}.Build()
+	File_rpc_reverse_transfer_proto = out.File
+	file_rpc_reverse_transfer_proto_goTypes = nil
+	file_rpc_reverse_transfer_proto_depIdxs = nil
+}
@@ -0,0 +1,160 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_revoke_all_sessions.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RevokeAllSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllSessionsRequest) Reset() {
+	*x = RevokeAllSessionsRequest{}
+	mi := &file_rpc_revoke_all_sessions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeAllSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_revoke_all_sessions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_revoke_all_sessions_proto_rawDescGZIP(), []int{0}
+}
+
+type RevokeAllSessionsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RevokedSessions int64                  `protobuf:"varint,1,opt,name=revoked_sessions,json=revokedSessions,proto3" json:"revoked_sessions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RevokeAllSessionsResponse) Reset() {
+	*x = RevokeAllSessionsResponse{}
+	mi := &file_rpc_revoke_all_sessions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllSessionsResponse) ProtoMessage() {}
+
+func (x *RevokeAllSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_revoke_all_sessions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllSessionsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_revoke_all_sessions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RevokeAllSessionsResponse) GetRevokedSessions() int64 {
+	if x != nil {
+		return x.RevokedSessions
+	}
+	return 0
+}
+
+var File_rpc_revoke_all_sessions_proto protoreflect.FileDescriptor
+
+const file_rpc_revoke_all_sessions_proto_rawDesc = "" +
+	"\n" +
+	"\x1drpc_revoke_all_sessions.proto\x12\x02pb\"\x1a\n" +
+	"\x18RevokeAllSessionsRequest\"F\n" +
+	"\x19RevokeAllSessionsResponse\x12)\n" +
+	"\x10revoked_sessions\x18\x01 \x01(\x03R\x0frevokedSessionsB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_revoke_all_sessions_proto_rawDescOnce sync.Once
+	file_rpc_revoke_all_sessions_proto_rawDescData []byte
+)
+
+func file_rpc_revoke_all_sessions_proto_rawDescGZIP() []byte {
+	file_rpc_revoke_all_sessions_proto_rawDescOnce.Do(func() {
+		file_rpc_revoke_all_sessions_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_revoke_all_sessions_proto_rawDesc), len(file_rpc_revoke_all_sessions_proto_rawDesc)))
+	})
+	return file_rpc_revoke_all_sessions_proto_rawDescData
+}
+
+var file_rpc_revoke_all_sessions_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_revoke_all_sessions_proto_goTypes = []any{
+	(*RevokeAllSessionsRequest)(nil),  // 0: pb.RevokeAllSessionsRequest
+	(*RevokeAllSessionsResponse)(nil), // 1: pb.RevokeAllSessionsResponse
+}
+var file_rpc_revoke_all_sessions_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_revoke_all_sessions_proto_init() }
+func file_rpc_revoke_all_sessions_proto_init() {
+	if File_rpc_revoke_all_sessions_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_revoke_all_sessions_proto_rawDesc), len(file_rpc_revoke_all_sessions_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_revoke_all_sessions_proto_goTypes,
+		DependencyIndexes: file_rpc_revoke_all_sessions_proto_depIdxs,
+		MessageInfos:      file_rpc_revoke_all_sessions_proto_msgTypes,
+	}.Build()
+	File_rpc_revoke_all_sessions_proto = out.File
+	file_rpc_revoke_all_sessions_proto_goTypes = nil
+	file_rpc_revoke_all_sessions_proto_depIdxs = nil
+}
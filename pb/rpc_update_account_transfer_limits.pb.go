@@ -0,0 +1,218 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_update_account_transfer_limits.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// an unset limit falls back to the default of the account currency.
+// Owners may only set limits up to the defaults, bankers may set any limit
+type UpdateAccountTransferLimitsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	MaxPerTransfer *int64                 `protobuf:"varint,2,opt,name=max_per_transfer,json=maxPerTransfer,proto3,oneof" json:"max_per_transfer,omitempty"`
+	DailyAmount    *int64                 `protobuf:"varint,3,opt,name=daily_amount,json=dailyAmount,proto3,oneof" json:"daily_amount,omitempty"`
+	DailyCount     *int32                 `protobuf:"varint,4,opt,name=daily_count,json=dailyCount,proto3,oneof" json:"daily_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateAccountTransferLimitsRequest) Reset() {
+	*x = UpdateAccountTransferLimitsRequest{}
+	mi := &file_rpc_update_account_transfer_limits_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountTransferLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountTransferLimitsRequest) ProtoMessage() {}
+
+func (x *UpdateAccountTransferLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_update_account_transfer_limits_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountTransferLimitsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAccountTransferLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_update_account_transfer_limits_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpdateAccountTransferLimitsRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *UpdateAccountTransferLimitsRequest) GetMaxPerTransfer() int64 {
+	if x != nil && x.MaxPerTransfer != nil {
+		return *x.MaxPerTransfer
+	}
+	return 0
+}
+
+func (x *UpdateAccountTransferLimitsRequest) GetDailyAmount() int64 {
+	if x != nil && x.DailyAmount != nil {
+		return *x.DailyAmount
+	}
+	return 0
+}
+
+func (x *UpdateAccountTransferLimitsRequest) GetDailyCount() int32 {
+	if x != nil && x.DailyCount != nil {
+		return *x.DailyCount
+	}
+	return 0
+}
+
+type UpdateAccountTransferLimitsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limits        *TransferLimits        `protobuf:"bytes,1,opt,name=limits,proto3" json:"limits,omitempty"`
+	Allowance     *TransferAllowance     `protobuf:"bytes,2,opt,name=allowance,proto3" json:"allowance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAccountTransferLimitsResponse) Reset() {
+	*x = UpdateAccountTransferLimitsResponse{}
+	mi := &file_rpc_update_account_transfer_limits_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountTransferLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountTransferLimitsResponse) ProtoMessage() {}
+
+func (x *UpdateAccountTransferLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_update_account_transfer_limits_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountTransferLimitsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAccountTransferLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_update_account_transfer_limits_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpdateAccountTransferLimitsResponse) GetLimits() *TransferLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *UpdateAccountTransferLimitsResponse) GetAllowance() *TransferAllowance {
+	if x != nil {
+		return x.Allowance
+	}
+	return nil
+}
+
+var File_rpc_update_account_transfer_limits_proto protoreflect.FileDescriptor
+
+const file_rpc_update_account_transfer_limits_proto_rawDesc = "" +
+	"\n" +
+	"(rpc_update_account_transfer_limits.proto\x12\x02pb\x1a\x14transfer_limit.proto\"\xf6\x01\n" +
+	"\"UpdateAccountTransferLimitsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12-\n" +
+	"\x10max_per_transfer\x18\x02 \x01(\x03H\x00R\x0emaxPerTransfer\x88\x01\x01\x12&\n" +
+	"\fdaily_amount\x18\x03 \x01(\x03H\x01R\vdailyAmount\x88\x01\x01\x12$\n" +
+	"\vdaily_count\x18\x04 \x01(\x05H\x02R\n" +
+	"dailyCount\x88\x01\x01B\x13\n" +
+	"\x11_max_per_transferB\x0f\n" +
+	"\r_daily_amountB\x0e\n" +
+	"\f_daily_count\"\x86\x01\n" +
+	"#UpdateAccountTransferLimitsResponse\x12*\n" +
+	"\x06limits\x18\x01 \x01(\v2\x12.pb.TransferLimitsR\x06limits\x123\n" +
+	"\tallowance\x18\x02 \x01(\v2\x15.pb.TransferAllowanceR\tallowanceB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_update_account_transfer_limits_proto_rawDescOnce sync.Once
+	file_rpc_update_account_transfer_limits_proto_rawDescData []byte
+)
+
+func file_rpc_update_account_transfer_limits_proto_rawDescGZIP() []byte {
+	file_rpc_update_account_transfer_limits_proto_rawDescOnce.Do(func() {
+		file_rpc_update_account_transfer_limits_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_update_account_transfer_limits_proto_rawDesc), len(file_rpc_update_account_transfer_limits_proto_rawDesc)))
+	})
+	return file_rpc_update_account_transfer_limits_proto_rawDescData
+}
+
+var file_rpc_update_account_transfer_limits_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_update_account_transfer_limits_proto_goTypes = []any{
+	(*UpdateAccountTransferLimitsRequest)(nil),  // 0: pb.UpdateAccountTransferLimitsRequest
+	(*UpdateAccountTransferLimitsResponse)(nil), // 1: pb.UpdateAccountTransferLimitsResponse
+	(*TransferLimits)(nil),                      // 2: pb.TransferLimits
+	(*TransferAllowance)(nil),                   // 3: pb.TransferAllowance
+}
+var file_rpc_update_account_transfer_limits_proto_depIdxs = []int32{
+	2, // 0: pb.UpdateAccountTransferLimitsResponse.limits:type_name -> pb.TransferLimits
+	3, // 1: pb.UpdateAccountTransferLimitsResponse.allowance:type_name -> pb.TransferAllowance
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_update_account_transfer_limits_proto_init() }
+func file_rpc_update_account_transfer_limits_proto_init() {
+	if File_rpc_update_account_transfer_limits_proto != nil {
+		return
+	}
+	file_transfer_limit_proto_init()
+	file_rpc_update_account_transfer_limits_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_update_account_transfer_limits_proto_rawDesc), len(file_rpc_update_account_transfer_limits_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_update_account_transfer_limits_proto_goTypes,
+		DependencyIndexes: file_rpc_update_account_transfer_limits_proto_depIdxs,
+		MessageInfos:      file_rpc_update_account_transfer_limits_proto_msgTypes,
+	}.Build()
+	File_rpc_update_account_transfer_limits_proto = out.File
+	file_rpc_update_account_transfer_limits_proto_goTypes = nil
+	file_rpc_update_account_transfer_limits_proto_depIdxs = nil
+}
@@ -0,0 +1,233 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_verify_mfa.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// challenge_token comes from LoginUser, code is a code of the authenticator app or a recovery code
+type VerifyMFARequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeToken string                 `protobuf:"bytes,1,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	Code           string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VerifyMFARequest) Reset() {
+	*x = VerifyMFARequest{}
+	mi := &file_rpc_verify_mfa_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFARequest) ProtoMessage() {}
+
+func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_verify_mfa_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFARequest.ProtoReflect.Descriptor instead.
+func (*VerifyMFARequest) Descriptor() ([]byte, []int) {
+	return file_rpc_verify_mfa_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VerifyMFARequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *VerifyMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VerifyMFAResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	User                  *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	SessionId             string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XToken                string                 `protobuf:"bytes,3,opt,name=_token,json=Token,proto3" json:"_token,omitempty"`
+	XRefreshToken         string                 `protobuf:"bytes,4,opt,name=_refresh_token,json=RefreshToken,proto3" json:"_refresh_token,omitempty"`
+	TokenExpiredAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=token_expired_at,json=tokenExpiredAt,proto3" json:"token_expired_at,omitempty"`
+	RefreshTokenExpiredAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=refresh_token_expired_at,json=refreshTokenExpiredAt,proto3" json:"refresh_token_expired_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *VerifyMFAResponse) Reset() {
+	*x = VerifyMFAResponse{}
+	mi := &file_rpc_verify_mfa_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFAResponse) ProtoMessage() {}
+
+func (x *VerifyMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_verify_mfa_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFAResponse.ProtoReflect.Descriptor instead.
+func (*VerifyMFAResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_verify_mfa_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VerifyMFAResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *VerifyMFAResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *VerifyMFAResponse) GetXToken() string {
+	if x != nil {
+		return x.XToken
+	}
+	return ""
+}
+
+func (x *VerifyMFAResponse) GetXRefreshToken() string {
+	if x != nil {
+		return x.XRefreshToken
+	}
+	return ""
+}
+
+func (x *VerifyMFAResponse) GetTokenExpiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TokenExpiredAt
+	}
+	return nil
+}
+
+func (x *VerifyMFAResponse) GetRefreshTokenExpiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshTokenExpiredAt
+	}
+	return nil
+}
+
+var File_rpc_verify_mfa_proto protoreflect.FileDescriptor
+
+const file_rpc_verify_mfa_proto_rawDesc = "" +
+	"\n" +
+	"\x14rpc_verify_mfa.proto\x12\x02pb\x1a\n" +
+	"user.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"O\n" +
+	"\x10VerifyMFARequest\x12'\n" +
+	"\x0fchallenge_token\x18\x01 \x01(\tR\x0echallengeToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\xa8\x02\n" +
+	"\x11VerifyMFAResponse\x12\x1c\n" +
+	"\x04user\x18\x01 \x01(\v2\b.pb.UserR\x04user\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x15\n" +
+	"\x06_token\x18\x03 \x01(\tR\x05Token\x12$\n" +
+	"\x0e_refresh_token\x18\x04 \x01(\tR\fRefreshToken\x12D\n" +
+	"\x10token_expired_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0etokenExpiredAt\x12S\n" +
+	"\x18refresh_token_expired_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x15refreshTokenExpiredAtB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_verify_mfa_proto_rawDescOnce sync.Once
+	file_rpc_verify_mfa_proto_rawDescData []byte
+)
+
+func file_rpc_verify_mfa_proto_rawDescGZIP() []byte {
+	file_rpc_verify_mfa_proto_rawDescOnce.Do(func() {
+		file_rpc_verify_mfa_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_verify_mfa_proto_rawDesc), len(file_rpc_verify_mfa_proto_rawDesc)))
+	})
+	return file_rpc_verify_mfa_proto_rawDescData
+}
+
+var file_rpc_verify_mfa_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_verify_mfa_proto_goTypes = []any{
+	(*VerifyMFARequest)(nil),      // 0: pb.VerifyMFARequest
+	(*VerifyMFAResponse)(nil),     // 1: pb.VerifyMFAResponse
+	(*User)(nil),                  // 2: pb.User
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_rpc_verify_mfa_proto_depIdxs = []int32{
+	2, // 0: pb.VerifyMFAResponse.user:type_name -> pb.User
+	3, // 1: pb.VerifyMFAResponse.token_expired_at:type_name -> google.protobuf.Timestamp
+	3, // 2: pb.VerifyMFAResponse.refresh_token_expired_at:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rpc_verify_mfa_proto_init() }
+func file_rpc_verify_mfa_proto_init() {
+	if File_rpc_verify_mfa_proto != nil {
+		return
+	}
+	file_user_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_verify_mfa_proto_rawDesc), len(file_rpc_verify_mfa_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_verify_mfa_proto_goTypes,
+		DependencyIndexes: file_rpc_verify_mfa_proto_depIdxs,
+		MessageInfos:      file_rpc_verify_mfa_proto_msgTypes,
+	}.Build()
+	File_rpc_verify_mfa_proto = out.File
+	file_rpc_verify_mfa_proto_goTypes = nil
+	file_rpc_verify_mfa_proto_depIdxs = nil
+}
@@ -0,0 +1,198 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: rpc_watch_account.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WatchAccountRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// id of the last entry received before a reconnect, the entries posted after it are sent first.
+	// 0 only streams the entries posted from now on
+	AfterEntryId  int64 `protobuf:"varint,2,opt,name=after_entry_id,json=afterEntryId,proto3" json:"after_entry_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAccountRequest) Reset() {
+	*x = WatchAccountRequest{}
+	mi := &file_rpc_watch_account_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAccountRequest) ProtoMessage() {}
+
+func (x *WatchAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_watch_account_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAccountRequest.ProtoReflect.Descriptor instead.
+func (*WatchAccountRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_watch_account_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchAccountRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *WatchAccountRequest) GetAfterEntryId() int64 {
+	if x != nil {
+		return x.AfterEntryId
+	}
+	return 0
+}
+
+type WatchAccountResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the account as it was when the message was sent
+	Account *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// empty in the first message of the stream
+	Entry         *Entry `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAccountResponse) Reset() {
+	*x = WatchAccountResponse{}
+	mi := &file_rpc_watch_account_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAccountResponse) ProtoMessage() {}
+
+func (x *WatchAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_watch_account_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAccountResponse.ProtoReflect.Descriptor instead.
+func (*WatchAccountResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_watch_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WatchAccountResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *WatchAccountResponse) GetEntry() *Entry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+var File_rpc_watch_account_proto protoreflect.FileDescriptor
+
+const file_rpc_watch_account_proto_rawDesc = "" +
+	"\n" +
+	"\x17rpc_watch_account.proto\x12\x02pb\x1a\raccount.proto\x1a\ventry.proto\"Z\n" +
+	"\x13WatchAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12$\n" +
+	"\x0eafter_entry_id\x18\x02 \x01(\x03R\fafterEntryId\"^\n" +
+	"\x14WatchAccountResponse\x12%\n" +
+	"\aaccount\x18\x01 \x01(\v2\v.pb.AccountR\aaccount\x12\x1f\n" +
+	"\x05entry\x18\x02 \x01(\v2\t.pb.EntryR\x05entryB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_rpc_watch_account_proto_rawDescOnce sync.Once
+	file_rpc_watch_account_proto_rawDescData []byte
+)
+
+func file_rpc_watch_account_proto_rawDescGZIP() []byte {
+	file_rpc_watch_account_proto_rawDescOnce.Do(func() {
+		file_rpc_watch_account_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_watch_account_proto_rawDesc), len(file_rpc_watch_account_proto_rawDesc)))
+	})
+	return file_rpc_watch_account_proto_rawDescData
+}
+
+var file_rpc_watch_account_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_watch_account_proto_goTypes = []any{
+	(*WatchAccountRequest)(nil),  // 0: pb.WatchAccountRequest
+	(*WatchAccountResponse)(nil), // 1: pb.WatchAccountResponse
+	(*Account)(nil),              // 2: pb.Account
+	(*Entry)(nil),                // 3: pb.Entry
+}
+var file_rpc_watch_account_proto_depIdxs = []int32{
+	2, // 0: pb.WatchAccountResponse.account:type_name -> pb.Account
+	3, // 1: pb.WatchAccountResponse.entry:type_name -> pb.Entry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_watch_account_proto_init() }
+func file_rpc_watch_account_proto_init() {
+	if File_rpc_watch_account_proto != nil {
+		return
+	}
+	file_account_proto_init()
+	file_entry_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_watch_account_proto_rawDesc), len(file_rpc_watch_account_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_watch_account_proto_goTypes,
+		DependencyIndexes: file_rpc_watch_account_proto_depIdxs,
+		MessageInfos:      file_rpc_watch_account_proto_msgTypes,
+	}.Build()
+	File_rpc_watch_account_proto = out.File
+	file_rpc_watch_account_proto_goTypes = nil
+	file_rpc_watch_account_proto_depIdxs = nil
+}
@@ -0,0 +1,341 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: scheduled_transfer.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ScheduledTransfer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	FromAccountId int64                  `protobuf:"varint,3,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId   int64                  `protobuf:"varint,4,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	// RRULE subset like FREQ=MONTHLY;BYMONTHDAY=1, empty for a one-off transfer
+	Recurrence string                 `protobuf:"bytes,7,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	StartAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	// not set when the transfer repeats forever
+	EndAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	NextRunAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	// active, completed or cancelled
+	Status        string                 `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledTransfer) Reset() {
+	*x = ScheduledTransfer{}
+	mi := &file_scheduled_transfer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTransfer) ProtoMessage() {}
+
+func (x *ScheduledTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduled_transfer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTransfer.ProtoReflect.Descriptor instead.
+func (*ScheduledTransfer) Descriptor() ([]byte, []int) {
+	return file_scheduled_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ScheduledTransfer) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ScheduledTransfer) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ScheduledTransfer) GetFromAccountId() int64 {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return 0
+}
+
+func (x *ScheduledTransfer) GetToAccountId() int64 {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return 0
+}
+
+func (x *ScheduledTransfer) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ScheduledTransfer) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ScheduledTransfer) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *ScheduledTransfer) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *ScheduledTransfer) GetEndAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndAt
+	}
+	return nil
+}
+
+func (x *ScheduledTransfer) GetNextRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return nil
+}
+
+func (x *ScheduledTransfer) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledTransfer) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ScheduledTransferRun struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ScheduledTransferId int64                  `protobuf:"varint,2,opt,name=scheduled_transfer_id,json=scheduledTransferId,proto3" json:"scheduled_transfer_id,omitempty"`
+	OccurrenceAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurrence_at,json=occurrenceAt,proto3" json:"occurrence_at,omitempty"`
+	// succeeded or failed
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// 0 when the run failed
+	TransferId    int64                  `protobuf:"varint,5,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledTransferRun) Reset() {
+	*x = ScheduledTransferRun{}
+	mi := &file_scheduled_transfer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledTransferRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTransferRun) ProtoMessage() {}
+
+func (x *ScheduledTransferRun) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduled_transfer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTransferRun.ProtoReflect.Descriptor instead.
+func (*ScheduledTransferRun) Descriptor() ([]byte, []int) {
+	return file_scheduled_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScheduledTransferRun) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ScheduledTransferRun) GetScheduledTransferId() int64 {
+	if x != nil {
+		return x.ScheduledTransferId
+	}
+	return 0
+}
+
+func (x *ScheduledTransferRun) GetOccurrenceAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurrenceAt
+	}
+	return nil
+}
+
+func (x *ScheduledTransferRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledTransferRun) GetTransferId() int64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *ScheduledTransferRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ScheduledTransferRun) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_scheduled_transfer_proto protoreflect.FileDescriptor
+
+const file_scheduled_transfer_proto_rawDesc = "" +
+	"\n" +
+	"\x18scheduled_transfer.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd2\x03\n" +
+	"\x11ScheduledTransfer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12&\n" +
+	"\x0ffrom_account_id\x18\x03 \x01(\x03R\rfromAccountId\x12\"\n" +
+	"\rto_account_id\x18\x04 \x01(\x03R\vtoAccountId\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\a \x01(\tR\n" +
+	"recurrence\x125\n" +
+	"\bstart_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x121\n" +
+	"\x06end_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\x12:\n" +
+	"\vnext_run_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tnextRunAt\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa5\x02\n" +
+	"\x14ScheduledTransferRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x122\n" +
+	"\x15scheduled_transfer_id\x18\x02 \x01(\x03R\x13scheduledTransferId\x12?\n" +
+	"\roccurrence_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\foccurrenceAt\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1f\n" +
+	"\vtransfer_id\x18\x05 \x01(\x03R\n" +
+	"transferId\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_scheduled_transfer_proto_rawDescOnce sync.Once
+	file_scheduled_transfer_proto_rawDescData []byte
+)
+
+func file_scheduled_transfer_proto_rawDescGZIP() []byte {
+	file_scheduled_transfer_proto_rawDescOnce.Do(func() {
+		file_scheduled_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_scheduled_transfer_proto_rawDesc), len(file_scheduled_transfer_proto_rawDesc)))
+	})
+	return file_scheduled_transfer_proto_rawDescData
+}
+
+var file_scheduled_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_scheduled_transfer_proto_goTypes = []any{
+	(*ScheduledTransfer)(nil),     // 0: pb.ScheduledTransfer
+	(*ScheduledTransferRun)(nil),  // 1: pb.ScheduledTransferRun
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_scheduled_transfer_proto_depIdxs = []int32{
+	2, // 0: pb.ScheduledTransfer.start_at:type_name -> google.protobuf.Timestamp
+	2, // 1: pb.ScheduledTransfer.end_at:type_name -> google.protobuf.Timestamp
+	2, // 2: pb.ScheduledTransfer.next_run_at:type_name -> google.protobuf.Timestamp
+	2, // 3: pb.ScheduledTransfer.created_at:type_name -> google.protobuf.Timestamp
+	2, // 4: pb.ScheduledTransferRun.occurrence_at:type_name -> google.protobuf.Timestamp
+	2, // 5: pb.ScheduledTransferRun.created_at:type_name -> google.protobuf.Timestamp
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_scheduled_transfer_proto_init() }
+func file_scheduled_transfer_proto_init() {
+	if File_scheduled_transfer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scheduled_transfer_proto_rawDesc), len(file_scheduled_transfer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_scheduled_transfer_proto_goTypes,
+		DependencyIndexes: file_scheduled_transfer_proto_depIdxs,
+		MessageInfos:      file_scheduled_transfer_proto_msgTypes,
+	}.Build()
+	File_scheduled_transfer_proto = out.File
+	file_scheduled_transfer_proto_goTypes = nil
+	file_scheduled_transfer_proto_depIdxs = nil
+}
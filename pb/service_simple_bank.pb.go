@@ -26,7 +26,7 @@ var File_service_simple_bank_proto protoreflect.FileDescriptor
 
 const file_service_simple_bank_proto_rawDesc = "" +
 	"\n" +
-	"\x19service_simple_bank.proto\x12\x02pb\x1a\x1cgoogle/api/annotations.proto\x1a\x15rpc_create_user.proto\x1a\x14rpc_login_user.proto\x1a\x15rpc_update_user.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\x85\x03\n" +
+	"\x19service_simple_bank.proto\x12\x02pb\x1a\x1cgoogle/api/annotations.proto\x1a\x15rpc_create_user.proto\x1a\x14rpc_login_user.proto\x1a\x15rpc_update_user.proto\x1a\x18rpc_create_account.proto\x1a\x15rpc_get_account.proto\x1a\x17rpc_list_accounts.proto\x1a\x19rpc_create_transfer.proto\x1a\x1frpc_get_account_statement.proto\x1a\x16rpc_list_entries.proto\x1a\x18rpc_list_transfers.proto\x1a\x17rpc_list_sessions.proto\x1a\x18rpc_revoke_session.proto\x1a\x1drpc_revoke_all_sessions.proto\x1a\x1crpc_renew_access_token.proto\x1a\x16rpc_verify_email.proto\x1a!rpc_create_webhook_endpoint.proto\x1a rpc_list_webhook_endpoints.proto\x1a!rpc_delete_webhook_endpoint.proto\x1a!rpc_list_webhook_deliveries.proto\x1a!rpc_replay_webhook_delivery.proto\x1a\x17rpc_watch_account.proto\x1a\x1erpc_list_ledger_accounts.proto\x1a\x1crpc_reconcile_balances.proto\x1a\x11rpc_deposit.proto\x1a\x12rpc_withdraw.proto\x1a\x1arpc_reverse_transfer.proto\x1a#rpc_create_scheduled_transfer.proto\x1a\"rpc_list_scheduled_transfers.proto\x1a#rpc_cancel_scheduled_transfer.proto\x1a&rpc_list_scheduled_transfer_runs.proto\x1a\x1frpc_update_account_status.proto\x1a%rpc_list_account_status_changes.proto\x1a%rpc_get_account_transfer_limits.proto\x1a(rpc_update_account_transfer_limits.proto\x1a\x14rpc_enroll_mfa.proto\x1a\x15rpc_confirm_mfa.proto\x1a\x15rpc_disable_mfa.proto\x1a\x14rpc_verify_mfa.proto\x1a\x1arpc_confirm_transfer.proto\x1a rpc_request_password_reset.proto\x1a\x18rpc_reset_password.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xe7A\n" +
 	"\n" +
 	"SimpleBank\x12\x80\x01\n" +
 	"\n" +
@@ -34,30 +34,225 @@ const file_service_simple_bank_proto_rawDesc = "" +
 	"\tLoginUser\x12\x14.pb.LoginUserRequest\x1a\x15.pb.LoginUserResponse\"<\x92A \x12\n" +
 	"Login User\x1a\x12API for user login\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/login_user\x12|\n" +
 	"\n" +
-	"UpdateUser\x12\x15.pb.UpdateUserRequest\x1a\x16.pb.UpdateUserResponse\"?\x92A\"\x12\vUpdate User\x1a\x13API for update user\x82\xd3\xe4\x93\x02\x14:\x01*2\x0f/v1/update_userB{\x92AX\x12V\n" +
+	"UpdateUser\x12\x15.pb.UpdateUserRequest\x1a\x16.pb.UpdateUserResponse\"?\x92A\"\x12\vUpdate User\x1a\x13API for update user\x82\xd3\xe4\x93\x02\x14:\x01*2\x0f/v1/update_user\x12\x8e\x01\n" +
+	"\rCreateAccount\x12\x18.pb.CreateAccountRequest\x1a\x19.pb.CreateAccountResponse\"H\x92A(\x12\x0eCreate Account\x1a\x16API for create account\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/create_account\x12\x8d\x01\n" +
+	"\n" +
+	"GetAccount\x12\x15.pb.GetAccountRequest\x1a\x16.pb.GetAccountResponse\"P\x92A4\x12\vGet Account\x1a%API for get account owned by the user\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/accounts/{id}\x12\x92\x01\n" +
+	"\fListAccounts\x12\x17.pb.ListAccountsRequest\x1a\x18.pb.ListAccountsResponse\"O\x92A8\x12\rList Accounts\x1a'API for list accounts owned by the user\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/accounts\x12\xa8\x01\n" +
+	"\x0eCreateTransfer\x12\x19.pb.CreateTransferRequest\x1a\x1a.pb.CreateTransferResponse\"_\x92A>\x12\x0fCreate Transfer\x1a+API for transfer money between two accounts\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/create_transfer\x12\xdf\x01\n" +
+	"\x13GetAccountStatement\x12\x1e.pb.GetAccountStatementRequest\x1a\x1f.pb.GetAccountStatementResponse\"\x86\x01\x92AX\x12\x15Get Account Statement\x1a?API for account statement with running balances within a period\x82\xd3\xe4\x93\x02%\x12#/v1/accounts/{account_id}/statement\x12\xb0\x01\n" +
+	"\vListEntries\x12\x16.pb.ListEntriesRequest\x1a\x17.pb.ListEntriesResponse\"p\x92AD\x12\fList Entries\x1a4API for list entries of an account owned by the user\x82\xd3\xe4\x93\x02#\x12!/v1/accounts/{account_id}/entries\x12\xc4\x01\n" +
+	"\rListTransfers\x12\x18.pb.ListTransfersRequest\x1a\x19.pb.ListTransfersResponse\"~\x92AP\x12\x0eList Transfers\x1a>API for list transfers from or to an account owned by the user\x82\xd3\xe4\x93\x02%\x12#/v1/accounts/{account_id}/transfers\x12\x93\x01\n" +
+	"\fListSessions\x12\x17.pb.ListSessionsRequest\x1a\x18.pb.ListSessionsResponse\"P\x92A9\x12\rList Sessions\x1a(API for list active sessions of the user\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/sessions\x12\xca\x01\n" +
+	"\rRevokeSession\x12\x18.pb.RevokeSessionRequest\x1a\x19.pb.RevokeSessionResponse\"\x83\x01\x92AU\x12\x0eRevoke Session\x1aCAPI for revoke a session so its refresh token can no longer be used\x82\xd3\xe4\x93\x02%:\x01*\" /v1/sessions/{session_id}/revoke\x12\xca\x01\n" +
+	"\x11RevokeAllSessions\x12\x1c.pb.RevokeAllSessionsRequest\x1a\x1d.pb.RevokeAllSessionsResponse\"x\x92AS\x12\x13Revoke All Sessions\x1a<API for log out the user everywhere by revoking all sessions\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/sessions/revoke_all\x12\xde\x01\n" +
+	"\x10RenewAccessToken\x12\x1b.pb.RenewAccessTokenRequest\x1a\x1c.pb.RenewAccessTokenResponse\"\x8e\x01\x92Aj\x12\x12Renew Access Token\x1aTAPI for renew the access token, the refresh token is rotated and can't be used again\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/renew_access_token\x12\xb5\x01\n" +
+	"\vVerifyEmail\x12\x16.pb.VerifyEmailRequest\x1a\x17.pb.VerifyEmailResponse\"u\x92AZ\x12\fVerify Email\x1aJAPI for verify the email address of a new user with the code sent by email\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/verify_email\x12\xef\x01\n" +
+	"\x15CreateWebhookEndpoint\x12 .pb.CreateWebhookEndpointRequest\x1a!.pb.CreateWebhookEndpointResponse\"\x90\x01\x92Av\x12\x17Create Webhook Endpoint\x1a[API for register a webhook endpoint receiving signed notifications of the subscribed events\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/webhooks\x12\xc1\x01\n" +
+	"\x14ListWebhookEndpoints\x12\x1f.pb.ListWebhookEndpointsRequest\x1a .pb.ListWebhookEndpointsResponse\"f\x92AO\x12\x16List Webhook Endpoints\x1a5API for list the active webhook endpoints of the user\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/webhooks\x12\xe5\x01\n" +
+	"\x15DeleteWebhookEndpoint\x12 .pb.DeleteWebhookEndpointRequest\x1a!.pb.DeleteWebhookEndpointResponse\"\x86\x01\x92Aa\x12\x17Delete Webhook Endpoint\x1aFAPI for delete a webhook endpoint, its pending deliveries are not sent\x82\xd3\xe4\x93\x02\x1c*\x1a/v1/webhooks/{endpoint_id}\x12\xdc\x01\n" +
+	"\x15ListWebhookDeliveries\x12 .pb.ListWebhookDeliveriesRequest\x1a!.pb.ListWebhookDeliveriesResponse\"~\x92AN\x12\x17List Webhook Deliveries\x1a3API for list the delivery log of a webhook endpoint\x82\xd3\xe4\x93\x02'\x12%/v1/webhooks/{endpoint_id}/deliveries\x12\xef\x01\n" +
+	"\x15ReplayWebhookDelivery\x12 .pb.ReplayWebhookDeliveryRequest\x1a!.pb.ReplayWebhookDeliveryResponse\"\x90\x01\x92AW\x12\x17Replay Webhook Delivery\x1a<API for send a webhook delivery again with the same event id\x82\xd3\xe4\x93\x020:\x01*\"+/v1/webhook_deliveries/{delivery_id}/replay\x12\xb2\x01\n" +
+	"\fWatchAccount\x12\x17.pb.WatchAccountRequest\x1a\x18.pb.WatchAccountResponse\"m\x92Aj\x12\rWatch Account\x1aYAPI for follow the balance of an account, a message is sent whenever an entry posts to it0\x01\x12\xdf\x01\n" +
+	"\x12ListLedgerAccounts\x12\x1d.pb.ListLedgerAccountsRequest\x1a\x1e.pb.ListLedgerAccountsResponse\"\x89\x01\x92Ak\x12\x14List Ledger Accounts\x1aSAPI for list the system ledger accounts with their balance, only bankers may use it\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/ledger_accounts\x12\xde\x01\n" +
+	"\x11ReconcileBalances\x12\x1c.pb.ReconcileBalancesRequest\x1a\x1d.pb.ReconcileBalancesResponse\"\x8b\x01\x92Ag\x12\x12Reconcile Balances\x1aQAPI for check the account balances against their entries, only bankers may use it\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/reconcile_balances\x12\xb6\x01\n" +
+	"\aDeposit\x12\x12.pb.DepositRequest\x1a\x13.pb.DepositResponse\"\x81\x01\x92Ag\x12\aDeposit\x1a\\API for credit an account against the cash account, only bankers and integrations may use it\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/deposits\x12\xbc\x01\n" +
+	"\bWithdraw\x12\x13.pb.WithdrawRequest\x1a\x14.pb.WithdrawResponse\"\x84\x01\x92Ag\x12\bWithdraw\x1a[API for debit an account against the cash account, only bankers and integrations may use it\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/withdrawals\x12\xe8\x01\n" +
+	"\x0fReverseTransfer\x12\x1a.pb.ReverseTransferRequest\x1a\x1b.pb.ReverseTransferResponse\"\x9b\x01\x92Aj\x12\x10Reverse Transfer\x1aVAPI for give back all or part of a transfer, only the recipient or a banker may use it\x82\xd3\xe4\x93\x02(:\x01*\"#/v1/transfers/{transfer_id}/reverse\x12\xd6\x01\n" +
+	"\x17CreateScheduledTransfer\x12\".pb.CreateScheduledTransferRequest\x1a#.pb.CreateScheduledTransferResponse\"r\x92AM\x12\x19Create Scheduled Transfer\x1a0API for schedule a one-off or recurring transfer\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/scheduled_transfers\x12\xcf\x01\n" +
+	"\x16ListScheduledTransfers\x12!.pb.ListScheduledTransfersRequest\x1a\".pb.ListScheduledTransfersResponse\"n\x92AL\x12\x18List Scheduled Transfers\x1a0API for list the scheduled transfers of the user\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/scheduled_transfers\x12\xde\x01\n" +
+	"\x17CancelScheduledTransfer\x12\".pb.CancelScheduledTransferRequest\x1a#.pb.CancelScheduledTransferResponse\"z\x92A@\x12\x19Cancel Scheduled Transfer\x1a#API for cancel a scheduled transfer\x82\xd3\xe4\x93\x021*//v1/scheduled_transfers/{scheduled_transfer_id}\x12\x8b\x02\n" +
+	"\x19ListScheduledTransferRuns\x12$.pb.ListScheduledTransferRunsRequest\x1a%.pb.ListScheduledTransferRunsResponse\"\xa0\x01\x92Aa\x12\x1cList Scheduled Transfer Runs\x1aAAPI for list the runs of a scheduled transfer with their failures\x82\xd3\xe4\x93\x026\x124/v1/scheduled_transfers/{scheduled_transfer_id}/runs\x12\xe8\x01\n" +
+	"\x13UpdateAccountStatus\x12\x1e.pb.UpdateAccountStatusRequest\x1a\x1f.pb.UpdateAccountStatusResponse\"\x8f\x01\x92Aa\x12\x15Update Account Status\x1aHAPI for freeze, close or reopen an account with the reason of the change\x82\xd3\xe4\x93\x02%:\x01*\" /v1/accounts/{account_id}/status\x12\xe6\x01\n" +
+	"\x18ListAccountStatusChanges\x12#.pb.ListAccountStatusChangesRequest\x1a$.pb.ListAccountStatusChangesResponse\"\x7f\x92AL\x12\x1bList Account Status Changes\x1a-API for list the status changes of an account\x82\xd3\xe4\x93\x02*\x12(/v1/accounts/{account_id}/status_changes\x12\x89\x02\n" +
+	"\x18GetAccountTransferLimits\x12#.pb.GetAccountTransferLimitsRequest\x1a$.pb.GetAccountTransferLimitsResponse\"\xa1\x01\x92Am\x12\x1bGet Account Transfer Limits\x1aNAPI for get the transfer limits of an account and what it may still send today\x82\xd3\xe4\x93\x02+\x12)/v1/accounts/{account_id}/transfer_limits\x12\xa9\x02\n" +
+	"\x1bUpdateAccountTransferLimits\x12&.pb.UpdateAccountTransferLimitsRequest\x1a'.pb.UpdateAccountTransferLimitsResponse\"\xb8\x01\x92A\x80\x01\x12\x1eUpdate Account Transfer Limits\x1a^API for set the transfer limits of an account, unset limits fall back to the currency defaults\x82\xd3\xe4\x93\x02.:\x01*\x1a)/v1/accounts/{account_id}/transfer_limits\x12\xca\x01\n" +
+	"\tEnrollMFA\x12\x14.pb.EnrollMFARequest\x1a\x15.pb.EnrollMFAResponse\"\x8f\x01\x92As\x12\n" +
+	"Enroll MFA\x1aeAPI for start the enrollment of an authenticator app, it returns the TOTP secret and provisioning uri\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/mfa/enroll\x12\xd3\x01\n" +
+	"\n" +
+	"ConfirmMFA\x12\x15.pb.ConfirmMFARequest\x1a\x16.pb.ConfirmMFAResponse\"\x95\x01\x92Ax\x12\vConfirm MFA\x1aiAPI for enable multi-factor authentication with a code of the enrolled app, it returns the recovery codes\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/mfa/confirm\x12\xbf\x01\n" +
+	"\n" +
+	"DisableMFA\x12\x15.pb.DisableMFARequest\x1a\x16.pb.DisableMFAResponse\"\x81\x01\x92Ad\x12\vDisable MFA\x1aUAPI for disable multi-factor authentication with a code of the app or a recovery code\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/mfa/disable\x12\xc3\x01\n" +
+	"\tVerifyMFA\x12\x14.pb.VerifyMFARequest\x1a\x15.pb.VerifyMFAResponse\"\x88\x01\x92Al\x12\n" +
+	"Verify MFA\x1a^API for exchange the challenge token of the login and a code for the access and refresh tokens\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/verify_mfa\x12\xf5\x01\n" +
+	"\x0fConfirmTransfer\x12\x1a.pb.ConfirmTransferRequest\x1a\x1b.pb.ConfirmTransferResponse\"\xa8\x01\x92Al\x12\x10Confirm Transfer\x1aXAPI for make a transfer above the step-up amount with the password or a code of the user\x82\xd3\xe4\x93\x023:\x01*\"./v1/transfer_challenges/{challenge_id}/confirm\x12\xee\x01\n" +
+	"\x14RequestPasswordReset\x12\x1f.pb.RequestPasswordResetRequest\x1a .pb.RequestPasswordResetResponse\"\x92\x01\x92Aj\x12\x16Request Password Reset\x1aPAPI for email a single-use password reset link to a user who forgot the password\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/request_password_reset\x12\xd5\x01\n" +
+	"\rResetPassword\x12\x18.pb.ResetPasswordRequest\x1a\x19.pb.ResetPasswordResponse\"\x8e\x01\x92An\x12\x0eReset Password\x1a\\API for set a new password with the emailed reset token, it blocks every session of the user\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/reset_passwordB{\x92AX\x12V\n" +
 	"\x0fSimple bank API\">\n" +
 	"\bCell6969\x12\x1bhttps://github.com/Cell6969\x1a\x15bossmarinoo@gmail.com2\x031.2Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
 
 var file_service_simple_bank_proto_goTypes = []any{
-	(*CreateUserRequest)(nil),  // 0: pb.CreateUserRequest
-	(*LoginUserRequest)(nil),   // 1: pb.LoginUserRequest
-	(*UpdateUserRequest)(nil),  // 2: pb.UpdateUserRequest
-	(*CreateUserResponse)(nil), // 3: pb.CreateUserResponse
-	(*LoginUserResponse)(nil),  // 4: pb.LoginUserResponse
-	(*UpdateUserResponse)(nil), // 5: pb.UpdateUserResponse
+	(*CreateUserRequest)(nil),                   // 0: pb.CreateUserRequest
+	(*LoginUserRequest)(nil),                    // 1: pb.LoginUserRequest
+	(*UpdateUserRequest)(nil),                   // 2: pb.UpdateUserRequest
+	(*CreateAccountRequest)(nil),                // 3: pb.CreateAccountRequest
+	(*GetAccountRequest)(nil),                   // 4: pb.GetAccountRequest
+	(*ListAccountsRequest)(nil),                 // 5: pb.ListAccountsRequest
+	(*CreateTransferRequest)(nil),               // 6: pb.CreateTransferRequest
+	(*GetAccountStatementRequest)(nil),          // 7: pb.GetAccountStatementRequest
+	(*ListEntriesRequest)(nil),                  // 8: pb.ListEntriesRequest
+	(*ListTransfersRequest)(nil),                // 9: pb.ListTransfersRequest
+	(*ListSessionsRequest)(nil),                 // 10: pb.ListSessionsRequest
+	(*RevokeSessionRequest)(nil),                // 11: pb.RevokeSessionRequest
+	(*RevokeAllSessionsRequest)(nil),            // 12: pb.RevokeAllSessionsRequest
+	(*RenewAccessTokenRequest)(nil),             // 13: pb.RenewAccessTokenRequest
+	(*VerifyEmailRequest)(nil),                  // 14: pb.VerifyEmailRequest
+	(*CreateWebhookEndpointRequest)(nil),        // 15: pb.CreateWebhookEndpointRequest
+	(*ListWebhookEndpointsRequest)(nil),         // 16: pb.ListWebhookEndpointsRequest
+	(*DeleteWebhookEndpointRequest)(nil),        // 17: pb.DeleteWebhookEndpointRequest
+	(*ListWebhookDeliveriesRequest)(nil),        // 18: pb.ListWebhookDeliveriesRequest
+	(*ReplayWebhookDeliveryRequest)(nil),        // 19: pb.ReplayWebhookDeliveryRequest
+	(*WatchAccountRequest)(nil),                 // 20: pb.WatchAccountRequest
+	(*ListLedgerAccountsRequest)(nil),           // 21: pb.ListLedgerAccountsRequest
+	(*ReconcileBalancesRequest)(nil),            // 22: pb.ReconcileBalancesRequest
+	(*DepositRequest)(nil),                      // 23: pb.DepositRequest
+	(*WithdrawRequest)(nil),                     // 24: pb.WithdrawRequest
+	(*ReverseTransferRequest)(nil),              // 25: pb.ReverseTransferRequest
+	(*CreateScheduledTransferRequest)(nil),      // 26: pb.CreateScheduledTransferRequest
+	(*ListScheduledTransfersRequest)(nil),       // 27: pb.ListScheduledTransfersRequest
+	(*CancelScheduledTransferRequest)(nil),      // 28: pb.CancelScheduledTransferRequest
+	(*ListScheduledTransferRunsRequest)(nil),    // 29: pb.ListScheduledTransferRunsRequest
+	(*UpdateAccountStatusRequest)(nil),          // 30: pb.UpdateAccountStatusRequest
+	(*ListAccountStatusChangesRequest)(nil),     // 31: pb.ListAccountStatusChangesRequest
+	(*GetAccountTransferLimitsRequest)(nil),     // 32: pb.GetAccountTransferLimitsRequest
+	(*UpdateAccountTransferLimitsRequest)(nil),  // 33: pb.UpdateAccountTransferLimitsRequest
+	(*EnrollMFARequest)(nil),                    // 34: pb.EnrollMFARequest
+	(*ConfirmMFARequest)(nil),                   // 35: pb.ConfirmMFARequest
+	(*DisableMFARequest)(nil),                   // 36: pb.DisableMFARequest
+	(*VerifyMFARequest)(nil),                    // 37: pb.VerifyMFARequest
+	(*ConfirmTransferRequest)(nil),              // 38: pb.ConfirmTransferRequest
+	(*RequestPasswordResetRequest)(nil),         // 39: pb.RequestPasswordResetRequest
+	(*ResetPasswordRequest)(nil),                // 40: pb.ResetPasswordRequest
+	(*CreateUserResponse)(nil),                  // 41: pb.CreateUserResponse
+	(*LoginUserResponse)(nil),                   // 42: pb.LoginUserResponse
+	(*UpdateUserResponse)(nil),                  // 43: pb.UpdateUserResponse
+	(*CreateAccountResponse)(nil),               // 44: pb.CreateAccountResponse
+	(*GetAccountResponse)(nil),                  // 45: pb.GetAccountResponse
+	(*ListAccountsResponse)(nil),                // 46: pb.ListAccountsResponse
+	(*CreateTransferResponse)(nil),              // 47: pb.CreateTransferResponse
+	(*GetAccountStatementResponse)(nil),         // 48: pb.GetAccountStatementResponse
+	(*ListEntriesResponse)(nil),                 // 49: pb.ListEntriesResponse
+	(*ListTransfersResponse)(nil),               // 50: pb.ListTransfersResponse
+	(*ListSessionsResponse)(nil),                // 51: pb.ListSessionsResponse
+	(*RevokeSessionResponse)(nil),               // 52: pb.RevokeSessionResponse
+	(*RevokeAllSessionsResponse)(nil),           // 53: pb.RevokeAllSessionsResponse
+	(*RenewAccessTokenResponse)(nil),            // 54: pb.RenewAccessTokenResponse
+	(*VerifyEmailResponse)(nil),                 // 55: pb.VerifyEmailResponse
+	(*CreateWebhookEndpointResponse)(nil),       // 56: pb.CreateWebhookEndpointResponse
+	(*ListWebhookEndpointsResponse)(nil),        // 57: pb.ListWebhookEndpointsResponse
+	(*DeleteWebhookEndpointResponse)(nil),       // 58: pb.DeleteWebhookEndpointResponse
+	(*ListWebhookDeliveriesResponse)(nil),       // 59: pb.ListWebhookDeliveriesResponse
+	(*ReplayWebhookDeliveryResponse)(nil),       // 60: pb.ReplayWebhookDeliveryResponse
+	(*WatchAccountResponse)(nil),                // 61: pb.WatchAccountResponse
+	(*ListLedgerAccountsResponse)(nil),          // 62: pb.ListLedgerAccountsResponse
+	(*ReconcileBalancesResponse)(nil),           // 63: pb.ReconcileBalancesResponse
+	(*DepositResponse)(nil),                     // 64: pb.DepositResponse
+	(*WithdrawResponse)(nil),                    // 65: pb.WithdrawResponse
+	(*ReverseTransferResponse)(nil),             // 66: pb.ReverseTransferResponse
+	(*CreateScheduledTransferResponse)(nil),     // 67: pb.CreateScheduledTransferResponse
+	(*ListScheduledTransfersResponse)(nil),      // 68: pb.ListScheduledTransfersResponse
+	(*CancelScheduledTransferResponse)(nil),     // 69: pb.CancelScheduledTransferResponse
+	(*ListScheduledTransferRunsResponse)(nil),   // 70: pb.ListScheduledTransferRunsResponse
+	(*UpdateAccountStatusResponse)(nil),         // 71: pb.UpdateAccountStatusResponse
+	(*ListAccountStatusChangesResponse)(nil),    // 72: pb.ListAccountStatusChangesResponse
+	(*GetAccountTransferLimitsResponse)(nil),    // 73: pb.GetAccountTransferLimitsResponse
+	(*UpdateAccountTransferLimitsResponse)(nil), // 74: pb.UpdateAccountTransferLimitsResponse
+	(*EnrollMFAResponse)(nil),                   // 75: pb.EnrollMFAResponse
+	(*ConfirmMFAResponse)(nil),                  // 76: pb.ConfirmMFAResponse
+	(*DisableMFAResponse)(nil),                  // 77: pb.DisableMFAResponse
+	(*VerifyMFAResponse)(nil),                   // 78: pb.VerifyMFAResponse
+	(*ConfirmTransferResponse)(nil),             // 79: pb.ConfirmTransferResponse
+	(*RequestPasswordResetResponse)(nil),        // 80: pb.RequestPasswordResetResponse
+	(*ResetPasswordResponse)(nil),               // 81: pb.ResetPasswordResponse
 }
 var file_service_simple_bank_proto_depIdxs = []int32{
-	0, // 0: pb.SimpleBank.CreateUser:input_type -> pb.CreateUserRequest
-	1, // 1: pb.SimpleBank.LoginUser:input_type -> pb.LoginUserRequest
-	2, // 2: pb.SimpleBank.UpdateUser:input_type -> pb.UpdateUserRequest
-	3, // 3: pb.SimpleBank.CreateUser:output_type -> pb.CreateUserResponse
-	4, // 4: pb.SimpleBank.LoginUser:output_type -> pb.LoginUserResponse
-	5, // 5: pb.SimpleBank.UpdateUser:output_type -> pb.UpdateUserResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: pb.SimpleBank.CreateUser:input_type -> pb.CreateUserRequest
+	1,  // 1: pb.SimpleBank.LoginUser:input_type -> pb.LoginUserRequest
+	2,  // 2: pb.SimpleBank.UpdateUser:input_type -> pb.UpdateUserRequest
+	3,  // 3: pb.SimpleBank.CreateAccount:input_type -> pb.CreateAccountRequest
+	4,  // 4: pb.SimpleBank.GetAccount:input_type -> pb.GetAccountRequest
+	5,  // 5: pb.SimpleBank.ListAccounts:input_type -> pb.ListAccountsRequest
+	6,  // 6: pb.SimpleBank.CreateTransfer:input_type -> pb.CreateTransferRequest
+	7,  // 7: pb.SimpleBank.GetAccountStatement:input_type -> pb.GetAccountStatementRequest
+	8,  // 8: pb.SimpleBank.ListEntries:input_type -> pb.ListEntriesRequest
+	9,  // 9: pb.SimpleBank.ListTransfers:input_type -> pb.ListTransfersRequest
+	10, // 10: pb.SimpleBank.ListSessions:input_type -> pb.ListSessionsRequest
+	11, // 11: pb.SimpleBank.RevokeSession:input_type -> pb.RevokeSessionRequest
+	12, // 12: pb.SimpleBank.RevokeAllSessions:input_type -> pb.RevokeAllSessionsRequest
+	13, // 13: pb.SimpleBank.RenewAccessToken:input_type -> pb.RenewAccessTokenRequest
+	14, // 14: pb.SimpleBank.VerifyEmail:input_type -> pb.VerifyEmailRequest
+	15, // 15: pb.SimpleBank.CreateWebhookEndpoint:input_type -> pb.CreateWebhookEndpointRequest
+	16, // 16: pb.SimpleBank.ListWebhookEndpoints:input_type -> pb.ListWebhookEndpointsRequest
+	17, // 17: pb.SimpleBank.DeleteWebhookEndpoint:input_type -> pb.DeleteWebhookEndpointRequest
+	18, // 18: pb.SimpleBank.ListWebhookDeliveries:input_type -> pb.ListWebhookDeliveriesRequest
+	19, // 19: pb.SimpleBank.ReplayWebhookDelivery:input_type -> pb.ReplayWebhookDeliveryRequest
+	20, // 20: pb.SimpleBank.WatchAccount:input_type -> pb.WatchAccountRequest
+	21, // 21: pb.SimpleBank.ListLedgerAccounts:input_type -> pb.ListLedgerAccountsRequest
+	22, // 22: pb.SimpleBank.ReconcileBalances:input_type -> pb.ReconcileBalancesRequest
+	23, // 23: pb.SimpleBank.Deposit:input_type -> pb.DepositRequest
+	24, // 24: pb.SimpleBank.Withdraw:input_type -> pb.WithdrawRequest
+	25, // 25: pb.SimpleBank.ReverseTransfer:input_type -> pb.ReverseTransferRequest
+	26, // 26: pb.SimpleBank.CreateScheduledTransfer:input_type -> pb.CreateScheduledTransferRequest
+	27, // 27: pb.SimpleBank.ListScheduledTransfers:input_type -> pb.ListScheduledTransfersRequest
+	28, // 28: pb.SimpleBank.CancelScheduledTransfer:input_type -> pb.CancelScheduledTransferRequest
+	29, // 29: pb.SimpleBank.ListScheduledTransferRuns:input_type -> pb.ListScheduledTransferRunsRequest
+	30, // 30: pb.SimpleBank.UpdateAccountStatus:input_type -> pb.UpdateAccountStatusRequest
+	31, // 31: pb.SimpleBank.ListAccountStatusChanges:input_type -> pb.ListAccountStatusChangesRequest
+	32, // 32: pb.SimpleBank.GetAccountTransferLimits:input_type -> pb.GetAccountTransferLimitsRequest
+	33, // 33: pb.SimpleBank.UpdateAccountTransferLimits:input_type -> pb.UpdateAccountTransferLimitsRequest
+	34, // 34: pb.SimpleBank.EnrollMFA:input_type -> pb.EnrollMFARequest
+	35, // 35: pb.SimpleBank.ConfirmMFA:input_type -> pb.ConfirmMFARequest
+	36, // 36: pb.SimpleBank.DisableMFA:input_type -> pb.DisableMFARequest
+	37, // 37: pb.SimpleBank.VerifyMFA:input_type -> pb.VerifyMFARequest
+	38, // 38: pb.SimpleBank.ConfirmTransfer:input_type -> pb.ConfirmTransferRequest
+	39, // 39: pb.SimpleBank.RequestPasswordReset:input_type -> pb.RequestPasswordResetRequest
+	40, // 40: pb.SimpleBank.ResetPassword:input_type -> pb.ResetPasswordRequest
+	41, // 41: pb.SimpleBank.CreateUser:output_type -> pb.CreateUserResponse
+	42, // 42: pb.SimpleBank.LoginUser:output_type -> pb.LoginUserResponse
+	43, // 43: pb.SimpleBank.UpdateUser:output_type -> pb.UpdateUserResponse
+	44, // 44: pb.SimpleBank.CreateAccount:output_type -> pb.CreateAccountResponse
+	45, // 45: pb.SimpleBank.GetAccount:output_type -> pb.GetAccountResponse
+	46, // 46: pb.SimpleBank.ListAccounts:output_type -> pb.ListAccountsResponse
+	47, // 47: pb.SimpleBank.CreateTransfer:output_type -> pb.CreateTransferResponse
+	48, // 48: pb.SimpleBank.GetAccountStatement:output_type -> pb.GetAccountStatementResponse
+	49, // 49: pb.SimpleBank.ListEntries:output_type -> pb.ListEntriesResponse
+	50, // 50: pb.SimpleBank.ListTransfers:output_type -> pb.ListTransfersResponse
+	51, // 51: pb.SimpleBank.ListSessions:output_type -> pb.ListSessionsResponse
+	52, // 52: pb.SimpleBank.RevokeSession:output_type -> pb.RevokeSessionResponse
+	53, // 53: pb.SimpleBank.RevokeAllSessions:output_type -> pb.RevokeAllSessionsResponse
+	54, // 54: pb.SimpleBank.RenewAccessToken:output_type -> pb.RenewAccessTokenResponse
+	55, // 55: pb.SimpleBank.VerifyEmail:output_type -> pb.VerifyEmailResponse
+	56, // 56: pb.SimpleBank.CreateWebhookEndpoint:output_type -> pb.CreateWebhookEndpointResponse
+	57, // 57: pb.SimpleBank.ListWebhookEndpoints:output_type -> pb.ListWebhookEndpointsResponse
+	58, // 58: pb.SimpleBank.DeleteWebhookEndpoint:output_type -> pb.DeleteWebhookEndpointResponse
+	59, // 59: pb.SimpleBank.ListWebhookDeliveries:output_type -> pb.ListWebhookDeliveriesResponse
+	60, // 60: pb.SimpleBank.ReplayWebhookDelivery:output_type -> pb.ReplayWebhookDeliveryResponse
+	61, // 61: pb.SimpleBank.WatchAccount:output_type -> pb.WatchAccountResponse
+	62, // 62: pb.SimpleBank.ListLedgerAccounts:output_type -> pb.ListLedgerAccountsResponse
+	63, // 63: pb.SimpleBank.ReconcileBalances:output_type -> pb.ReconcileBalancesResponse
+	64, // 64: pb.SimpleBank.Deposit:output_type -> pb.DepositResponse
+	65, // 65: pb.SimpleBank.Withdraw:output_type -> pb.WithdrawResponse
+	66, // 66: pb.SimpleBank.ReverseTransfer:output_type -> pb.ReverseTransferResponse
+	67, // 67: pb.SimpleBank.CreateScheduledTransfer:output_type -> pb.CreateScheduledTransferResponse
+	68, // 68: pb.SimpleBank.ListScheduledTransfers:output_type -> pb.ListScheduledTransfersResponse
+	69, // 69: pb.SimpleBank.CancelScheduledTransfer:output_type -> pb.CancelScheduledTransferResponse
+	70, // 70: pb.SimpleBank.ListScheduledTransferRuns:output_type -> pb.ListScheduledTransferRunsResponse
+	71, // 71: pb.SimpleBank.UpdateAccountStatus:output_type -> pb.UpdateAccountStatusResponse
+	72, // 72: pb.SimpleBank.ListAccountStatusChanges:output_type -> pb.ListAccountStatusChangesResponse
+	73, // 73: pb.SimpleBank.GetAccountTransferLimits:output_type -> pb.GetAccountTransferLimitsResponse
+	74, // 74: pb.SimpleBank.UpdateAccountTransferLimits:output_type -> pb.UpdateAccountTransferLimitsResponse
+	75, // 75: pb.SimpleBank.EnrollMFA:output_type -> pb.EnrollMFAResponse
+	76, // 76: pb.SimpleBank.ConfirmMFA:output_type -> pb.ConfirmMFAResponse
+	77, // 77: pb.SimpleBank.DisableMFA:output_type -> pb.DisableMFAResponse
+	78, // 78: pb.SimpleBank.VerifyMFA:output_type -> pb.VerifyMFAResponse
+	79, // 79: pb.SimpleBank.ConfirmTransfer:output_type -> pb.ConfirmTransferResponse
+	80, // 80: pb.SimpleBank.RequestPasswordReset:output_type -> pb.RequestPasswordResetResponse
+	81, // 81: pb.SimpleBank.ResetPassword:output_type -> pb.ResetPasswordResponse
+	41, // [41:82] is the sub-list for method output_type
+	0,  // [0:41] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_service_simple_bank_proto_init() }
@@ -68,6 +263,44 @@ func file_service_simple_bank_proto_init() {
 	file_rpc_create_user_proto_init()
 	file_rpc_login_user_proto_init()
 	file_rpc_update_user_proto_init()
+	file_rpc_create_account_proto_init()
+	file_rpc_get_account_proto_init()
+	file_rpc_list_accounts_proto_init()
+	file_rpc_create_transfer_proto_init()
+	file_rpc_get_account_statement_proto_init()
+	file_rpc_list_entries_proto_init()
+	file_rpc_list_transfers_proto_init()
+	file_rpc_list_sessions_proto_init()
+	file_rpc_revoke_session_proto_init()
+	file_rpc_revoke_all_sessions_proto_init()
+	file_rpc_renew_access_token_proto_init()
+	file_rpc_verify_email_proto_init()
+	file_rpc_create_webhook_endpoint_proto_init()
+	file_rpc_list_webhook_endpoints_proto_init()
+	file_rpc_delete_webhook_endpoint_proto_init()
+	file_rpc_list_webhook_deliveries_proto_init()
+	file_rpc_replay_webhook_delivery_proto_init()
+	file_rpc_watch_account_proto_init()
+	file_rpc_list_ledger_accounts_proto_init()
+	file_rpc_reconcile_balances_proto_init()
+	file_rpc_deposit_proto_init()
+	file_rpc_withdraw_proto_init()
+	file_rpc_reverse_transfer_proto_init()
+	file_rpc_create_scheduled_transfer_proto_init()
+	file_rpc_list_scheduled_transfers_proto_init()
+	file_rpc_cancel_scheduled_transfer_proto_init()
+	file_rpc_list_scheduled_transfer_runs_proto_init()
+	file_rpc_update_account_status_proto_init()
+	file_rpc_list_account_status_changes_proto_init()
+	file_rpc_get_account_transfer_limits_proto_init()
+	file_rpc_update_account_transfer_limits_proto_init()
+	file_rpc_enroll_mfa_proto_init()
+	file_rpc_confirm_mfa_proto_init()
+	file_rpc_disable_mfa_proto_init()
+	file_rpc_verify_mfa_proto_init()
+	file_rpc_confirm_transfer_proto_init()
+	file_rpc_request_password_reset_proto_init()
+	file_rpc_reset_password_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -0,0 +1,182 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: transfer_challenge.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// a transfer waiting for the user to prove their identity again,
+// method is password or mfa and tells what ConfirmTransfer expects
+type TransferChallenge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromAccountId int64                  `protobuf:"varint,2,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId   int64                  `protobuf:"varint,3,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	ExchangeRate  string                 `protobuf:"bytes,5,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"`
+	Method        string                 `protobuf:"bytes,6,opt,name=method,proto3" json:"method,omitempty"`
+	ExpiredAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferChallenge) Reset() {
+	*x = TransferChallenge{}
+	mi := &file_transfer_challenge_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferChallenge) ProtoMessage() {}
+
+func (x *TransferChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_transfer_challenge_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferChallenge.ProtoReflect.Descriptor instead.
+func (*TransferChallenge) Descriptor() ([]byte, []int) {
+	return file_transfer_challenge_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransferChallenge) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TransferChallenge) GetFromAccountId() int64 {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return 0
+}
+
+func (x *TransferChallenge) GetToAccountId() int64 {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return 0
+}
+
+func (x *TransferChallenge) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferChallenge) GetExchangeRate() string {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return ""
+}
+
+func (x *TransferChallenge) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *TransferChallenge) GetExpiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return nil
+}
+
+var File_transfer_challenge_proto protoreflect.FileDescriptor
+
+const file_transfer_challenge_proto_rawDesc = "" +
+	"\n" +
+	"\x18transfer_challenge.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xff\x01\n" +
+	"\x11TransferChallenge\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12&\n" +
+	"\x0ffrom_account_id\x18\x02 \x01(\x03R\rfromAccountId\x12\"\n" +
+	"\rto_account_id\x18\x03 \x01(\x03R\vtoAccountId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12#\n" +
+	"\rexchange_rate\x18\x05 \x01(\tR\fexchangeRate\x12\x16\n" +
+	"\x06method\x18\x06 \x01(\tR\x06method\x129\n" +
+	"\n" +
+	"expired_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiredAtB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_transfer_challenge_proto_rawDescOnce sync.Once
+	file_transfer_challenge_proto_rawDescData []byte
+)
+
+func file_transfer_challenge_proto_rawDescGZIP() []byte {
+	file_transfer_challenge_proto_rawDescOnce.Do(func() {
+		file_transfer_challenge_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_transfer_challenge_proto_rawDesc), len(file_transfer_challenge_proto_rawDesc)))
+	})
+	return file_transfer_challenge_proto_rawDescData
+}
+
+var file_transfer_challenge_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_transfer_challenge_proto_goTypes = []any{
+	(*TransferChallenge)(nil),     // 0: pb.TransferChallenge
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_transfer_challenge_proto_depIdxs = []int32{
+	1, // 0: pb.TransferChallenge.expired_at:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_transfer_challenge_proto_init() }
+func file_transfer_challenge_proto_init() {
+	if File_transfer_challenge_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transfer_challenge_proto_rawDesc), len(file_transfer_challenge_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_transfer_challenge_proto_goTypes,
+		DependencyIndexes: file_transfer_challenge_proto_depIdxs,
+		MessageInfos:      file_transfer_challenge_proto_msgTypes,
+	}.Build()
+	File_transfer_challenge_proto = out.File
+	file_transfer_challenge_proto_goTypes = nil
+	file_transfer_challenge_proto_depIdxs = nil
+}
@@ -0,0 +1,227 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: transfer_limit.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// the limits in force for the account, an account without its own limits uses the defaults of its currency
+type TransferLimits struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Currency       string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	MaxPerTransfer int64                  `protobuf:"varint,3,opt,name=max_per_transfer,json=maxPerTransfer,proto3" json:"max_per_transfer,omitempty"`
+	DailyAmount    int64                  `protobuf:"varint,4,opt,name=daily_amount,json=dailyAmount,proto3" json:"daily_amount,omitempty"`
+	DailyCount     int32                  `protobuf:"varint,5,opt,name=daily_count,json=dailyCount,proto3" json:"daily_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransferLimits) Reset() {
+	*x = TransferLimits{}
+	mi := &file_transfer_limit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLimits) ProtoMessage() {}
+
+func (x *TransferLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_transfer_limit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLimits.ProtoReflect.Descriptor instead.
+func (*TransferLimits) Descriptor() ([]byte, []int) {
+	return file_transfer_limit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransferLimits) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TransferLimits) GetMaxPerTransfer() int64 {
+	if x != nil {
+		return x.MaxPerTransfer
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetDailyAmount() int64 {
+	if x != nil {
+		return x.DailyAmount
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetDailyCount() int32 {
+	if x != nil {
+		return x.DailyCount
+	}
+	return 0
+}
+
+// what the account may still send today, the day starts at midnight of the database clock
+type TransferAllowance struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	MaxPerTransfer       int64                  `protobuf:"varint,1,opt,name=max_per_transfer,json=maxPerTransfer,proto3" json:"max_per_transfer,omitempty"`
+	DailyAmountRemaining int64                  `protobuf:"varint,2,opt,name=daily_amount_remaining,json=dailyAmountRemaining,proto3" json:"daily_amount_remaining,omitempty"`
+	DailyCountRemaining  int32                  `protobuf:"varint,3,opt,name=daily_count_remaining,json=dailyCountRemaining,proto3" json:"daily_count_remaining,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TransferAllowance) Reset() {
+	*x = TransferAllowance{}
+	mi := &file_transfer_limit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferAllowance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferAllowance) ProtoMessage() {}
+
+func (x *TransferAllowance) ProtoReflect() protoreflect.Message {
+	mi := &file_transfer_limit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferAllowance.ProtoReflect.Descriptor instead.
+func (*TransferAllowance) Descriptor() ([]byte, []int) {
+	return file_transfer_limit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TransferAllowance) GetMaxPerTransfer() int64 {
+	if x != nil {
+		return x.MaxPerTransfer
+	}
+	return 0
+}
+
+func (x *TransferAllowance) GetDailyAmountRemaining() int64 {
+	if x != nil {
+		return x.DailyAmountRemaining
+	}
+	return 0
+}
+
+func (x *TransferAllowance) GetDailyCountRemaining() int32 {
+	if x != nil {
+		return x.DailyCountRemaining
+	}
+	return 0
+}
+
+var File_transfer_limit_proto protoreflect.FileDescriptor
+
+const file_transfer_limit_proto_rawDesc = "" +
+	"\n" +
+	"\x14transfer_limit.proto\x12\x02pb\"\xb9\x01\n" +
+	"\x0eTransferLimits\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12(\n" +
+	"\x10max_per_transfer\x18\x03 \x01(\x03R\x0emaxPerTransfer\x12!\n" +
+	"\fdaily_amount\x18\x04 \x01(\x03R\vdailyAmount\x12\x1f\n" +
+	"\vdaily_count\x18\x05 \x01(\x05R\n" +
+	"dailyCount\"\xa7\x01\n" +
+	"\x11TransferAllowance\x12(\n" +
+	"\x10max_per_transfer\x18\x01 \x01(\x03R\x0emaxPerTransfer\x124\n" +
+	"\x16daily_amount_remaining\x18\x02 \x01(\x03R\x14dailyAmountRemaining\x122\n" +
+	"\x15daily_count_remaining\x18\x03 \x01(\x05R\x13dailyCountRemainingB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_transfer_limit_proto_rawDescOnce sync.Once
+	file_transfer_limit_proto_rawDescData []byte
+)
+
+func file_transfer_limit_proto_rawDescGZIP() []byte {
+	file_transfer_limit_proto_rawDescOnce.Do(func() {
+		file_transfer_limit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_transfer_limit_proto_rawDesc), len(file_transfer_limit_proto_rawDesc)))
+	})
+	return file_transfer_limit_proto_rawDescData
+}
+
+var file_transfer_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_transfer_limit_proto_goTypes = []any{
+	(*TransferLimits)(nil),    // 0: pb.TransferLimits
+	(*TransferAllowance)(nil), // 1: pb.TransferAllowance
+}
+var file_transfer_limit_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_transfer_limit_proto_init() }
+func file_transfer_limit_proto_init() {
+	if File_transfer_limit_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transfer_limit_proto_rawDesc), len(file_transfer_limit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_transfer_limit_proto_goTypes,
+		DependencyIndexes: file_transfer_limit_proto_depIdxs,
+		MessageInfos:      file_transfer_limit_proto_msgTypes,
+	}.Build()
+	File_transfer_limit_proto = out.File
+	file_transfer_limit_proto_goTypes = nil
+	file_transfer_limit_proto_depIdxs = nil
+}
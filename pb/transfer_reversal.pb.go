@@ -0,0 +1,193 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.26.1
+// source: transfer_reversal.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TransferReversal struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TransferId int64                  `protobuf:"varint,2,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	// given back to the sender, in the source account currency
+	Amount int64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// taken back from the recipient, in the destination account currency
+	ToAmount       int64                  `protobuf:"varint,4,opt,name=to_amount,json=toAmount,proto3" json:"to_amount,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	JournalEntryId int64                  `protobuf:"varint,6,opt,name=journal_entry_id,json=journalEntryId,proto3" json:"journal_entry_id,omitempty"`
+	CreatedBy      string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransferReversal) Reset() {
+	*x = TransferReversal{}
+	mi := &file_transfer_reversal_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferReversal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferReversal) ProtoMessage() {}
+
+func (x *TransferReversal) ProtoReflect() protoreflect.Message {
+	mi := &file_transfer_reversal_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferReversal.ProtoReflect.Descriptor instead.
+func (*TransferReversal) Descriptor() ([]byte, []int) {
+	return file_transfer_reversal_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransferReversal) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TransferReversal) GetTransferId() int64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *TransferReversal) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferReversal) GetToAmount() int64 {
+	if x != nil {
+		return x.ToAmount
+	}
+	return 0
+}
+
+func (x *TransferReversal) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TransferReversal) GetJournalEntryId() int64 {
+	if x != nil {
+		return x.JournalEntryId
+	}
+	return 0
+}
+
+func (x *TransferReversal) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *TransferReversal) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_transfer_reversal_proto protoreflect.FileDescriptor
+
+const file_transfer_reversal_proto_rawDesc = "" +
+	"\n" +
+	"\x17transfer_reversal.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\x94\x02\n" +
+	"\x10TransferReversal\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vtransfer_id\x18\x02 \x01(\x03R\n" +
+	"transferId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x1b\n" +
+	"\tto_amount\x18\x04 \x01(\x03R\btoAmount\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12(\n" +
+	"\x10journal_entry_id\x18\x06 \x01(\x03R\x0ejournalEntryId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB Z\x1egithub.com/Cell6969/go_bank/pbb\x06proto3"
+
+var (
+	file_transfer_reversal_proto_rawDescOnce sync.Once
+	file_transfer_reversal_proto_rawDescData []byte
+)
+
+func file_transfer_reversal_proto_rawDescGZIP() []byte {
+	file_transfer_reversal_proto_rawDescOnce.Do(func() {
+		file_transfer_reversal_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_transfer_reversal_proto_rawDesc), len(file_transfer_reversal_proto_rawDesc)))
+	})
+	return file_transfer_reversal_proto_rawDescData
+}
+
+var file_transfer_reversal_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_transfer_reversal_proto_goTypes = []any{
+	(*TransferReversal)(nil),      // 0: pb.TransferReversal
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_transfer_reversal_proto_depIdxs = []int32{
+	1, // 0: pb.TransferReversal.created_at:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_transfer_reversal_proto_init() }
+func file_transfer_reversal_proto_init() {
+	if File_transfer_reversal_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transfer_reversal_proto_rawDesc), len(file_transfer_reversal_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_transfer_reversal_proto_goTypes,
+		DependencyIndexes: file_transfer_reversal_proto_depIdxs,
+		MessageInfos:      file_transfer_reversal_proto_msgTypes,
+	}.Build()
+	File_transfer_reversal_proto = out.File
+	file_transfer_reversal_proto_goTypes = nil
+	file_transfer_reversal_proto_depIdxs = nil
+}
@@ -0,0 +1,98 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+// Drift is an account whose balance is not the sum of its entries
+type Drift struct {
+	AccountID    int64  `json:"account_id"`
+	Owner        string `json:"owner"`
+	Currency     string `json:"currency"`
+	Balance      int64  `json:"balance"`
+	EntriesTotal int64  `json:"entries_total"`
+	// Drift is the balance minus the sum of the entries
+	Drift int64 `json:"drift"`
+	// JournalEntryID is the adjustment written by a fixing run, 0 when the drift was only reported
+	JournalEntryID int64 `json:"journal_entry_id,omitempty"`
+}
+
+// Report is the result of a reconciliation run
+type Report struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Drifts    []Drift   `json:"drifts"`
+	// UnbalancedJournals lists the journal entries whose postings don't sum to zero
+	UnbalancedJournals []db.ListUnbalancedJournalEntriesRow `json:"unbalanced_journals"`
+}
+
+// Reconciler checks the account balances against their entries and the ledger against itself
+type Reconciler struct {
+	store db.Store
+}
+
+// NewReconciler creates a new reconciler for the store
+func NewReconciler(store db.Store) *Reconciler {
+	return &Reconciler{
+		store: store,
+	}
+}
+
+// Run finds the drifting accounts and the unbalanced journal entries.
+// With fix set an adjustment journal entry is written for every drifting account,
+// the drift reported is the one measured while the account was locked
+func (reconciler *Reconciler) Run(ctx context.Context, fix bool) (Report, error) {
+	report := Report{
+		CheckedAt:          time.Now().UTC(),
+		Drifts:             []Drift{},
+		UnbalancedJournals: []db.ListUnbalancedJournalEntriesRow{},
+	}
+
+	rows, err := reconciler.store.ListBalanceDrifts(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to list balance drifts: %w", err)
+	}
+
+	for _, row := range rows {
+		drift := Drift{
+			AccountID:    row.ID,
+			Owner:        row.Owner,
+			Currency:     row.Currency,
+			Balance:      row.Balance,
+			EntriesTotal: row.EntriesTotal,
+			Drift:        row.Balance - row.EntriesTotal,
+		}
+
+		if fix {
+			result, err := reconciler.store.ReconcileAccountTx(ctx, db.ReconcileAccountTxParams{AccountID: row.ID})
+			if err != nil {
+				return report, fmt.Errorf("failed to reconcile account %d: %w", row.ID, err)
+			}
+
+			drift.Balance = result.Account.Balance
+			drift.EntriesTotal = result.Account.Balance - result.Drift
+			drift.Drift = result.Drift
+			drift.JournalEntryID = result.JournalEntry.ID
+		}
+
+		log.Warn().
+			Int64("account_id", drift.AccountID).
+			Int64("balance", drift.Balance).
+			Int64("entries_total", drift.EntriesTotal).
+			Int64("journal_entry_id", drift.JournalEntryID).
+			Msg("account balance drift")
+
+		report.Drifts = append(report.Drifts, drift)
+	}
+
+	report.UnbalancedJournals, err = reconciler.store.ListUnbalancedJournalEntries(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to list unbalanced journal entries: %w", err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,92 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	mockdb "github.com/Cell6969/go_bank/db/mock"
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	drifting := db.ListBalanceDriftsRow{ID: 1, Owner: "alice", Currency: "USD", Balance: 150, EntriesTotal: 100}
+
+	testCases := []struct {
+		name        string
+		fix         bool
+		buildStubs  func(store *mockdb.MockStore)
+		checkReport func(t *testing.T, report Report)
+	}{
+		{
+			name: "Report Only",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBalanceDrifts(gomock.Any()).Times(1).Return([]db.ListBalanceDriftsRow{drifting}, nil)
+				store.EXPECT().ReconcileAccountTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListUnbalancedJournalEntries(gomock.Any()).Times(1).Return([]db.ListUnbalancedJournalEntriesRow{}, nil)
+			},
+			checkReport: func(t *testing.T, report Report) {
+				require.Len(t, report.Drifts, 1)
+				require.Equal(t, int64(50), report.Drifts[0].Drift)
+				require.Zero(t, report.Drifts[0].JournalEntryID)
+				require.Empty(t, report.UnbalancedJournals)
+			},
+		},
+		{
+			name: "Fix",
+			fix:  true,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBalanceDrifts(gomock.Any()).Times(1).Return([]db.ListBalanceDriftsRow{drifting}, nil)
+
+				// the drift measured under the lock wins over the one listed before
+				store.EXPECT().
+					ReconcileAccountTx(gomock.Any(), gomock.Eq(db.ReconcileAccountTxParams{AccountID: drifting.ID})).
+					Times(1).
+					Return(db.ReconcileAccountTxResult{
+						Account:      db.Account{ID: drifting.ID, Balance: 160},
+						Drift:        60,
+						JournalEntry: db.JournalEntry{ID: 7},
+					}, nil)
+				store.EXPECT().ListUnbalancedJournalEntries(gomock.Any()).Times(1).Return([]db.ListUnbalancedJournalEntriesRow{}, nil)
+			},
+			checkReport: func(t *testing.T, report Report) {
+				require.Len(t, report.Drifts, 1)
+				require.Equal(t, int64(60), report.Drifts[0].Drift)
+				require.Equal(t, int64(100), report.Drifts[0].EntriesTotal)
+				require.Equal(t, int64(7), report.Drifts[0].JournalEntryID)
+			},
+		},
+		{
+			name: "Unbalanced Journal",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBalanceDrifts(gomock.Any()).Times(1).Return([]db.ListBalanceDriftsRow{}, nil)
+				store.EXPECT().
+					ListUnbalancedJournalEntries(gomock.Any()).
+					Times(1).
+					Return([]db.ListUnbalancedJournalEntriesRow{{JournalEntryID: 3, Currency: "EUR", Total: 1}}, nil)
+			},
+			checkReport: func(t *testing.T, report Report) {
+				require.Empty(t, report.Drifts)
+				require.Len(t, report.UnbalancedJournals, 1)
+				require.Equal(t, int64(3), report.UnbalancedJournals[0].JournalEntryID)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			report, err := NewReconciler(store).Run(context.Background(), tc.fix)
+			require.NoError(t, err)
+			tc.checkReport(t, report)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultPollInterval = 10 * time.Second
+	// defaultLockDuration is how long a claimed scheduled transfer is kept from the other schedulers,
+	// an occurrence that failed for a temporary reason is retried once the lock expires
+	defaultLockDuration = time.Minute
+)
+
+// errRunFailed marks the errors that are recorded as a failed run instead of being retried
+var errRunFailed = errors.New("scheduled transfer run failed")
+
+// Scheduler runs the due scheduled transfers through TransferTx.
+// Several schedulers can share the database: a scheduled transfer is claimed by one of them at a time,
+// every occurrence is sent with its own idempotency key and recorded once, so it moves money exactly once
+type Scheduler struct {
+	store          db.Store
+	fxRateProvider util.FXRateProvider
+	pollInterval   time.Duration
+	lockDuration   time.Duration
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+// NewScheduler creates a new scheduler for the scheduled transfers of the store
+func NewScheduler(store db.Store, fxRateProvider util.FXRateProvider) *Scheduler {
+	return &Scheduler{
+		store:          store,
+		fxRateProvider: fxRateProvider,
+		pollInterval:   defaultPollInterval,
+		lockDuration:   defaultLockDuration,
+	}
+}
+
+// Start runs the scheduler in a goroutine, it returns immediately
+func (scheduler *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.cancel = cancel
+
+	log.Info().Msg("start transfer scheduler")
+
+	scheduler.wg.Add(1)
+	go func() {
+		defer scheduler.wg.Done()
+		scheduler.run(ctx)
+	}()
+}
+
+// Shutdown stops the scheduler and waits for the current occurrence to finish
+func (scheduler *Scheduler) Shutdown() {
+	if scheduler.cancel != nil {
+		scheduler.cancel()
+	}
+	scheduler.wg.Wait()
+}
+
+func (scheduler *Scheduler) run(ctx context.Context) {
+	for {
+		claimed, err := scheduler.runNext(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to run scheduled transfer")
+		}
+
+		// keep going while there are due occurrences
+		if claimed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(scheduler.pollInterval):
+		}
+	}
+}
+
+// runNext claims the next due scheduled transfer and runs its occurrence, it returns false when nothing is due.
+// On error the scheduled transfer stays locked, so it is retried by any scheduler once the lock expires
+func (scheduler *Scheduler) runNext(ctx context.Context) (bool, error) {
+	scheduledTransfer, err := scheduler.store.ClaimDueScheduledTransfer(ctx, time.Now().Add(scheduler.lockDuration))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim scheduled transfer: %w", err)
+	}
+
+	arg := db.RecordScheduledTransferRunTxParams{
+		ScheduledTransfer: scheduledTransfer,
+	}
+
+	transfer, err := scheduler.transfer(ctx, scheduledTransfer)
+	if err != nil {
+		if !errors.Is(err, errRunFailed) {
+			return true, fmt.Errorf("scheduled transfer %d: %w", scheduledTransfer.ID, err)
+		}
+		arg.Error = err.Error()
+	} else {
+		arg.TransferID = sql.NullInt64{Int64: transfer.ID, Valid: true}
+	}
+
+	arg.NextRunAt, err = nextRunAt(scheduledTransfer)
+	if err != nil {
+		return true, fmt.Errorf("scheduled transfer %d: %w", scheduledTransfer.ID, err)
+	}
+
+	result, err := scheduler.store.RecordScheduledTransferRunTx(ctx, arg)
+	if err != nil {
+		return true, fmt.Errorf("failed to record run of scheduled transfer %d: %w", scheduledTransfer.ID, err)
+	}
+
+	log.Info().
+		Int64("scheduled_transfer_id", scheduledTransfer.ID).
+		Time("occurrence_at", scheduledTransfer.NextRunAt).
+		Str("status", result.Run.Status).
+		Str("error", arg.Error).
+		Bool("recorded", result.Recorded).
+		Msg("run scheduled transfer")
+
+	return true, nil
+}
+
+// transfer moves the money of the current occurrence.
+// Problems the owner has to fix, like insufficient funds, are wrapped in errRunFailed
+func (scheduler *Scheduler) transfer(ctx context.Context, scheduledTransfer db.ScheduledTransfer) (db.Transfer, error) {
+	fromAccount, err := scheduler.getAccount(ctx, scheduledTransfer.FromAccountID)
+	if err != nil {
+		return db.Transfer{}, err
+	}
+
+	if fromAccount.Owner != scheduledTransfer.Owner {
+		return db.Transfer{}, fmt.Errorf("%w: account [%d] doesn't belong to %s", errRunFailed, fromAccount.ID, scheduledTransfer.Owner)
+	}
+
+	if fromAccount.Currency != scheduledTransfer.Currency {
+		return db.Transfer{}, fmt.Errorf("%w: account [%d] currency missmatch: %s vs %s", errRunFailed, fromAccount.ID, fromAccount.Currency, scheduledTransfer.Currency)
+	}
+
+	toAccount, err := scheduler.getAccount(ctx, scheduledTransfer.ToAccountID)
+	if err != nil {
+		return db.Transfer{}, err
+	}
+
+	exchangeRate, err := scheduler.fxRateProvider.GetRate(ctx, fromAccount.Currency, toAccount.Currency)
+	if err != nil {
+		if errors.Is(err, util.ErrUnsupportedFXPair) {
+			return db.Transfer{}, fmt.Errorf("%w: %s", errRunFailed, err)
+		}
+		return db.Transfer{}, err
+	}
+
+	result, err := scheduler.store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountId:  fromAccount.ID,
+		ToAccountId:    toAccount.ID,
+		Amount:         scheduledTransfer.Amount,
+		ExchangeRate:   exchangeRate,
+		Owner:          scheduledTransfer.Owner,
+		IdempotencyKey: occurrenceKey(scheduledTransfer),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			return db.Transfer{}, fmt.Errorf("%w: account [%d] has insufficient funds", errRunFailed, fromAccount.ID)
+		}
+		if errors.Is(err, db.ErrAccountFrozen) || errors.Is(err, db.ErrAccountClosed) || errors.Is(err, db.ErrTransferLimitExceeded) ||
+			errors.Is(err, db.ErrConvertedAmountTooSmall) {
+			return db.Transfer{}, fmt.Errorf("%w: %s", errRunFailed, err)
+		}
+		return db.Transfer{}, err
+	}
+
+	return result.Transfer, nil
+}
+
+func (scheduler *Scheduler) getAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := scheduler.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account, fmt.Errorf("%w: account [%d] not found", errRunFailed, accountID)
+		}
+		return account, err
+	}
+	return account, nil
+}
+
+// occurrenceKey is the idempotency key of the transfer of the current occurrence,
+// a retried occurrence gets back the transfer made the first time instead of moving money again
+func occurrenceKey(scheduledTransfer db.ScheduledTransfer) string {
+	return fmt.Sprintf("scheduled_transfer:%d:%d", scheduledTransfer.ID, scheduledTransfer.NextRunAt.Unix())
+}
+
+// nextRunAt returns the occurrence after the current one, zero when the scheduled transfer is done
+func nextRunAt(scheduledTransfer db.ScheduledTransfer) (time.Time, error) {
+	if scheduledTransfer.Recurrence == "" {
+		return time.Time{}, nil
+	}
+
+	recurrence, err := util.ParseRecurrence(scheduledTransfer.Recurrence)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next := recurrence.Next(scheduledTransfer.StartAt, scheduledTransfer.NextRunAt)
+	if scheduledTransfer.EndAt.Valid && next.After(scheduledTransfer.EndAt.Time) {
+		return time.Time{}, nil
+	}
+
+	return next, nil
+}
@@ -0,0 +1,160 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	mockdb "github.com/Cell6969/go_bank/db/mock"
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunNext(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	monthly := db.ScheduledTransfer{
+		ID:            1,
+		Owner:         "alice",
+		FromAccountID: 10,
+		ToAccountID:   20,
+		Amount:        500,
+		Currency:      util.USD,
+		Recurrence:    "FREQ=MONTHLY;BYMONTHDAY=1",
+		StartAt:       start,
+		NextRunAt:     start,
+		Status:        db.ScheduledTransferActive,
+	}
+	fromAccount := db.Account{ID: 10, Owner: "alice", Currency: util.USD}
+	toAccount := db.Account{ID: 20, Owner: "bob", Currency: util.USD}
+
+	oneOff := monthly
+	oneOff.Recurrence = ""
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, claimed bool, err error)
+	}{
+		{
+			name: "Nothing Due",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ClaimDueScheduledTransfer(gomock.Any(), gomock.Any()).Times(1).Return(db.ScheduledTransfer{}, sql.ErrNoRows)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, claimed bool, err error) {
+				require.NoError(t, err)
+				require.False(t, claimed)
+			},
+		},
+		{
+			name: "Succeeded",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ClaimDueScheduledTransfer(gomock.Any(), gomock.Any()).Times(1).Return(monthly, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountId:  fromAccount.ID,
+						ToAccountId:    toAccount.ID,
+						Amount:         monthly.Amount,
+						ExchangeRate:   "1",
+						Owner:          monthly.Owner,
+						IdempotencyKey: occurrenceKey(monthly),
+					})).
+					Times(1).
+					Return(db.TransferTxResult{Transfer: db.Transfer{ID: 99}}, nil)
+				store.EXPECT().
+					RecordScheduledTransferRunTx(gomock.Any(), gomock.Eq(db.RecordScheduledTransferRunTxParams{
+						ScheduledTransfer: monthly,
+						TransferID:        sql.NullInt64{Int64: 99, Valid: true},
+						NextRunAt:         time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC),
+					})).
+					Times(1).
+					Return(db.RecordScheduledTransferRunTxResult{Run: db.ScheduledTransferRun{Status: db.ScheduledRunSucceeded}, Recorded: true}, nil)
+			},
+			checkResponse: func(t *testing.T, claimed bool, err error) {
+				require.NoError(t, err)
+				require.True(t, claimed)
+			},
+		},
+		{
+			name: "Insufficient Funds",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ClaimDueScheduledTransfer(gomock.Any(), gomock.Any()).Times(1).Return(oneOff, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, db.ErrInsufficientFunds)
+				store.EXPECT().
+					RecordScheduledTransferRunTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.RecordScheduledTransferRunTxParams) (db.RecordScheduledTransferRunTxResult, error) {
+						// the failure is recorded and the one-off transfer is done
+						require.Contains(t, arg.Error, "insufficient funds")
+						require.False(t, arg.TransferID.Valid)
+						require.True(t, arg.NextRunAt.IsZero())
+						return db.RecordScheduledTransferRunTxResult{Run: db.ScheduledTransferRun{Status: db.ScheduledRunFailed}, Recorded: true}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, claimed bool, err error) {
+				require.NoError(t, err)
+				require.True(t, claimed)
+			},
+		},
+		{
+			name: "Temporary Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ClaimDueScheduledTransfer(gomock.Any(), gomock.Any()).Times(1).Return(monthly, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrConnDone)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().RecordScheduledTransferRunTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, claimed bool, err error) {
+				// nothing is recorded, the occurrence runs again once the lock expires
+				require.True(t, errors.Is(err, sql.ErrConnDone))
+				require.True(t, claimed)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			fxRateProvider, err := util.NewStaticFXRateProvider(nil)
+			require.NoError(t, err)
+
+			claimed, err := NewScheduler(store, fxRateProvider).runNext(context.Background())
+			tc.checkResponse(t, claimed, err)
+		})
+	}
+}
+
+func TestNextRunAt(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	scheduledTransfer := db.ScheduledTransfer{
+		Recurrence: "FREQ=WEEKLY",
+		StartAt:    start,
+		NextRunAt:  start,
+		EndAt:      sql.NullTime{Time: start.AddDate(0, 0, 7), Valid: true},
+	}
+
+	next, err := nextRunAt(scheduledTransfer)
+	require.NoError(t, err)
+	require.Equal(t, start.AddDate(0, 0, 7), next)
+
+	// the occurrence after the end is not run
+	scheduledTransfer.NextRunAt = next
+	next, err = nextRunAt(scheduledTransfer)
+	require.NoError(t, err)
+	require.True(t, next.IsZero())
+}
@@ -16,12 +16,13 @@ func TestJWTMaker(t *testing.T) {
 
 	// Generate Payload
 	username := util.GenerateRandomName()
+	role := util.DepositorRole
 	duration := time.Minute // 1 * time.Minute
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
 	// Create Token
-	token, payload, err := maker.CreateToken(username, duration)
+	token, payload, err := maker.CreateToken(username, role, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
@@ -34,6 +35,7 @@ func TestJWTMaker(t *testing.T) {
 
 	require.NotZero(t, payload.ID)
 	require.Equal(t, username, payload.Username)
+	require.Equal(t, role, payload.Role)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
 }
@@ -42,7 +44,7 @@ func TestExpiredJWTToken(t *testing.T) {
 	maker, err := NewJWTMaker(util.RandomString(32))
 	require.NoError(t, err)
 
-	token, payload, err := maker.CreateToken(util.GenerateRandomName(), -time.Minute)
+	token, payload, err := maker.CreateToken(util.GenerateRandomName(), util.DepositorRole, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
@@ -54,7 +56,7 @@ func TestExpiredJWTToken(t *testing.T) {
 }
 
 func TestInvalidJWTToken(t *testing.T) {
-	payload, err := NewPayload(util.GenerateRandomName(), time.Minute)
+	payload, err := NewPayload(util.GenerateRandomName(), util.DepositorRole, time.Minute)
 	require.NoError(t, err)
 
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
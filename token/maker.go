@@ -4,8 +4,8 @@ import "time"
 
 // Maker is an interface for managing token
 type Maker interface {
-	// Create Token for specific username and duration
-	CreateToken(username string, duration time.Duration) (string, *Payload, error)
+	// Create Token for specific username, role and duration
+	CreateToken(username string, role string, duration time.Duration) (string, *Payload, error)
 
 	VerifyToken(token string) (*Payload, error)
 }
@@ -15,12 +15,13 @@ var (
 type Payload struct {
 	ID        uuid.UUID `json:"id"`
 	Username  string    `json:"username"`
+	Role      string    `json:"role"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
-// NewPayload creates payload instance for new token with specific username and duration
-func NewPayload(username string, duration time.Duration) (*Payload, error) {
+// NewPayload creates payload instance for new token with specific username, role and duration
+func NewPayload(username string, role string, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -29,6 +30,7 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 	payload := &Payload{
 		ID:        tokenID,
 		Username:  username,
+		Role:      role,
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}
@@ -36,6 +38,16 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 	return payload, nil
 }
 
+// HasRole returns true if the payload carries one of the given roles
+func (payload *Payload) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if payload.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
 // Implement Valid method for payload jwtClaims
 func (payload *Payload) Valid() error {
 	if time.Now().After(payload.ExpiredAt) {
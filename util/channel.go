@@ -0,0 +1,18 @@
+package util
+
+// constant for all channels money is deposited or withdrawn through
+const (
+	ChannelBranch       = "branch"
+	ChannelATM          = "atm"
+	ChannelBankTransfer = "bank_transfer"
+	ChannelCard         = "card"
+)
+
+// IsSupportedChannel returns true if the channel is supported
+func IsSupportedChannel(channel string) bool {
+	switch channel {
+	case ChannelBranch, ChannelATM, ChannelBankTransfer, ChannelCard:
+		return true
+	}
+	return false
+}
@@ -18,6 +18,16 @@ type Config struct {
 	TokenKey             string        `mapstructure:"TOKEN_KEY"`
 	TokenDuration        time.Duration `mapstructure:"TOKEN_DURATION"`
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	MFAChallengeDuration time.Duration `mapstructure:"MFA_CHALLENGE_DURATION"`
+	StepUpDuration       time.Duration `mapstructure:"STEP_UP_DURATION"`
+	FXRatesFile          string        `mapstructure:"FX_RATES_FILE"`
+	MailDir              string        `mapstructure:"MAIL_DIR"`
+	VerifyEmailURL       string        `mapstructure:"VERIFY_EMAIL_URL"`
+	WorkerConcurrency    int           `mapstructure:"WORKER_CONCURRENCY"`
+	OutboxFile           string        `mapstructure:"OUTBOX_FILE"`
+
+	ResetPasswordURL      string        `mapstructure:"RESET_PASSWORD_URL"`
+	PasswordResetDuration time.Duration `mapstructure:"PASSWORD_RESET_DURATION"`
 }
 
 // LoadConfig read configuration from file
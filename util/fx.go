@@ -0,0 +1,120 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// ErrUnsupportedFXPair is returned when no rate is known for a currency pair
+var ErrUnsupportedFXPair = errors.New("unsupported currency pair")
+
+// FXRateProvider provides exchange rates between supported currencies
+// Rates are decimal strings so they can be stored without losing precision
+type FXRateProvider interface {
+	// GetRate returns the rate to multiply an amount in from currency to get the amount in to currency
+	GetRate(ctx context.Context, from string, to string) (string, error)
+}
+
+// StaticFXRateProvider serves a fixed set of exchange rates
+type StaticFXRateProvider struct {
+	rates map[string]map[string]*big.Rat
+}
+
+// NewStaticFXRateProvider creates a provider from rates keyed by source then destination currency
+func NewStaticFXRateProvider(rates map[string]map[string]string) (*StaticFXRateProvider, error) {
+	provider := &StaticFXRateProvider{
+		rates: make(map[string]map[string]*big.Rat),
+	}
+
+	for from, targets := range rates {
+		if !IsSupportedCurrency(from) {
+			return nil, fmt.Errorf("unsupported currency: %s", from)
+		}
+
+		provider.rates[from] = make(map[string]*big.Rat)
+		for to, value := range targets {
+			if !IsSupportedCurrency(to) {
+				return nil, fmt.Errorf("unsupported currency: %s", to)
+			}
+
+			rate, ok := new(big.Rat).SetString(value)
+			if !ok || rate.Sign() <= 0 {
+				return nil, fmt.Errorf("invalid rate %s for %s/%s", value, from, to)
+			}
+			provider.rates[from][to] = rate
+		}
+	}
+
+	return provider, nil
+}
+
+// LoadFXRateProvider creates a static provider from a JSON file, an empty path only allows same currency transfers
+func LoadFXRateProvider(path string) (*StaticFXRateProvider, error) {
+	rates := make(map[string]map[string]string)
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read fx rates file: %w", err)
+		}
+
+		if err := json.Unmarshal(data, &rates); err != nil {
+			return nil, fmt.Errorf("cannot parse fx rates file: %w", err)
+		}
+	}
+
+	return NewStaticFXRateProvider(rates)
+}
+
+// GetRate looks up the direct rate and falls back to the inverse of the opposite pair
+func (provider *StaticFXRateProvider) GetRate(ctx context.Context, from string, to string) (string, error) {
+	if from == to {
+		return "1", nil
+	}
+
+	if rate, ok := provider.rates[from][to]; ok {
+		return formatRate(rate), nil
+	}
+
+	if rate, ok := provider.rates[to][from]; ok {
+		return formatRate(new(big.Rat).Inv(rate)), nil
+	}
+
+	return "", fmt.Errorf("%w: %s/%s", ErrUnsupportedFXPair, from, to)
+}
+
+func formatRate(rate *big.Rat) string {
+	value := rate.FloatString(10)
+	value = strings.TrimRight(value, "0")
+	return strings.TrimSuffix(value, ".")
+}
+
+// ConvertAmount multiplies amount by a decimal rate, rounding half away from zero
+func ConvertAmount(amount int64, rate string) (int64, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid exchange rate: %s", rate)
+	}
+
+	value := new(big.Rat).Mul(big.NewRat(amount, 1), r)
+
+	// round |num| / den to the nearest integer
+	num := new(big.Int).Abs(value.Num())
+	den := value.Denom()
+	num.Mul(num, big.NewInt(2)).Add(num, den)
+	num.Quo(num, new(big.Int).Mul(den, big.NewInt(2)))
+	if value.Sign() < 0 {
+		num.Neg(num)
+	}
+
+	if !num.IsInt64() {
+		return 0, fmt.Errorf("converted amount overflows")
+	}
+
+	return num.Int64(), nil
+}
@@ -0,0 +1,62 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticFXRateProvider(t *testing.T) {
+	provider, err := NewStaticFXRateProvider(map[string]map[string]string{
+		USD: {EUR: "0.8", CAD: "1.25"},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	rate, err := provider.GetRate(ctx, USD, USD)
+	require.NoError(t, err)
+	require.Equal(t, "1", rate)
+
+	rate, err = provider.GetRate(ctx, USD, EUR)
+	require.NoError(t, err)
+	require.Equal(t, "0.8", rate)
+
+	// inverse of USD/EUR
+	rate, err = provider.GetRate(ctx, EUR, USD)
+	require.NoError(t, err)
+	require.Equal(t, "1.25", rate)
+
+	_, err = provider.GetRate(ctx, EUR, CAD)
+	require.ErrorIs(t, err, ErrUnsupportedFXPair)
+}
+
+func TestInvalidStaticFXRateProvider(t *testing.T) {
+	_, err := NewStaticFXRateProvider(map[string]map[string]string{
+		USD: {EUR: "-1"},
+	})
+	require.Error(t, err)
+
+	_, err = NewStaticFXRateProvider(map[string]map[string]string{
+		USD: {"IDR": "15000"},
+	})
+	require.Error(t, err)
+}
+
+func TestConvertAmount(t *testing.T) {
+	amount, err := ConvertAmount(100, "1")
+	require.NoError(t, err)
+	require.Equal(t, int64(100), amount)
+
+	amount, err = ConvertAmount(100, "0.925")
+	require.NoError(t, err)
+	require.Equal(t, int64(93), amount)
+
+	amount, err = ConvertAmount(-100, "0.925")
+	require.NoError(t, err)
+	require.Equal(t, int64(-93), amount)
+
+	_, err = ConvertAmount(100, "abc")
+	require.Error(t, err)
+}
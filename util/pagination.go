@@ -0,0 +1,48 @@
+package util
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPageToken is returned when a page token cannot be decoded
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// EncodePageToken creates an opaque cursor pointing after the row with the given created_at and id
+func EncodePageToken(createdAt time.Time, id int64) string {
+	cursor := fmt.Sprintf("%d:%d", createdAt.UnixMicro(), id)
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+// DecodePageToken returns the cursor stored in the token, an empty token points before the first row
+func DecodePageToken(token string) (createdAt time.Time, id int64, err error) {
+	if token == "" {
+		return time.Time{}, 0, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidPageToken
+	}
+
+	fields := strings.Split(string(data), ":")
+	if len(fields) != 2 {
+		return time.Time{}, 0, ErrInvalidPageToken
+	}
+
+	micros, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidPageToken
+	}
+
+	id, err = strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidPageToken
+	}
+
+	return time.UnixMicro(micros).UTC(), id, nil
+}
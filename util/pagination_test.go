@@ -0,0 +1,36 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	id := RandomInt(1, 1000)
+
+	token := EncodePageToken(createdAt, id)
+	require.NotEmpty(t, token)
+
+	cursorCreatedAt, cursorID, err := DecodePageToken(token)
+	require.NoError(t, err)
+	require.True(t, createdAt.Equal(cursorCreatedAt))
+	require.Equal(t, id, cursorID)
+}
+
+func TestEmptyPageToken(t *testing.T) {
+	cursorCreatedAt, cursorID, err := DecodePageToken("")
+	require.NoError(t, err)
+	require.True(t, cursorCreatedAt.IsZero())
+	require.Zero(t, cursorID)
+}
+
+func TestInvalidPageToken(t *testing.T) {
+	_, _, err := DecodePageToken("not a token")
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, _, err = DecodePageToken(EncodePageToken(time.Now(), 1) + "x")
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+}
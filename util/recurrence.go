@@ -0,0 +1,151 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// constant for all supported recurrence frequencies
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+// ErrInvalidRecurrence is returned when a recurrence rule cannot be parsed
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+// Recurrence is the subset of an iCalendar RRULE the scheduled transfers support,
+// for example FREQ=MONTHLY;BYMONTHDAY=1 for the 1st of every month.
+// The occurrences are anchored at the start time, which also gives their time of day
+type Recurrence struct {
+	Freq     string
+	Interval int
+	// ByMonthDay is the day of the month of a monthly recurrence, it falls on the last day of shorter months.
+	// 0 means the day of the start time
+	ByMonthDay int
+}
+
+// ParseRecurrence parses a rule like FREQ=WEEKLY;INTERVAL=2, an optional RRULE: prefix is allowed
+func ParseRecurrence(rule string) (Recurrence, error) {
+	recurrence := Recurrence{Interval: 1}
+
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return recurrence, fmt.Errorf("%w: empty rule", ErrInvalidRecurrence)
+	}
+
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return recurrence, fmt.Errorf("%w: %q is not NAME=VALUE", ErrInvalidRecurrence, part)
+		}
+
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			recurrence.Freq = strings.ToUpper(value)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 || interval > 366 {
+				return recurrence, fmt.Errorf("%w: INTERVAL must be from 1 - 366", ErrInvalidRecurrence)
+			}
+			recurrence.Interval = interval
+		case "BYMONTHDAY":
+			day, err := strconv.Atoi(value)
+			if err != nil || day < 1 || day > 31 {
+				return recurrence, fmt.Errorf("%w: BYMONTHDAY must be from 1 - 31", ErrInvalidRecurrence)
+			}
+			recurrence.ByMonthDay = day
+		default:
+			return recurrence, fmt.Errorf("%w: unsupported part %s", ErrInvalidRecurrence, name)
+		}
+	}
+
+	switch recurrence.Freq {
+	case FreqDaily, FreqWeekly:
+		if recurrence.ByMonthDay != 0 {
+			return recurrence, fmt.Errorf("%w: BYMONTHDAY needs FREQ=MONTHLY", ErrInvalidRecurrence)
+		}
+	case FreqMonthly:
+	default:
+		return recurrence, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY or MONTHLY", ErrInvalidRecurrence)
+	}
+
+	return recurrence, nil
+}
+
+// String formats the recurrence back into a rule
+func (recurrence Recurrence) String() string {
+	rule := "FREQ=" + recurrence.Freq
+	if recurrence.Interval > 1 {
+		rule += ";INTERVAL=" + strconv.Itoa(recurrence.Interval)
+	}
+	if recurrence.ByMonthDay != 0 {
+		rule += ";BYMONTHDAY=" + strconv.Itoa(recurrence.ByMonthDay)
+	}
+	return rule
+}
+
+// Next returns the first occurrence of a recurrence starting at start that comes after the given time
+func (recurrence Recurrence) Next(start time.Time, after time.Time) time.Time {
+	if recurrence.Freq == FreqMonthly {
+		return recurrence.nextMonthly(start, after)
+	}
+
+	days := recurrence.Interval
+	if recurrence.Freq == FreqWeekly {
+		days *= 7
+	}
+
+	if after.Before(start) {
+		return start
+	}
+
+	// jump close to the answer, then step over the occurrences that are not after yet
+	k := int(after.Sub(start) / (time.Duration(days) * 24 * time.Hour))
+	next := start.AddDate(0, 0, k*days)
+	for !next.After(after) {
+		k++
+		next = start.AddDate(0, 0, k*days)
+	}
+	return next
+}
+
+func (recurrence Recurrence) nextMonthly(start time.Time, after time.Time) time.Time {
+	day := recurrence.ByMonthDay
+	if day == 0 {
+		day = start.Day()
+	}
+
+	k := 0
+	if after.After(start) {
+		months := (after.Year()-start.Year())*12 + int(after.Month()-start.Month())
+		k = months/recurrence.Interval - 1
+		if k < 0 {
+			k = 0
+		}
+	}
+
+	for {
+		next := monthlyOccurrence(start, k*recurrence.Interval, day)
+		if !next.Before(start) && next.After(after) {
+			return next
+		}
+		k++
+	}
+}
+
+// monthlyOccurrence returns the given day of the month months after the month of start, at the time of day of start
+func monthlyOccurrence(start time.Time, months int, day int) time.Time {
+	firstOfMonth := time.Date(start.Year(), start.Month()+time.Month(months), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,88 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	recurrence, err := ParseRecurrence("RRULE:FREQ=monthly;BYMONTHDAY=1")
+	require.NoError(t, err)
+	require.Equal(t, Recurrence{Freq: FreqMonthly, Interval: 1, ByMonthDay: 1}, recurrence)
+	require.Equal(t, "FREQ=MONTHLY;BYMONTHDAY=1", recurrence.String())
+
+	recurrence, err = ParseRecurrence("FREQ=WEEKLY;INTERVAL=2")
+	require.NoError(t, err)
+	require.Equal(t, "FREQ=WEEKLY;INTERVAL=2", recurrence.String())
+
+	for _, rule := range []string{"", "FREQ=YEARLY", "FREQ=DAILY;BYMONTHDAY=3", "FREQ=MONTHLY;BYMONTHDAY=32", "FREQ=DAILY;INTERVAL=0", "FREQ=DAILY;COUNT=3", "FREQ"} {
+		_, err := ParseRecurrence(rule)
+		require.ErrorIs(t, err, ErrInvalidRecurrence, rule)
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		rule     string
+		after    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "BeforeStart",
+			rule:     "FREQ=DAILY",
+			after:    start.Add(-time.Hour),
+			expected: start,
+		},
+		{
+			name:     "Daily",
+			rule:     "FREQ=DAILY",
+			after:    start,
+			expected: time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "WeeklyInterval",
+			rule:     "FREQ=WEEKLY;INTERVAL=2",
+			after:    time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, time.February, 14, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "MonthlyShortMonth",
+			rule:     "FREQ=MONTHLY",
+			after:    start,
+			expected: time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "MonthlyBackToLongMonth",
+			rule:     "FREQ=MONTHLY",
+			after:    time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, time.March, 31, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "MonthlyByMonthDay",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=1",
+			after:    start,
+			expected: time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "MonthlyFarAfter",
+			rule:     "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=15",
+			after:    time.Date(2026, time.May, 20, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2026, time.July, 15, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			recurrence, err := ParseRecurrence(tc.rule)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, recurrence.Next(start, tc.after))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package util
+
+// constant for all user roles
+const (
+	// DepositorRole is the default role, a customer owning accounts
+	DepositorRole = "depositor"
+	// SupportRole is bank staff that can look at any account
+	SupportRole = "support"
+	// BankerRole is bank staff that can look at and manage any account
+	BankerRole = "banker"
+	// IntegrationRole is a trusted system, like a payment processor, that moves money in and out of accounts
+	IntegrationRole = "integration"
+)
+
+// IsSupportedRole returns true if the role is supported
+func IsSupportedRole(role string) bool {
+	switch role {
+	case DepositorRole, SupportRole, BankerRole, IntegrationRole:
+		return true
+	}
+	return false
+}
+
+// StaffRoles are the roles allowed to look at accounts of any user
+var StaffRoles = []string{SupportRole, BankerRole}
+
+// CashRoles are the roles allowed to deposit to and withdraw from any account
+var CashRoles = []string{BankerRole, IntegrationRole}
@@ -0,0 +1,46 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// GenerateSecretToken returns a random token of 256 bits for the links and challenges handed to users,
+// only its HashSecretToken is stored
+func GenerateSecretToken() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("cannot generate secret token: %w", err)
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// HashSecretToken returns the hex SHA-256 of the token, the tokens are random enough not to need a slow hash
+func HashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateRecoveryCodes returns n random codes like abcde-fghij
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	for i := range codes {
+		key := make([]byte, 7)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("cannot generate recovery code: %w", err)
+		}
+
+		code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(key))[:10]
+		codes[i] = code[:5] + "-" + code[5:]
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode lets users type recovery codes in any case and with spaces around them
+func NormalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
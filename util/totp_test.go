@@ -0,0 +1,88 @@
+package util
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// test vectors of RFC 6238 truncated to 6 digits
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+
+	for _, tc := range testCases {
+		code, err := TOTPCode(secret, TOTPStep(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tc.code, code)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	step := TOTPStep(now)
+
+	code, err := TOTPCode(secret, step)
+	require.NoError(t, err)
+
+	matched, ok := ValidateTOTP(secret, code, now, 0)
+	require.True(t, ok)
+	require.Equal(t, step, matched)
+
+	// the previous period is still accepted for clock drift
+	previous, err := TOTPCode(secret, step-1)
+	require.NoError(t, err)
+	_, ok = ValidateTOTP(secret, previous, now, 0)
+	require.True(t, ok)
+
+	// a code can't be used twice
+	_, ok = ValidateTOTP(secret, code, now, step)
+	require.False(t, ok)
+
+	_, ok = ValidateTOTP(secret, code, now.Add(10*TOTPPeriod), 0)
+	require.False(t, ok)
+
+	_, ok = ValidateTOTP(secret, "12345", now, 0)
+	require.False(t, ok)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("Go Bank", "alice", "ABCDEF")
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", parsed.Scheme)
+	require.Equal(t, "totp", parsed.Host)
+	require.Equal(t, "/Go Bank:alice", parsed.Path)
+	require.Equal(t, "ABCDEF", parsed.Query().Get("secret"))
+	require.Equal(t, "Go Bank", parsed.Query().Get("issuer"))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		require.Len(t, code, 11)
+		require.Equal(t, code, NormalizeRecoveryCode(" "+code+" "))
+		require.False(t, seen[code])
+		seen[code] = true
+	}
+}
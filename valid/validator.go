@@ -3,9 +3,17 @@ package valid
 import (
 	"fmt"
 	"net/mail"
+	"net/url"
 	"regexp"
+	"time"
+
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/webhook"
 )
 
+// MaxStatementPeriod limits how long a single account statement may cover
+const MaxStatementPeriod = 366 * 24 * time.Hour
+
 var (
 	isValidUsername = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
 	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
@@ -54,3 +62,112 @@ func ValidateEmail(value string) error {
 
 	return nil
 }
+
+func ValidateID(value int64) error {
+	if value < 1 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if !util.IsSupportedCurrency(value) {
+		return fmt.Errorf("unsupported currency: %s", value)
+	}
+	return nil
+}
+
+func ValidateChannel(value string) error {
+	if !util.IsSupportedChannel(value) {
+		return fmt.Errorf("unsupported channel: %s", value)
+	}
+	return nil
+}
+
+// ValidateRecurrence checks a scheduled transfer recurrence rule, empty is a one-off transfer
+func ValidateRecurrence(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if err := ValidateString(value, 1, 255); err != nil {
+		return err
+	}
+
+	_, err := util.ParseRecurrence(value)
+	return err
+}
+
+func ValidateStatementPeriod(fromTime time.Time, toTime time.Time) error {
+	if !fromTime.Before(toTime) {
+		return fmt.Errorf("from time must be before to time")
+	}
+
+	if toTime.Sub(fromTime) > MaxStatementPeriod {
+		return fmt.Errorf("period must not exceed %d days", MaxStatementPeriod/(24*time.Hour))
+	}
+
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 5 || value > 100 {
+		return fmt.Errorf("must be between 5 and 100")
+	}
+	return nil
+}
+
+func ValidateSecretCode(value string) error {
+	return ValidateString(value, 32, 128)
+}
+
+func ValidateWebhookURL(value string) error {
+	if err := ValidateString(value, 10, 2048); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("must be an absolute https url")
+	}
+
+	return nil
+}
+
+func ValidateWebhookEventTypes(values []string) error {
+	if len(values) == 0 {
+		return fmt.Errorf("must contain at least one event type")
+	}
+
+	for _, value := range values {
+		if !webhook.IsSupportedEventType(value) {
+			return fmt.Errorf("unsupported event type: %s", value)
+		}
+	}
+
+	return nil
+}
+
+func ValidateWebhookSecret(value string) error {
+	return ValidateString(value, 16, 128)
+}
+
+// ValidateMFACode accepts the codes of authenticator apps and recovery codes
+func ValidateMFACode(value string) error {
+	return ValidateString(value, util.TOTPDigits, 20)
+}
+
+// ValidateTOTPCode only accepts the codes of authenticator apps
+func ValidateTOTPCode(value string) error {
+	if len(value) != util.TOTPDigits {
+		return fmt.Errorf("must contain %d digits", util.TOTPDigits)
+	}
+
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return fmt.Errorf("must contain %d digits", util.TOTPDigits)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package valid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWebhookURL(t *testing.T) {
+	// the address is checked by the RPC and again on every connection, not here
+	require.NoError(t, ValidateWebhookURL("https://8.8.8.8/webhooks"))
+	require.NoError(t, ValidateWebhookURL("https://localhost/webhooks"))
+
+	for _, value := range []string{"http://8.8.8.8/webhooks", "/webhooks/receiver", "https:///webhooks", "ftp://example.com/webhooks"} {
+		require.Error(t, ValidateWebhookURL(value), value)
+	}
+}
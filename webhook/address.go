@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// ErrForbiddenAddress is returned for endpoints resolving to an address of the internal network
+var ErrForbiddenAddress = errors.New("address not allowed")
+
+const resolveTimeout = 5 * time.Second
+
+// CheckIP rejects loopback, private, link-local, unspecified and multicast addresses,
+// so endpoints can't be used to reach services inside the network of the bank
+func CheckIP(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+	}
+	return nil
+}
+
+// CheckHost resolves the host and checks every address it resolves to.
+// The client checks the address again when it connects, the host may resolve differently by then
+func CheckHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		return CheckIP(ip)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("cannot resolve host %s", host)
+	}
+
+	for _, addr := range addrs {
+		if err := CheckIP(addr.IP); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
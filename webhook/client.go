@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Client posts signed deliveries to the webhook endpoints
+type Client struct {
+	httpClient *http.Client
+}
+
+type clientOptions struct {
+	allowLoopback bool
+}
+
+// ClientOption configures a Client
+type ClientOption func(*clientOptions)
+
+// AllowLoopback lets the client connect to loopback addresses, it's only meant for tests against a local receiver
+func AllowLoopback() ClientOption {
+	return func(options *clientOptions) {
+		options.allowLoopback = true
+	}
+}
+
+// NewClient creates a new webhook client, a zero timeout uses the default of 10 seconds.
+// The address is checked when the connection is made, after the host has been resolved,
+// so a host resolving to an internal address by then is refused too. Redirects are not followed
+func NewClient(timeout time.Duration, opts ...ClientOption) *Client {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+			if options.allowLoopback && ip.IsLoopback() {
+				return nil
+			}
+			return CheckIP(ip)
+		},
+	}
+
+	return &Client{
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			CheckRedirect: func(request *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Deliver posts the delivery to the endpoint signed with the endpoint secret.
+// It returns the response status code, which is 0 when no response was received,
+// and an error unless the receiver answered with a 2xx status. The response body is never read,
+// the error only holds the status code
+func (client *Client) Deliver(ctx context.Context, endpoint db.WebhookEndpoint, delivery db.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(Event{
+		ID:        delivery.EventID,
+		Type:      delivery.EventType,
+		CreatedAt: delivery.CreatedAt,
+		Data:      delivery.Payload,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderID, delivery.EventID.String())
+	request.Header.Set(HeaderEvent, delivery.EventType)
+
+	timestamp := time.Now()
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	request.Header.Set(HeaderSignature, Sign(endpoint.Secret, timestamp, body))
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post event: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("endpoint responded with status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
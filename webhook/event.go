@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// constant for all event types an endpoint can subscribe to
+const (
+	EventEntryCreated      = "entry.created"
+	EventTransferCompleted = db.EventTransferCompleted
+)
+
+// EventTypes lists the event types an endpoint can subscribe to
+var EventTypes = []string{EventEntryCreated, EventTransferCompleted}
+
+// IsSupportedEventType returns true if endpoints can subscribe to the event type
+func IsSupportedEventType(eventType string) bool {
+	for _, supported := range EventTypes {
+		if eventType == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is the JSON body posted to the endpoints.
+// ID is stable across retries and replays, receivers use it to drop duplicates
+type Event struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// EntryData is the data of the entry.created event
+type EntryData struct {
+	ID                int64     `json:"id"`
+	AccountID         int64     `json:"account_id"`
+	Amount            int64     `json:"amount"`
+	ExchangeRate      string    `json:"exchange_rate"`
+	SourceAmount      int64     `json:"source_amount"`
+	DestinationAmount int64     `json:"destination_amount"`
+	TransferID        int64     `json:"transfer_id,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// NewEntryData converts an entry into the data of the entry.created event
+func NewEntryData(entry db.Entry) EntryData {
+	return EntryData{
+		ID:                entry.ID,
+		AccountID:         entry.AccountID,
+		Amount:            entry.Amount,
+		ExchangeRate:      entry.ExchangeRate,
+		SourceAmount:      entry.SourceAmount,
+		DestinationAmount: entry.DestinationAmount,
+		TransferID:        entry.TransferID.Int64,
+		CreatedAt:         entry.CreatedAt,
+	}
+}
@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// constant for the headers sent with every delivery
+const (
+	HeaderID        = "X-Webhook-Id"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+// ErrInvalidSignature is returned when a delivery signature does not match its body
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// NewSecret generates a random secret for signing the deliveries of an endpoint
+func NewSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("cannot generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(key), nil
+}
+
+// Sign computes the signature header value of a delivery.
+// The timestamp is part of the signed message so a captured request can't be replayed later
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and timestamp headers of a received delivery.
+// Deliveries signed more than tolerance ago are rejected, a zero tolerance disables the check
+func Verify(secret string, timestampHeader string, signatureHeader string, body []byte, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	timestamp := time.Unix(unix, 0)
+
+	if tolerance > 0 && time.Since(timestamp) > tolerance {
+		return fmt.Errorf("%w: timestamp is too old", ErrInvalidSignature)
+	}
+
+	if !strings.HasPrefix(signatureHeader, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+
+	expected := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(signatureHeader)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func randomDelivery(endpointID int64) db.WebhookDelivery {
+	return db.WebhookDelivery{
+		ID:         1,
+		EndpointID: endpointID,
+		EventID:    uuid.New(),
+		EventType:  EventEntryCreated,
+		Payload:    json.RawMessage(`{"id":1,"account_id":2,"amount":-10}`),
+		Status:     "pending",
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+
+	body := []byte(`{"id":"1"}`)
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := Sign(secret, now, body)
+
+	require.NoError(t, Verify(secret, timestamp, signature, body, time.Minute))
+
+	err = Verify(secret, timestamp, signature, []byte(`{"id":"2"}`), time.Minute)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	err = Verify("whsec_other", timestamp, signature, body, time.Minute)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	old := now.Add(-time.Hour)
+	err = Verify(secret, strconv.FormatInt(old.Unix(), 10), Sign(secret, old, body), body, time.Minute)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestDeliver(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+
+	endpoint := db.WebhookEndpoint{ID: 1, Owner: "alice", Secret: secret}
+	delivery := randomDelivery(endpoint.ID)
+
+	var received Event
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, delivery.EventID.String(), r.Header.Get(HeaderID))
+		require.Equal(t, delivery.EventType, r.Header.Get(HeaderEvent))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		err = Verify(secret, r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), body, time.Minute)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	endpoint.Url = receiver.URL
+	client := NewClient(time.Second, AllowLoopback())
+
+	statusCode, err := client.Deliver(context.Background(), endpoint, delivery)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, statusCode)
+
+	require.Equal(t, delivery.EventID, received.ID)
+	require.Equal(t, delivery.EventType, received.Type)
+	require.JSONEq(t, string(delivery.Payload), string(received.Data))
+	require.WithinDuration(t, delivery.CreatedAt, received.CreatedAt, time.Second)
+}
+
+func TestDeliverWrongSecret(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		err := Verify("whsec_receiver", r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), body, time.Minute)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	endpoint := db.WebhookEndpoint{ID: 1, Url: receiver.URL, Secret: "whsec_sender"}
+	client := NewClient(time.Second, AllowLoopback())
+
+	statusCode, err := client.Deliver(context.Background(), endpoint, randomDelivery(endpoint.ID))
+	require.Error(t, err)
+	require.Equal(t, http.StatusUnauthorized, statusCode)
+	require.NotContains(t, err.Error(), "invalid webhook signature")
+}
+
+func TestDeliverUnreachable(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	url := receiver.URL
+	receiver.Close()
+
+	endpoint := db.WebhookEndpoint{ID: 1, Url: url, Secret: "whsec_secret"}
+	client := NewClient(time.Second, AllowLoopback())
+
+	statusCode, err := client.Deliver(context.Background(), endpoint, randomDelivery(endpoint.ID))
+	require.Error(t, err)
+	require.Zero(t, statusCode)
+}
+
+func TestDeliverForbiddenAddress(t *testing.T) {
+	calls := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	// the default client refuses to connect to the loopback receiver
+	endpoint := db.WebhookEndpoint{ID: 1, Url: receiver.URL, Secret: "whsec_secret"}
+	client := NewClient(time.Second)
+
+	statusCode, err := client.Deliver(context.Background(), endpoint, randomDelivery(endpoint.ID))
+	require.ErrorIs(t, err, ErrForbiddenAddress)
+	require.Zero(t, statusCode)
+	require.Zero(t, calls)
+}
+
+func TestDeliverRedirect(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+
+	receiver := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer receiver.Close()
+
+	// redirects are not followed, they could point into the internal network
+	endpoint := db.WebhookEndpoint{ID: 1, Url: receiver.URL, Secret: "whsec_secret"}
+	client := NewClient(time.Second, AllowLoopback())
+
+	statusCode, err := client.Deliver(context.Background(), endpoint, randomDelivery(endpoint.ID))
+	require.Error(t, err)
+	require.Equal(t, http.StatusFound, statusCode)
+}
+
+func TestCheckIP(t *testing.T) {
+	for _, value := range []string{"8.8.8.8", "2606:4700:4700::1111"} {
+		require.NoError(t, CheckIP(net.ParseIP(value)), value)
+	}
+
+	for _, value := range []string{"127.0.0.1", "::1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "fd00::1",
+		"169.254.169.254", "fe80::1", "0.0.0.0", "::", "224.0.0.1", "ff02::1", "::ffff:127.0.0.1"} {
+		require.ErrorIs(t, CheckIP(net.ParseIP(value)), ErrForbiddenAddress, value)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	require.NoError(t, CheckHost(context.Background(), "8.8.8.8"))
+
+	testCases := []struct {
+		name string
+		host string
+	}{
+		{name: "Loopback", host: "127.0.0.1"},
+		{name: "Loopback IPv6", host: "::1"},
+		{name: "Localhost", host: "localhost"},
+		{name: "Private", host: "10.0.0.5"},
+		{name: "Private 172", host: "172.16.3.4"},
+		{name: "Private 192", host: "192.168.0.10"},
+		{name: "Private IPv6", host: "fd12::1"},
+		{name: "Link Local", host: "169.254.169.254"},
+		{name: "Link Local IPv6", host: "fe80::1"},
+		{name: "Unspecified", host: "0.0.0.0"},
+		{name: "Multicast", host: "224.0.0.1"},
+		{name: "IPv4 Mapped Loopback", host: "::ffff:127.0.0.1"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, CheckHost(context.Background(), tc.host), ErrForbiddenAddress)
+		})
+	}
+
+	// the lookup stops with the context of the request
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, CheckHost(ctx, "example.com"))
+}
@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+)
+
+// TaskDistributor enqueues tasks for the background workers
+type TaskDistributor interface {
+	DistributeTaskDispatchWebhooks(ctx context.Context, payload *PayloadDispatchWebhooks, opts ...Option) error
+	DistributeTaskDeliverWebhook(ctx context.Context, payload *PayloadDeliverWebhook, opts ...Option) error
+	DistributeTaskSendResetPassword(ctx context.Context, payload *PayloadSendResetPassword, opts ...Option) error
+}
+
+// PostgresTaskDistributor stores tasks in the tasks table of the database
+type PostgresTaskDistributor struct {
+	store db.Store
+}
+
+// NewPostgresTaskDistributor creates a new task distributor backed by the database
+func NewPostgresTaskDistributor(store db.Store) TaskDistributor {
+	return &PostgresTaskDistributor{
+		store: store,
+	}
+}
+
+// NewTask builds a new task of the given type, so it can also be inserted within the transaction of the caller
+func NewTask(taskType string, payload interface{}, opts ...Option) (db.CreateTaskParams, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return db.CreateTaskParams{}, fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	options := taskOptions{
+		queue:       QueueDefault,
+		maxAttempts: defaultMaxAttempts,
+		runAt:       time.Now(),
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	arg := db.CreateTaskParams{
+		Type:        taskType,
+		Payload:     jsonPayload,
+		Queue:       options.queue,
+		Priority:    queuePriority(options.queue),
+		MaxAttempts: options.maxAttempts,
+		RunAt:       options.runAt,
+	}
+
+	return arg, nil
+}
+
+// distribute stores a new task of the given type
+func (distributor *PostgresTaskDistributor) distribute(ctx context.Context, taskType string, payload interface{}, opts ...Option) (db.Task, error) {
+	arg, err := NewTask(taskType, payload, opts...)
+	if err != nil {
+		return db.Task{}, err
+	}
+
+	task, err := distributor.store.CreateTask(ctx, arg)
+	if err != nil {
+		return task, fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	return task, nil
+}
@@ -0,0 +1,53 @@
+package worker
+
+import "time"
+
+// constant for all queues, tasks of a higher priority queue are processed first
+const (
+	QueueCritical = "critical"
+	QueueDefault  = "default"
+	QueueLow      = "low"
+)
+
+const defaultMaxAttempts = 10
+
+// queuePriority returns the priority of the tasks in a queue, unknown queues get the default priority
+func queuePriority(queue string) int32 {
+	switch queue {
+	case QueueCritical:
+		return 10
+	case QueueLow:
+		return 1
+	}
+	return 5
+}
+
+type taskOptions struct {
+	queue       string
+	maxAttempts int32
+	runAt       time.Time
+}
+
+// Option configures how a task is enqueued
+type Option func(*taskOptions)
+
+// Queue puts the task into the given queue
+func Queue(queue string) Option {
+	return func(options *taskOptions) {
+		options.queue = queue
+	}
+}
+
+// MaxAttempts sets how often the task is tried before it is moved to the dead letter queue
+func MaxAttempts(attempts int32) Option {
+	return func(options *taskOptions) {
+		options.maxAttempts = attempts
+	}
+}
+
+// ProcessIn delays the first attempt of the task
+func ProcessIn(delay time.Duration) Option {
+	return func(options *taskOptions) {
+		options.runAt = time.Now().Add(delay)
+	}
+}
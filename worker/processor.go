@@ -0,0 +1,199 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/mail"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/webhook"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrSkipRetry marks a task error as permanent, the task is moved to the dead letter queue right away
+var ErrSkipRetry = errors.New("skip retry")
+
+const (
+	defaultConcurrency  = 4
+	defaultPollInterval = time.Second
+	taskLease           = 5 * time.Minute
+	maxRetryDelay       = time.Hour
+)
+
+// TaskProcessor runs the workers that process the enqueued tasks
+type TaskProcessor interface {
+	Start()
+	Shutdown()
+}
+
+type taskHandler func(ctx context.Context, task db.Task) error
+
+// PostgresTaskProcessor claims tasks from the tasks table with SELECT ... FOR UPDATE SKIP LOCKED,
+// so any number of workers can run next to each other without picking the same task
+type PostgresTaskProcessor struct {
+	store         db.Store
+	distributor   TaskDistributor
+	mailer        mail.EmailSender
+	webhookClient *webhook.Client
+	config        util.Config
+	handlers      map[string]taskHandler
+	concurrency   int
+	pollInterval  time.Duration
+	ctx           context.Context
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
+}
+
+// NewPostgresTaskProcessor creates a new task processor backed by the database
+func NewPostgresTaskProcessor(config util.Config, store db.Store, mailer mail.EmailSender) TaskProcessor {
+	concurrency := config.WorkerConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	processor := &PostgresTaskProcessor{
+		store:         store,
+		distributor:   NewPostgresTaskDistributor(store),
+		mailer:        mailer,
+		webhookClient: webhook.NewClient(0),
+		config:        config,
+		concurrency:   concurrency,
+		pollInterval:  defaultPollInterval,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+
+	processor.handlers = map[string]taskHandler{
+		TaskSendVerifyEmail:   processor.ProcessTaskSendVerifyEmail,
+		TaskSendResetPassword: processor.ProcessTaskSendResetPassword,
+		TaskDispatchWebhooks:  processor.ProcessTaskDispatchWebhooks,
+		TaskDeliverWebhook:    processor.ProcessTaskDeliverWebhook,
+	}
+
+	return processor
+}
+
+// Start launches the workers, it returns immediately
+func (processor *PostgresTaskProcessor) Start() {
+	log.Info().Int("concurrency", processor.concurrency).Msg("start task processor")
+
+	for i := 0; i < processor.concurrency; i++ {
+		processor.wg.Add(1)
+		go processor.work()
+	}
+}
+
+// Shutdown stops claiming new tasks and waits for the running ones to finish
+func (processor *PostgresTaskProcessor) Shutdown() {
+	processor.cancel()
+	processor.wg.Wait()
+}
+
+func (processor *PostgresTaskProcessor) work() {
+	defer processor.wg.Done()
+
+	for {
+		processed, err := processor.processNext()
+		if err != nil {
+			log.Error().Err(err).Msg("failed to process task")
+		}
+
+		if processed {
+			continue
+		}
+
+		select {
+		case <-processor.ctx.Done():
+			return
+		case <-time.After(processor.pollInterval):
+		}
+	}
+}
+
+// processNext claims and processes one task, it returns false when no task is due
+func (processor *PostgresTaskProcessor) processNext() (bool, error) {
+	if processor.ctx.Err() != nil {
+		return false, nil
+	}
+
+	// the task keeps running when a shutdown starts, only claiming new tasks stops
+	ctx := context.Background()
+
+	task, err := processor.store.ClaimTask(ctx, time.Now().Add(taskLease))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim task: %w", err)
+	}
+
+	handler, ok := processor.handlers[task.Type]
+	if !ok {
+		err = fmt.Errorf("%w: unknown task type %s", ErrSkipRetry, task.Type)
+	} else {
+		err = handler(ctx, task)
+	}
+
+	if err == nil {
+		_, err = processor.store.CompleteTask(ctx, task.ID)
+		return true, err
+	}
+
+	return true, processor.fail(ctx, task, err)
+}
+
+// fail schedules the next attempt of a failed task or moves it to the dead letter queue
+func (processor *PostgresTaskProcessor) fail(ctx context.Context, task db.Task, taskErr error) error {
+	lastError := sql.NullString{String: taskErr.Error(), Valid: true}
+
+	if errors.Is(taskErr, ErrSkipRetry) || task.Attempts >= task.MaxAttempts {
+		log.Error().Err(taskErr).
+			Int64("id", task.ID).
+			Str("type", task.Type).
+			Int32("attempts", task.Attempts).
+			Msg("task moved to dead letter queue")
+
+		_, err := processor.store.KillTask(ctx, db.KillTaskParams{
+			ID:        task.ID,
+			LastError: lastError,
+		})
+		return err
+	}
+
+	delay := retryDelay(task.Attempts)
+	log.Warn().Err(taskErr).
+		Int64("id", task.ID).
+		Str("type", task.Type).
+		Int32("attempts", task.Attempts).
+		Dur("retry_in", delay).
+		Msg("task failed")
+
+	_, err := processor.store.RetryTask(ctx, db.RetryTaskParams{
+		ID:        task.ID,
+		RunAt:     time.Now().Add(delay),
+		LastError: lastError,
+	})
+	return err
+}
+
+// retryDelay doubles the delay with every attempt, starting at one second
+func retryDelay(attempts int32) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 12 {
+		return maxRetryDelay
+	}
+
+	delay := time.Second << (attempts - 1)
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
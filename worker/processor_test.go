@@ -0,0 +1,172 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	mockdb "github.com/Cell6969/go_bank/db/mock"
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/Cell6969/go_bank/webhook"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProcessor(store db.Store) *PostgresTaskProcessor {
+	processor := NewPostgresTaskProcessor(util.Config{}, store, nil).(*PostgresTaskProcessor)
+	// the receivers of the tests listen on the loopback interface
+	processor.webhookClient = webhook.NewClient(time.Second, webhook.AllowLoopback())
+	return processor
+}
+
+func TestProcessNext(t *testing.T) {
+	task := db.Task{
+		ID:          1,
+		Type:        "task:test",
+		Payload:     []byte(`{}`),
+		Attempts:    1,
+		MaxAttempts: 3,
+	}
+
+	testCases := []struct {
+		name       string
+		task       db.Task
+		handlerErr error
+		buildStubs func(store *mockdb.MockStore)
+		processed  bool
+	}{
+		{
+			name: "No Task",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Task{}, sql.ErrNoRows)
+			},
+			processed: false,
+		},
+		{
+			name: "Completed",
+			task: task,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(task, nil)
+				store.EXPECT().
+					CompleteTask(gomock.Any(), gomock.Eq(task.ID)).
+					Times(1).
+					Return(task, nil)
+			},
+			processed: true,
+		},
+		{
+			name:       "Retried",
+			task:       task,
+			handlerErr: errors.New("mail server unavailable"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(task, nil)
+				store.EXPECT().
+					RetryTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.RetryTaskParams) (db.Task, error) {
+						require.Equal(t, task.ID, arg.ID)
+						require.Equal(t, "mail server unavailable", arg.LastError.String)
+						require.WithinDuration(t, time.Now().Add(retryDelay(task.Attempts)), arg.RunAt, time.Second)
+						return task, nil
+					})
+			},
+			processed: true,
+		},
+		{
+			name:       "Out Of Attempts",
+			task:       db.Task{ID: 2, Type: task.Type, Attempts: 3, MaxAttempts: 3},
+			handlerErr: errors.New("mail server unavailable"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Task{ID: 2, Type: task.Type, Attempts: 3, MaxAttempts: 3}, nil)
+				store.EXPECT().
+					KillTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Task{}, nil)
+			},
+			processed: true,
+		},
+		{
+			name:       "Skip Retry",
+			task:       task,
+			handlerErr: ErrSkipRetry,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(task, nil)
+				store.EXPECT().
+					KillTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Task{}, nil)
+				store.EXPECT().
+					RetryTask(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			processed: true,
+		},
+		{
+			name: "Unknown Type",
+			task: db.Task{ID: 3, Type: "task:unknown", Attempts: 1, MaxAttempts: 3},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Task{ID: 3, Type: "task:unknown", Attempts: 1, MaxAttempts: 3}, nil)
+				store.EXPECT().
+					KillTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Task{}, nil)
+			},
+			processed: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			processor := newTestProcessor(store)
+			processor.handlers[task.Type] = func(ctx context.Context, task db.Task) error {
+				return tc.handlerErr
+			}
+
+			processed, err := processor.processNext()
+			require.NoError(t, err)
+			require.Equal(t, tc.processed, processed)
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	require.Equal(t, time.Second, retryDelay(1))
+	require.Equal(t, 2*time.Second, retryDelay(2))
+	require.Equal(t, 8*time.Second, retryDelay(4))
+	require.Equal(t, maxRetryDelay, retryDelay(20))
+}
+
+func TestQueuePriority(t *testing.T) {
+	require.Greater(t, queuePriority(QueueCritical), queuePriority(QueueDefault))
+	require.Greater(t, queuePriority(QueueDefault), queuePriority(QueueLow))
+	require.Equal(t, queuePriority(QueueDefault), queuePriority("unknown"))
+}
@@ -0,0 +1,117 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/webhook"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskDeliverWebhook = "task:deliver_webhook"
+
+// PayloadDeliverWebhook is the payload of the task posting one webhook delivery to its endpoint
+type PayloadDeliverWebhook struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+func (distributor *PostgresTaskDistributor) DistributeTaskDeliverWebhook(ctx context.Context, payload *PayloadDeliverWebhook, opts ...Option) error {
+	task, err := distributor.distribute(ctx, TaskDeliverWebhook, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Int64("id", task.ID).
+		Str("type", task.Type).
+		Str("queue", task.Queue).
+		Msg("enqueued task")
+	return nil
+}
+
+// ProcessTaskDeliverWebhook posts the delivery and records the attempt in the delivery log.
+// A failed attempt returns an error so the task is retried with backoff, the delivery is marked
+// as failed once the task runs out of attempts
+func (processor *PostgresTaskProcessor) ProcessTaskDeliverWebhook(ctx context.Context, task db.Task) error {
+	var payload PayloadDeliverWebhook
+	if err := json.Unmarshal(task.Payload, &payload); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal payload: %v", ErrSkipRetry, err)
+	}
+
+	delivery, err := processor.store.GetWebhookDelivery(ctx, payload.DeliveryID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: webhook delivery doesn't exist", ErrSkipRetry)
+		}
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	// the delivery was already made by an earlier task
+	if delivery.Status != db.WebhookDeliveryPending {
+		return nil
+	}
+
+	endpoint, err := processor.store.GetWebhookEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	if !endpoint.IsActive {
+		_, err = processor.store.FailWebhookDelivery(ctx, db.FailWebhookDeliveryParams{
+			ID:        delivery.ID,
+			LastError: sql.NullString{String: "endpoint is deleted", Valid: true},
+		})
+		return err
+	}
+
+	statusCode, deliverErr := processor.webhookClient.Deliver(ctx, endpoint, delivery)
+
+	arg := db.UpdateWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         db.WebhookDeliverySucceeded,
+		ResponseStatus: sql.NullInt32{Int32: int32(statusCode), Valid: statusCode != 0},
+	}
+	if deliverErr == nil {
+		arg.DeliveredAt = sql.NullTime{Time: time.Now(), Valid: true}
+	} else {
+		arg.LastError = sql.NullString{String: deliveryError(statusCode, deliverErr), Valid: true}
+		arg.Status = db.WebhookDeliveryPending
+		if task.Attempts >= task.MaxAttempts {
+			arg.Status = db.WebhookDeliveryFailed
+		}
+	}
+
+	_, err = processor.store.UpdateWebhookDeliveryAttempt(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+
+	if deliverErr != nil {
+		return deliverErr
+	}
+
+	log.Info().
+		Int64("id", task.ID).
+		Str("type", task.Type).
+		Int64("delivery_id", delivery.ID).
+		Int("status_code", statusCode).
+		Msg("processed task")
+	return nil
+}
+
+// deliveryError is the error of a failed attempt shown to the owner of the endpoint in the delivery log.
+// It only tells the status code, the response and the network errors could reveal the internal network
+func deliveryError(statusCode int, err error) string {
+	if errors.Is(err, webhook.ErrForbiddenAddress) {
+		return "endpoint address not allowed"
+	}
+	if statusCode != 0 {
+		return fmt.Sprintf("endpoint responded with status %d", statusCode)
+	}
+	return "endpoint unreachable"
+}
@@ -0,0 +1,211 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/webhook"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskDispatchWebhooks = "task:dispatch_webhooks"
+
+// PayloadDispatchWebhooks is the payload of the task creating the webhook deliveries of an outbox event
+type PayloadDispatchWebhooks struct {
+	EventID   uuid.UUID       `json:"event_id"`
+	EventType string          `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+func (distributor *PostgresTaskDistributor) DistributeTaskDispatchWebhooks(ctx context.Context, payload *PayloadDispatchWebhooks, opts ...Option) error {
+	task, err := distributor.distribute(ctx, TaskDispatchWebhooks, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Int64("id", task.ID).
+		Str("type", task.Type).
+		Str("queue", task.Queue).
+		Msg("enqueued task")
+	return nil
+}
+
+// webhookEvent is an event to deliver to the endpoints of one user
+type webhookEvent struct {
+	owner     string
+	eventType string
+	eventID   uuid.UUID
+	data      json.RawMessage
+}
+
+// entryEventID derives the id of the entry.created event from the outbox event,
+// so dispatching the same outbox event again produces the same ids
+func entryEventID(outboxEventID uuid.UUID, entryID int64) uuid.UUID {
+	return uuid.NewSHA1(outboxEventID, []byte("entry:"+strconv.FormatInt(entryID, 10)))
+}
+
+func (processor *PostgresTaskProcessor) ProcessTaskDispatchWebhooks(ctx context.Context, task db.Task) error {
+	var payload PayloadDispatchWebhooks
+	if err := json.Unmarshal(task.Payload, &payload); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal payload: %v", ErrSkipRetry, err)
+	}
+
+	var events []webhookEvent
+	var err error
+
+	switch payload.EventType {
+	case db.EventTransferCompleted:
+		var transfer db.TransferCompletedPayload
+		if err := json.Unmarshal(payload.Payload, &transfer); err != nil {
+			return fmt.Errorf("%w: failed to unmarshal event: %v", ErrSkipRetry, err)
+		}
+
+		events, err = processor.transferWebhookEvents(ctx, payload, transfer)
+	case db.EventTransferReversed:
+		var reversal db.TransferReversedPayload
+		if err := json.Unmarshal(payload.Payload, &reversal); err != nil {
+			return fmt.Errorf("%w: failed to unmarshal event: %v", ErrSkipRetry, err)
+		}
+
+		events, err = processor.reversalWebhookEvents(ctx, payload, reversal)
+	case db.EventCashDeposited, db.EventCashWithdrawn:
+		var cash db.CashPayload
+		if err := json.Unmarshal(payload.Payload, &cash); err != nil {
+			return fmt.Errorf("%w: failed to unmarshal event: %v", ErrSkipRetry, err)
+		}
+
+		// cash events are not delivered themselves, subscribers get the entry.created event of the account
+		var event webhookEvent
+		event, _, err = processor.entryWebhookEvent(ctx, payload.EventID, cash.EntryID)
+		events = []webhookEvent{event}
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := processor.dispatchWebhookEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	log.Info().
+		Int64("id", task.ID).
+		Str("type", task.Type).
+		Str("event_id", payload.EventID.String()).
+		Msg("processed task")
+	return nil
+}
+
+// transferWebhookEvents returns the transfer.completed event for the owners of both accounts
+// and the entry.created event of each entry for the owner of its account
+func (processor *PostgresTaskProcessor) transferWebhookEvents(ctx context.Context, payload PayloadDispatchWebhooks, transfer db.TransferCompletedPayload) ([]webhookEvent, error) {
+	var events []webhookEvent
+	transferOwners := map[string]bool{}
+
+	for _, entryID := range []int64{transfer.FromEntryID, transfer.ToEntryID} {
+		entryEvent, account, err := processor.entryWebhookEvent(ctx, payload.EventID, entryID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !transferOwners[account.Owner] {
+			transferOwners[account.Owner] = true
+			events = append(events, webhookEvent{
+				owner:     account.Owner,
+				eventType: webhook.EventTransferCompleted,
+				eventID:   payload.EventID,
+				data:      payload.Payload,
+			})
+		}
+
+		events = append(events, entryEvent)
+	}
+
+	return events, nil
+}
+
+// reversalWebhookEvents returns the entry.created event of both legs of the reversal for the owner of its account,
+// the reversal itself is not an event endpoints can subscribe to
+func (processor *PostgresTaskProcessor) reversalWebhookEvents(ctx context.Context, payload PayloadDispatchWebhooks, reversal db.TransferReversedPayload) ([]webhookEvent, error) {
+	var events []webhookEvent
+
+	for _, entryID := range []int64{reversal.FromEntryID, reversal.ToEntryID} {
+		event, _, err := processor.entryWebhookEvent(ctx, payload.EventID, entryID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// entryWebhookEvent returns the entry.created event of the entry for the owner of its account, and the account
+func (processor *PostgresTaskProcessor) entryWebhookEvent(ctx context.Context, outboxEventID uuid.UUID, entryID int64) (webhookEvent, db.Account, error) {
+	entry, err := processor.store.GetEntry(ctx, entryID)
+	if err != nil {
+		return webhookEvent{}, db.Account{}, fmt.Errorf("failed to get entry: %w", err)
+	}
+
+	account, err := processor.store.GetAccount(ctx, entry.AccountID)
+	if err != nil {
+		return webhookEvent{}, db.Account{}, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	data, err := json.Marshal(webhook.NewEntryData(entry))
+	if err != nil {
+		return webhookEvent{}, db.Account{}, fmt.Errorf("failed to marshal entry: %w", err)
+	}
+
+	event := webhookEvent{
+		owner:     account.Owner,
+		eventType: webhook.EventEntryCreated,
+		eventID:   entryEventID(outboxEventID, entry.ID),
+		data:      data,
+	}
+
+	return event, account, nil
+}
+
+// dispatchWebhookEvent creates a delivery for every endpoint subscribed to the event and enqueues it.
+// Deliveries are unique per endpoint and event, a retried dispatch enqueues the pending ones again
+func (processor *PostgresTaskProcessor) dispatchWebhookEvent(ctx context.Context, event webhookEvent) error {
+	endpoints, err := processor.store.ListSubscribedWebhookEndpoints(ctx, db.ListSubscribedWebhookEndpointsParams{
+		Owner:     event.owner,
+		EventType: event.eventType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+
+	for _, endpoint := range endpoints {
+		delivery, err := processor.store.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+			EndpointID: endpoint.ID,
+			EventID:    event.eventID,
+			EventType:  event.eventType,
+			Payload:    event.data,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create webhook delivery: %w", err)
+		}
+
+		if delivery.Status != db.WebhookDeliveryPending {
+			continue
+		}
+
+		err = processor.distributor.DistributeTaskDeliverWebhook(ctx, &PayloadDeliverWebhook{DeliveryID: delivery.ID})
+		if err != nil {
+			return fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/mail"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendResetPassword = "task:send_reset_password"
+
+// PayloadSendResetPassword is the payload of the task sending the password reset email,
+// the username may not exist so the request reveals nothing about who has an account
+type PayloadSendResetPassword struct {
+	Username string `json:"username"`
+}
+
+func (distributor *PostgresTaskDistributor) DistributeTaskSendResetPassword(ctx context.Context, payload *PayloadSendResetPassword, opts ...Option) error {
+	task, err := distributor.distribute(ctx, TaskSendResetPassword, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Int64("id", task.ID).
+		Str("type", task.Type).
+		Str("queue", task.Queue).
+		Msg("enqueued task")
+	return nil
+}
+
+// ProcessTaskSendResetPassword creates the reset token and emails it. The token is only generated here,
+// so it is never stored in clear, and no new token is sent while the user has an unused and unexpired one
+func (processor *PostgresTaskProcessor) ProcessTaskSendResetPassword(ctx context.Context, task db.Task) error {
+	var payload PayloadSendResetPassword
+	if err := json.Unmarshal(task.Payload, &payload); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal payload: %v", ErrSkipRetry, err)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	resetToken, err := util.GenerateSecretToken()
+	if err != nil {
+		return fmt.Errorf("failed to create reset token: %w", err)
+	}
+
+	passwordReset, err := processor.store.CreatePasswordReset(ctx, db.CreatePasswordResetParams{
+		Username:  user.Username,
+		TokenHash: util.HashSecretToken(resetToken),
+		ExpiredAt: time.Now().Add(processor.config.PasswordResetDuration),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Info().
+				Int64("id", task.ID).
+				Str("type", task.Type).
+				Msg("password reset already pending")
+			return nil
+		}
+		return fmt.Errorf("failed to create password reset: %w", err)
+	}
+
+	link := mail.ResetPasswordLink(processor.config.ResetPasswordURL, resetToken)
+	subject, content := mail.NewResetPasswordEmail(user.FullName, link, passwordReset.ExpiredAt)
+
+	err = processor.mailer.SendEmail(subject, content, []string{user.Email})
+	if err != nil {
+		// the retry creates a new token, the pending reset would refuse it
+		if deleteErr := processor.store.DeletePasswordReset(ctx, passwordReset.ID); deleteErr != nil {
+			return fmt.Errorf("failed to send reset password email: %w, delete err: %v", err, deleteErr)
+		}
+		return fmt.Errorf("failed to send reset password email: %w", err)
+	}
+
+	log.Info().
+		Int64("id", task.ID).
+		Str("type", task.Type).
+		Str("email", user.Email).
+		Msg("processed task")
+	return nil
+}
@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	mockdb "github.com/Cell6969/go_bank/db/mock"
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// testMailer records the recipients of the emails instead of sending them
+type testMailer struct {
+	err  error
+	sent []string
+}
+
+func (mailer *testMailer) SendEmail(subject string, content string, to []string) error {
+	if mailer.err != nil {
+		return mailer.err
+	}
+	mailer.sent = append(mailer.sent, to...)
+	return nil
+}
+
+func TestProcessTaskSendResetPassword(t *testing.T) {
+	user := db.User{
+		Username: util.GenerateRandomName(),
+		FullName: util.GenerateRandomName(),
+		Email:    util.GenerateRandomEmail(),
+	}
+	passwordReset := db.PasswordReset{ID: 1, Username: user.Username, ExpiredAt: time.Now().Add(time.Minute)}
+
+	testCases := []struct {
+		name       string
+		mailErr    error
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+		sent       int
+	}{
+		{
+			name: "Sent",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					CreatePasswordReset(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreatePasswordResetParams) (db.PasswordReset, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.NotEmpty(t, arg.TokenHash)
+						return passwordReset, nil
+					})
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+			sent: 1,
+		},
+		{
+			name: "Unknown User",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Reset Pending",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(1).Return(db.PasswordReset{}, sql.ErrNoRows)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:    "Send Failed",
+			mailErr: errors.New("mail server unavailable"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(1).Return(passwordReset, nil)
+				// the reset is removed so the retry can create a new one
+				store.EXPECT().DeletePasswordReset(gomock.Any(), gomock.Eq(passwordReset.ID)).Times(1).Return(nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.NotErrorIs(t, err, ErrSkipRetry)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			mailer := &testMailer{err: tc.mailErr}
+			processor := newTestProcessor(store)
+			processor.mailer = mailer
+
+			payload, err := json.Marshal(PayloadSendResetPassword{Username: user.Username})
+			require.NoError(t, err)
+
+			err = processor.ProcessTaskSendResetPassword(context.Background(), db.Task{ID: 1, Type: TaskSendResetPassword, Payload: payload})
+			tc.checkError(t, err)
+			require.Len(t, mailer.sent, tc.sent)
+		})
+	}
+}
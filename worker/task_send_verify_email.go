@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/mail"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendVerifyEmail = "task:send_verify_email"
+
+// PayloadSendVerifyEmail is the payload of the task sending the verification email of a new user
+type PayloadSendVerifyEmail struct {
+	Username      string `json:"username"`
+	VerifyEmailID int64  `json:"verify_email_id"`
+}
+
+// NewTaskSendVerifyEmail builds the task sending the verification email,
+// CreateUserTx inserts it together with the user so neither is committed without the other
+func NewTaskSendVerifyEmail(payload *PayloadSendVerifyEmail, opts ...Option) (db.CreateTaskParams, error) {
+	return NewTask(TaskSendVerifyEmail, payload, opts...)
+}
+
+func (processor *PostgresTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, task db.Task) error {
+	var payload PayloadSendVerifyEmail
+	if err := json.Unmarshal(task.Payload, &payload); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal payload: %v", ErrSkipRetry, err)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: user doesn't exist", ErrSkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	verifyEmail, err := processor.store.GetVerifyEmail(ctx, payload.VerifyEmailID)
+	if err != nil {
+		return fmt.Errorf("failed to get verify email: %w", err)
+	}
+
+	if verifyEmail.IsUsed || user.IsEmailVerified {
+		return nil
+	}
+
+	link := mail.VerifyEmailLink(processor.config.VerifyEmailURL, verifyEmail.ID, verifyEmail.SecretCode)
+	subject, content := mail.NewVerifyEmail(user.FullName, link)
+
+	err = processor.mailer.SendEmail(subject, content, []string{verifyEmail.Email})
+	if err != nil {
+		return fmt.Errorf("failed to send verify email: %w", err)
+	}
+
+	log.Info().
+		Int64("id", task.ID).
+		Str("type", task.Type).
+		Str("email", verifyEmail.Email).
+		Msg("processed task")
+	return nil
+}
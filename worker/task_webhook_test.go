@@ -0,0 +1,445 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/Cell6969/go_bank/db/mock"
+	db "github.com/Cell6969/go_bank/db/sqlc"
+	"github.com/Cell6969/go_bank/webhook"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeliverWebhookTask(t *testing.T, deliveryID int64, attempts int32) db.Task {
+	payload, err := json.Marshal(PayloadDeliverWebhook{DeliveryID: deliveryID})
+	require.NoError(t, err)
+
+	return db.Task{
+		ID:          1,
+		Type:        TaskDeliverWebhook,
+		Payload:     payload,
+		Attempts:    attempts,
+		MaxAttempts: 3,
+	}
+}
+
+func TestProcessTaskDeliverWebhook(t *testing.T) {
+	secret, err := webhook.NewSecret()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		statusCode int
+		attempts   int32
+		isActive   bool
+		status     string
+		checkError func(t *testing.T, err error)
+		checkCalls func(t *testing.T, calls int)
+	}{
+		{
+			name:       "Succeeded",
+			statusCode: http.StatusOK,
+			attempts:   1,
+			isActive:   true,
+			status:     db.WebhookDeliverySucceeded,
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+			checkCalls: func(t *testing.T, calls int) {
+				require.Equal(t, 1, calls)
+			},
+		},
+		{
+			name:       "Retried",
+			statusCode: http.StatusInternalServerError,
+			attempts:   1,
+			isActive:   true,
+			status:     db.WebhookDeliveryPending,
+			checkError: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.NotErrorIs(t, err, ErrSkipRetry)
+			},
+			checkCalls: func(t *testing.T, calls int) {
+				require.Equal(t, 1, calls)
+			},
+		},
+		{
+			name:       "Out Of Attempts",
+			statusCode: http.StatusInternalServerError,
+			attempts:   3,
+			isActive:   true,
+			status:     db.WebhookDeliveryFailed,
+			checkError: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+			checkCalls: func(t *testing.T, calls int) {
+				require.Equal(t, 1, calls)
+			},
+		},
+		{
+			name:     "Endpoint Deleted",
+			attempts: 1,
+			isActive: false,
+			status:   db.WebhookDeliveryFailed,
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+			checkCalls: func(t *testing.T, calls int) {
+				require.Zero(t, calls)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				err = webhook.Verify(secret, r.Header.Get(webhook.HeaderTimestamp), r.Header.Get(webhook.HeaderSignature), body, time.Minute)
+				require.NoError(t, err)
+
+				w.WriteHeader(tc.statusCode)
+				w.Write([]byte("internal response"))
+			}))
+			defer receiver.Close()
+
+			endpoint := db.WebhookEndpoint{
+				ID:         1,
+				Owner:      "alice",
+				Url:        receiver.URL,
+				Secret:     secret,
+				EventTypes: []string{webhook.EventEntryCreated},
+				IsActive:   tc.isActive,
+			}
+			delivery := db.WebhookDelivery{
+				ID:         2,
+				EndpointID: endpoint.ID,
+				EventID:    uuid.New(),
+				EventType:  webhook.EventEntryCreated,
+				Payload:    json.RawMessage(`{"id":1}`),
+				Status:     db.WebhookDeliveryPending,
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetWebhookDelivery(gomock.Any(), gomock.Eq(delivery.ID)).
+				Times(1).
+				Return(delivery, nil)
+			store.EXPECT().
+				GetWebhookEndpoint(gomock.Any(), gomock.Eq(endpoint.ID)).
+				Times(1).
+				Return(endpoint, nil)
+
+			if tc.isActive {
+				store.EXPECT().
+					UpdateWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+						require.Equal(t, delivery.ID, arg.ID)
+						require.Equal(t, tc.status, arg.Status)
+						require.Equal(t, int32(tc.statusCode), arg.ResponseStatus.Int32)
+						require.Equal(t, tc.status == db.WebhookDeliverySucceeded, arg.DeliveredAt.Valid)
+						require.Equal(t, tc.status != db.WebhookDeliverySucceeded, arg.LastError.Valid)
+						// the owner of the endpoint only gets to see the status code
+						require.NotContains(t, arg.LastError.String, "internal response")
+						return delivery, nil
+					})
+			} else {
+				store.EXPECT().
+					FailWebhookDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(delivery, nil)
+			}
+
+			processor := newTestProcessor(store)
+			err := processor.ProcessTaskDeliverWebhook(context.Background(), newDeliverWebhookTask(t, delivery.ID, tc.attempts))
+			tc.checkError(t, err)
+			tc.checkCalls(t, calls)
+		})
+	}
+}
+
+func TestProcessTaskDeliverWebhookAlreadyDelivered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	delivery := db.WebhookDelivery{ID: 2, EndpointID: 1, Status: db.WebhookDeliverySucceeded}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetWebhookDelivery(gomock.Any(), gomock.Eq(delivery.ID)).
+		Times(1).
+		Return(delivery, nil)
+	store.EXPECT().GetWebhookEndpoint(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().UpdateWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).Times(0)
+
+	processor := newTestProcessor(store)
+	err := processor.ProcessTaskDeliverWebhook(context.Background(), newDeliverWebhookTask(t, delivery.ID, 1))
+	require.NoError(t, err)
+}
+
+func TestProcessTaskDispatchWebhooks(t *testing.T) {
+	fromAccount := db.Account{ID: 1, Owner: "alice", Currency: "USD"}
+	toAccount := db.Account{ID: 2, Owner: "bob", Currency: "USD"}
+	fromEntry := db.Entry{ID: 10, AccountID: fromAccount.ID, Amount: -100, ExchangeRate: "1"}
+	toEntry := db.Entry{ID: 11, AccountID: toAccount.ID, Amount: 100, ExchangeRate: "1"}
+
+	transfer, err := json.Marshal(db.TransferCompletedPayload{
+		TransferID:    5,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        100,
+		ExchangeRate:  "1",
+		ToAmount:      100,
+		FromEntryID:   fromEntry.ID,
+		ToEntryID:     toEntry.ID,
+	})
+	require.NoError(t, err)
+
+	eventID := uuid.New()
+	payload, err := json.Marshal(PayloadDispatchWebhooks{
+		EventID:   eventID,
+		EventType: db.EventTransferCompleted,
+		Payload:   transfer,
+	})
+	require.NoError(t, err)
+
+	aliceEndpoint := db.WebhookEndpoint{ID: 1, Owner: fromAccount.Owner, IsActive: true}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetEntry(gomock.Any(), gomock.Eq(fromEntry.ID)).Times(1).Return(fromEntry, nil)
+	store.EXPECT().GetEntry(gomock.Any(), gomock.Eq(toEntry.ID)).Times(1).Return(toEntry, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+
+	// only alice has an endpoint, subscribed to both event types
+	store.EXPECT().
+		ListSubscribedWebhookEndpoints(gomock.Any(), gomock.Any()).
+		Times(4).
+		DoAndReturn(func(_ context.Context, arg db.ListSubscribedWebhookEndpointsParams) ([]db.WebhookEndpoint, error) {
+			if arg.Owner == fromAccount.Owner {
+				return []db.WebhookEndpoint{aliceEndpoint}, nil
+			}
+			return nil, nil
+		})
+
+	var deliveries []db.CreateWebhookDeliveryParams
+	store.EXPECT().
+		CreateWebhookDelivery(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.CreateWebhookDeliveryParams) (db.WebhookDelivery, error) {
+			deliveries = append(deliveries, arg)
+			return db.WebhookDelivery{
+				ID:         int64(len(deliveries)),
+				EndpointID: arg.EndpointID,
+				EventID:    arg.EventID,
+				EventType:  arg.EventType,
+				Payload:    arg.Payload,
+				Status:     db.WebhookDeliveryPending,
+			}, nil
+		})
+
+	store.EXPECT().
+		CreateTask(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.CreateTaskParams) (db.Task, error) {
+			require.Equal(t, TaskDeliverWebhook, arg.Type)
+			return db.Task{ID: 1, Type: arg.Type, Queue: arg.Queue}, nil
+		})
+
+	processor := newTestProcessor(store)
+	err = processor.ProcessTaskDispatchWebhooks(context.Background(), db.Task{ID: 1, Type: TaskDispatchWebhooks, Payload: payload})
+	require.NoError(t, err)
+
+	require.Len(t, deliveries, 2)
+	require.Equal(t, webhook.EventTransferCompleted, deliveries[0].EventType)
+	require.Equal(t, eventID, deliveries[0].EventID)
+	require.JSONEq(t, string(transfer), string(deliveries[0].Payload))
+
+	require.Equal(t, webhook.EventEntryCreated, deliveries[1].EventType)
+	require.Equal(t, entryEventID(eventID, fromEntry.ID), deliveries[1].EventID)
+
+	var entry webhook.EntryData
+	require.NoError(t, json.Unmarshal(deliveries[1].Payload, &entry))
+	require.Equal(t, fromEntry.ID, entry.ID)
+	require.Equal(t, fromEntry.Amount, entry.Amount)
+}
+
+func TestProcessTaskDispatchWebhooksCash(t *testing.T) {
+	account := db.Account{ID: 1, Owner: "alice", Currency: "USD"}
+	entry := db.Entry{ID: 10, AccountID: account.ID, Amount: 100, ExchangeRate: "1"}
+
+	testCases := []struct {
+		name      string
+		eventType string
+	}{
+		{
+			name:      "Deposited",
+			eventType: db.EventCashDeposited,
+		},
+		{
+			name:      "Withdrawn",
+			eventType: db.EventCashWithdrawn,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			cash, err := json.Marshal(db.CashPayload{
+				CashTransactionID: 5,
+				AccountID:         account.ID,
+				Amount:            100,
+				EntryID:           entry.ID,
+			})
+			require.NoError(t, err)
+
+			eventID := uuid.New()
+			payload, err := json.Marshal(PayloadDispatchWebhooks{
+				EventID:   eventID,
+				EventType: tc.eventType,
+				Payload:   cash,
+			})
+			require.NoError(t, err)
+
+			endpoint := db.WebhookEndpoint{ID: 1, Owner: account.Owner, IsActive: true}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetEntry(gomock.Any(), gomock.Eq(entry.ID)).Times(1).Return(entry, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+			// the cash event itself is not delivered, only the entry.created event
+			store.EXPECT().
+				ListSubscribedWebhookEndpoints(gomock.Any(), gomock.Eq(db.ListSubscribedWebhookEndpointsParams{
+					Owner:     account.Owner,
+					EventType: webhook.EventEntryCreated,
+				})).
+				Times(1).
+				Return([]db.WebhookEndpoint{endpoint}, nil)
+
+			var delivery db.CreateWebhookDeliveryParams
+			store.EXPECT().
+				CreateWebhookDelivery(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, arg db.CreateWebhookDeliveryParams) (db.WebhookDelivery, error) {
+					delivery = arg
+					return db.WebhookDelivery{ID: 1, EndpointID: arg.EndpointID, Status: db.WebhookDeliveryPending}, nil
+				})
+
+			store.EXPECT().
+				CreateTask(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(db.Task{ID: 1, Type: TaskDeliverWebhook}, nil)
+
+			processor := newTestProcessor(store)
+			err = processor.ProcessTaskDispatchWebhooks(context.Background(), db.Task{ID: 1, Type: TaskDispatchWebhooks, Payload: payload})
+			require.NoError(t, err)
+
+			require.Equal(t, webhook.EventEntryCreated, delivery.EventType)
+			require.Equal(t, entryEventID(eventID, entry.ID), delivery.EventID)
+
+			var data webhook.EntryData
+			require.NoError(t, json.Unmarshal(delivery.Payload, &data))
+			require.Equal(t, entry.ID, data.ID)
+			require.Equal(t, entry.Amount, data.Amount)
+		})
+	}
+}
+
+func TestProcessTaskDispatchWebhooksReversal(t *testing.T) {
+	fromAccount := db.Account{ID: 1, Owner: "alice", Currency: "USD"}
+	toAccount := db.Account{ID: 2, Owner: "bob", Currency: "USD"}
+	fromEntry := db.Entry{ID: 20, AccountID: fromAccount.ID, Amount: 100, ExchangeRate: "1"}
+	toEntry := db.Entry{ID: 21, AccountID: toAccount.ID, Amount: -100, ExchangeRate: "1"}
+
+	reversal, err := json.Marshal(db.TransferReversedPayload{
+		ReversalID:  7,
+		TransferID:  5,
+		Amount:      100,
+		ToAmount:    100,
+		FromEntryID: fromEntry.ID,
+		ToEntryID:   toEntry.ID,
+	})
+	require.NoError(t, err)
+
+	eventID := uuid.New()
+	payload, err := json.Marshal(PayloadDispatchWebhooks{
+		EventID:   eventID,
+		EventType: db.EventTransferReversed,
+		Payload:   reversal,
+	})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetEntry(gomock.Any(), gomock.Eq(fromEntry.ID)).Times(1).Return(fromEntry, nil)
+	store.EXPECT().GetEntry(gomock.Any(), gomock.Eq(toEntry.ID)).Times(1).Return(toEntry, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+
+	// both owners have an endpoint, only entry.created is dispatched
+	store.EXPECT().
+		ListSubscribedWebhookEndpoints(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.ListSubscribedWebhookEndpointsParams) ([]db.WebhookEndpoint, error) {
+			require.Equal(t, webhook.EventEntryCreated, arg.EventType)
+			if arg.Owner == fromAccount.Owner {
+				return []db.WebhookEndpoint{{ID: 1, Owner: fromAccount.Owner, IsActive: true}}, nil
+			}
+			return []db.WebhookEndpoint{{ID: 2, Owner: toAccount.Owner, IsActive: true}}, nil
+		})
+
+	var deliveries []db.CreateWebhookDeliveryParams
+	store.EXPECT().
+		CreateWebhookDelivery(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.CreateWebhookDeliveryParams) (db.WebhookDelivery, error) {
+			deliveries = append(deliveries, arg)
+			return db.WebhookDelivery{ID: int64(len(deliveries)), EndpointID: arg.EndpointID, Status: db.WebhookDeliveryPending}, nil
+		})
+
+	store.EXPECT().
+		CreateTask(gomock.Any(), gomock.Any()).
+		Times(2).
+		Return(db.Task{ID: 1, Type: TaskDeliverWebhook}, nil)
+
+	processor := newTestProcessor(store)
+	err = processor.ProcessTaskDispatchWebhooks(context.Background(), db.Task{ID: 1, Type: TaskDispatchWebhooks, Payload: payload})
+	require.NoError(t, err)
+
+	require.Len(t, deliveries, 2)
+	for i, entry := range []db.Entry{fromEntry, toEntry} {
+		require.Equal(t, int64(i+1), deliveries[i].EndpointID)
+		require.Equal(t, webhook.EventEntryCreated, deliveries[i].EventType)
+		require.Equal(t, entryEventID(eventID, entry.ID), deliveries[i].EventID)
+
+		var data webhook.EntryData
+		require.NoError(t, json.Unmarshal(deliveries[i].Payload, &data))
+		require.Equal(t, entry.ID, data.ID)
+		require.Equal(t, entry.Amount, data.Amount)
+	}
+}
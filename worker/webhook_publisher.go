@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/Cell6969/go_bank/outbox"
+)
+
+// WebhookPublisher publishes outbox events to the webhook endpoints of the users,
+// it enqueues a task creating the deliveries so the relay doesn't wait for the endpoints
+type WebhookPublisher struct {
+	distributor TaskDistributor
+}
+
+// NewWebhookPublisher creates a new publisher enqueueing the webhook deliveries of the events
+func NewWebhookPublisher(distributor TaskDistributor) *WebhookPublisher {
+	return &WebhookPublisher{
+		distributor: distributor,
+	}
+}
+
+func (publisher *WebhookPublisher) Publish(ctx context.Context, event outbox.Event) error {
+	payload := &PayloadDispatchWebhooks{
+		EventID:   event.ID,
+		EventType: event.Type,
+		Payload:   event.Payload,
+	}
+	return publisher.distributor.DistributeTaskDispatchWebhooks(ctx, payload)
+}